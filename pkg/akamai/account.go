@@ -0,0 +1,140 @@
+package akamai
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+
+	"github.com/akamai/terraform-provider-akamai/v2/pkg/tools"
+)
+
+const (
+	// AccountKeyField is the name of the attribute added to every resource and data source
+	// which allows to override the provider account switch key
+	AccountKeyField = "account_key"
+
+	// importAccountKeyMarker separates the account switch key from the ID given to terraform import,
+	// as in <import ID>;account_key=<account switch key>
+	importAccountKeyMarker = ";" + AccountKeyField + "="
+)
+
+// accountKeyFromEnv returns the account switch key from AKAMAI_<SECTION>_ACCOUNT_KEY or AKAMAI_ACCOUNT_KEY
+func accountKeyFromEnv(section string) string {
+	if section != "" {
+		if v := os.Getenv(fmt.Sprintf("AKAMAI_%s_ACCOUNT_KEY", strings.ToUpper(section))); v != "" {
+			return v
+		}
+	}
+	return os.Getenv("AKAMAI_ACCOUNT_KEY")
+}
+
+// accountKeySchema returns the schema of the account key override attribute
+func accountKeySchema() *schema.Schema {
+	return &schema.Schema{
+		Type:        schema.TypeString,
+		Optional:    true,
+		Description: "The account switch key used for this object's API calls, overrides the provider account key",
+	}
+}
+
+// withAccountKeyOverride adds the account key attribute to the resource and wraps its functions,
+// so that all API calls made on behalf of the resource are signed for the configured account.
+// Changing the account key does not replace the object, it is updated and read with the new key.
+func withAccountKeyOverride(r *schema.Resource) error {
+	if _, ok := r.Schema[AccountKeyField]; ok {
+		return fmt.Errorf("%w: %s", ErrDuplicateSchemaKey, AccountKeyField)
+	}
+	r.Schema[AccountKeyField] = accountKeySchema()
+
+	// resources whose attributes all force a new resource have no update, changing their account key only reads them
+	if r.CreateContext != nil && r.UpdateContext == nil {
+		r.UpdateContext = func(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+			return r.ReadContext(ctx, d, m)
+		}
+	}
+
+	wrapCRUD(r, wrapAccountKeyCRUD)
+
+	if r.Importer != nil {
+		if r.Importer.StateContext != nil {
+			stateContext := r.Importer.StateContext
+			r.Importer.StateContext = func(ctx context.Context, d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
+				m, err := metaForImport(d, m)
+				if err != nil {
+					return nil, err
+				}
+				return stateContext(ctx, d, m)
+			}
+		}
+		if r.Importer.State != nil {
+			state := r.Importer.State
+			r.Importer.State = func(d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
+				m, err := metaForImport(d, m)
+				if err != nil {
+					return nil, err
+				}
+				return state(d, m)
+			}
+		}
+	}
+
+	if r.CustomizeDiff != nil {
+		customizeDiff := r.CustomizeDiff
+		r.CustomizeDiff = func(ctx context.Context, d *schema.ResourceDiff, m interface{}) error {
+			m, err := metaForAccount(d, m)
+			if err != nil {
+				return err
+			}
+			return customizeDiff(ctx, d, m)
+		}
+	}
+
+	return nil
+}
+
+func wrapAccountKeyCRUD(f crudFunc) crudFunc {
+	return func(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+		m, err := metaForAccount(d, m)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+		return f(ctx, d, m)
+	}
+}
+
+// metaForAccount returns the meta to be used for the account configured on the given object
+// the provider meta is returned when no override is set
+func metaForAccount(d tools.ResourceDataFetcher, m interface{}) (interface{}, error) {
+	accountKey, err := tools.GetStringValue(AccountKeyField, d)
+	if err != nil {
+		if errors.Is(err, tools.ErrNotFound) {
+			return m, nil
+		}
+		return nil, err
+	}
+
+	providerMeta, ok := m.(*meta)
+	if !ok || providerMeta.accountKey == accountKey {
+		return m, nil
+	}
+
+	return providerMeta.withAccountKey(accountKey)
+}
+
+// metaForImport removes the account switch key suffix from the imported ID and sets it as the account key of the
+// imported object, then returns the meta to be used for its account
+func metaForImport(d *schema.ResourceData, m interface{}) (interface{}, error) {
+	if i := strings.LastIndex(d.Id(), importAccountKeyMarker); i >= 0 {
+		accountKey := d.Id()[i+len(importAccountKeyMarker):]
+		d.SetId(d.Id()[:i])
+		if err := d.Set(AccountKeyField, accountKey); err != nil {
+			return nil, fmt.Errorf("%w: %s", tools.ErrValueSet, err.Error())
+		}
+	}
+	return metaForAccount(d, m)
+}
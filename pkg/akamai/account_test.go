@@ -0,0 +1,184 @@
+package akamai
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v2/pkg/edgegrid"
+	"github.com/hashicorp/go-hclog"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/stretchr/testify/require"
+	"github.com/tj/assert"
+)
+
+func TestWithAccountKeyOverride(t *testing.T) {
+	var accountKey string
+	readFunc := func(_ context.Context, _ *schema.ResourceData, m interface{}) diag.Diagnostics {
+		accountKey = m.(*meta).accountKey
+		return nil
+	}
+
+	providerMeta := &meta{
+		log:         hclog.NewNullLogger(),
+		creds:       &staticCredentials{edgerc: &edgegrid.Config{AccountKey: "provider-account"}},
+		sessBuilder: &sessionBuilder{},
+		accountKey:  "provider-account",
+	}
+
+	tests := map[string]struct {
+		givenData          map[string]interface{}
+		expectedAccountKey string
+	}{
+		"account key not set": {
+			givenData:          map[string]interface{}{"name": "test"},
+			expectedAccountKey: "provider-account",
+		},
+		"account key set": {
+			givenData:          map[string]interface{}{"name": "test", AccountKeyField: "resource-account"},
+			expectedAccountKey: "resource-account",
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			res := &schema.Resource{
+				ReadContext: readFunc,
+				Schema: map[string]*schema.Schema{
+					"name": {
+						Type:     schema.TypeString,
+						Optional: true,
+					},
+				},
+			}
+			require.NoError(t, withAccountKeyOverride(res))
+			assert.Nil(t, res.CreateContext)
+
+			d := schema.TestResourceDataRaw(t, res.Schema, test.givenData)
+			diags := res.ReadContext(context.Background(), d, providerMeta)
+			require.False(t, diags.HasError())
+			assert.Equal(t, test.expectedAccountKey, accountKey)
+		})
+	}
+}
+
+func TestWithAccountKeyOverride_Import(t *testing.T) {
+	providerMeta := &meta{
+		log:         hclog.NewNullLogger(),
+		creds:       &staticCredentials{edgerc: &edgegrid.Config{AccountKey: "provider-account"}},
+		sessBuilder: &sessionBuilder{},
+		accountKey:  "provider-account",
+	}
+
+	tests := map[string]struct {
+		givenID            string
+		expectedID         string
+		expectedAccountKey string
+		expectedState      string
+	}{
+		"no account key in ID": {
+			givenID:            "ehn_1,ctr_1,grp_1",
+			expectedID:         "ehn_1,ctr_1,grp_1",
+			expectedAccountKey: "provider-account",
+		},
+		"account key in ID": {
+			givenID:            "ehn_1,ctr_1,grp_1;account_key=1-ABCDE:1-FGHIJ",
+			expectedID:         "ehn_1,ctr_1,grp_1",
+			expectedAccountKey: "1-ABCDE:1-FGHIJ",
+			expectedState:      "1-ABCDE:1-FGHIJ",
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			var id, accountKey string
+			res := &schema.Resource{
+				Importer: &schema.ResourceImporter{
+					StateContext: func(_ context.Context, d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
+						id, accountKey = d.Id(), m.(*meta).accountKey
+						return []*schema.ResourceData{d}, nil
+					},
+				},
+				Schema: map[string]*schema.Schema{},
+			}
+			require.NoError(t, withAccountKeyOverride(res))
+
+			d := res.TestResourceData()
+			d.SetId(test.givenID)
+			imported, err := res.Importer.StateContext(context.Background(), d, providerMeta)
+			require.NoError(t, err)
+			assert.Equal(t, test.expectedID, id)
+			assert.Equal(t, test.expectedAccountKey, accountKey)
+			require.Len(t, imported, 1)
+			assert.Equal(t, test.expectedState, imported[0].Get(AccountKeyField))
+		})
+	}
+}
+
+func TestWithAccountKeyOverride_Update(t *testing.T) {
+	var reads int
+	noop := func(context.Context, *schema.ResourceData, interface{}) diag.Diagnostics { return nil }
+	res := &schema.Resource{
+		CreateContext: noop,
+		ReadContext: func(context.Context, *schema.ResourceData, interface{}) diag.Diagnostics {
+			reads++
+			return nil
+		},
+		DeleteContext: noop,
+		Schema: map[string]*schema.Schema{
+			"name": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+		},
+	}
+	require.NoError(t, withAccountKeyOverride(res))
+
+	assert.False(t, res.Schema[AccountKeyField].ForceNew)
+	require.NoError(t, res.InternalValidate(nil, true))
+	require.NotNil(t, res.UpdateContext)
+
+	d := schema.TestResourceDataRaw(t, res.Schema, map[string]interface{}{"name": "test", AccountKeyField: "provider-account"})
+	diags := res.UpdateContext(context.Background(), d, &meta{log: hclog.NewNullLogger(), accountKey: "provider-account"})
+	require.False(t, diags.HasError())
+	assert.Equal(t, 1, reads)
+}
+
+func TestWithAccountKeyOverride_DuplicateKey(t *testing.T) {
+	res := &schema.Resource{
+		Schema: map[string]*schema.Schema{
+			AccountKeyField: {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+		},
+	}
+	err := withAccountKeyOverride(res)
+	assert.True(t, errors.Is(err, ErrDuplicateSchemaKey))
+}
+
+func TestMetaWithAccountKey(t *testing.T) {
+	providerMeta := &meta{
+		log:         hclog.NewNullLogger(),
+		creds:       &staticCredentials{edgerc: &edgegrid.Config{Host: "host", AccountKey: "provider-account"}},
+		sessBuilder: &sessionBuilder{},
+	}
+
+	accountMeta, err := providerMeta.withAccountKey("resource-account")
+	require.NoError(t, err)
+
+	assert.Equal(t, "resource-account", accountMeta.accountKey)
+	assert.Equal(t, "provider-account", providerMeta.creds.config().AccountKey)
+	require.NotNil(t, accountMeta.sess)
+
+	req, err := http.NewRequest(http.MethodGet, "https://host/papi/v1/groups", nil)
+	require.NoError(t, err)
+	require.NoError(t, accountMeta.sess.Sign(req))
+	assert.Equal(t, "resource-account", req.URL.Query().Get("accountSwitchKey"))
+
+	assert.Equal(t, "key:test", providerMeta.cacheKey(testInst, "key"))
+	assert.Equal(t, "key:test:resource-account", accountMeta.cacheKey(testInst, "key"))
+}
@@ -0,0 +1,110 @@
+package akamai
+
+import (
+	"context"
+	"errors"
+	"math/rand"
+	"time"
+
+	"github.com/apex/log"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+
+	"github.com/akamai/terraform-provider-akamai/v2/pkg/tools"
+)
+
+type (
+	// ActivationPoller waits between the status checks of a long running activation or deactivation.
+	// The wait grows exponentially from the poll interval up to the maximum interval, with random jitter
+	// so that parallel activations do not check their status at the same time.
+	ActivationPoller struct {
+		interval    time.Duration
+		maxInterval time.Duration
+		log         log.Interface
+		name        string
+		start       time.Time
+		checks      int
+	}
+)
+
+const (
+	// PollIntervalField is the name of the attribute of activation resources setting the first poll interval
+	PollIntervalField = "poll_interval"
+
+	pollBackoffMultiplier = 1.5
+	pollJitter            = 0.2
+)
+
+var (
+	// DefaultActivationPollInterval is the first interval between status checks when poll_interval is not set
+	DefaultActivationPollInterval = 10 * time.Second
+
+	// MaxActivationPollInterval is the longest interval between status checks, unless poll_interval is longer
+	MaxActivationPollInterval = time.Minute
+)
+
+// PollIntervalSchema returns the schema of the poll_interval attribute of activation resources
+func PollIntervalSchema() *schema.Schema {
+	return &schema.Schema{
+		Type:         schema.TypeInt,
+		Optional:     true,
+		ValidateFunc: validation.IntAtLeast(1),
+		Description:  "The time in seconds before the first activation status check, the time between checks grows up to a minute",
+	}
+}
+
+// NewActivationPoller returns a poller for the named activation. The poll_interval of the resource is used
+// as the first interval, or defaultInterval if it is not set.
+func NewActivationPoller(d tools.ResourceDataFetcher, defaultInterval time.Duration, logger log.Interface, name string) (*ActivationPoller, error) {
+	interval := defaultInterval
+	seconds, err := tools.GetIntValue(PollIntervalField, d)
+	if err != nil && !errors.Is(err, tools.ErrNotFound) {
+		return nil, err
+	}
+	if err == nil && seconds > 0 {
+		interval = time.Duration(seconds) * time.Second
+	}
+
+	return &ActivationPoller{
+		interval:    interval,
+		maxInterval: tools.MaxDuration(interval, MaxActivationPollInterval),
+		log:         logger,
+		name:        name,
+		start:       time.Now(),
+	}, nil
+}
+
+// Wait reports the current status and waits until the next status check.
+// The context error is returned if the context is done before.
+func (p *ActivationPoller) Wait(ctx context.Context, status string) error {
+	wait := p.next()
+	p.log.WithFields(log.Fields{
+		"activation": p.name,
+		"status":     status,
+		"elapsed":    time.Since(p.start).Round(time.Second).String(),
+		"checks":     p.checks,
+	}).Infof("%s is %s, checking again in %s", p.name, status, wait.Round(time.Millisecond))
+
+	timer := time.NewTimer(wait)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		p.log.WithField("activation", p.name).Warnf("stopped waiting for %s with status %s: %s", p.name, status, ctx.Err())
+		return ctx.Err()
+	}
+}
+
+// next returns the time to wait before the next check and increases the interval
+func (p *ActivationPoller) next() time.Duration {
+	p.checks++
+	wait := p.interval
+	p.interval = time.Duration(float64(p.interval) * pollBackoffMultiplier)
+	if p.interval > p.maxInterval {
+		p.interval = p.maxInterval
+	}
+
+	jitter := time.Duration(float64(wait) * pollJitter * (2*rand.Float64() - 1))
+	return wait + jitter
+}
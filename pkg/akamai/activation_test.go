@@ -0,0 +1,93 @@
+package akamai
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/apex/log"
+	"github.com/apex/log/handlers/discard"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/stretchr/testify/require"
+	"github.com/tj/assert"
+)
+
+func TestNewActivationPoller(t *testing.T) {
+	resourceSchema := map[string]*schema.Schema{
+		PollIntervalField: PollIntervalSchema(),
+	}
+	logger := &log.Logger{Handler: discard.New()}
+
+	tests := map[string]struct {
+		givenData           map[string]interface{}
+		defaultInterval     time.Duration
+		expectedInterval    time.Duration
+		expectedMaxInterval time.Duration
+	}{
+		"default interval": {
+			givenData:           map[string]interface{}{},
+			defaultInterval:     DefaultActivationPollInterval,
+			expectedInterval:    DefaultActivationPollInterval,
+			expectedMaxInterval: MaxActivationPollInterval,
+		},
+		"poll_interval set": {
+			givenData:           map[string]interface{}{PollIntervalField: 30},
+			defaultInterval:     DefaultActivationPollInterval,
+			expectedInterval:    30 * time.Second,
+			expectedMaxInterval: MaxActivationPollInterval,
+		},
+		"poll_interval longer than max interval": {
+			givenData:           map[string]interface{}{PollIntervalField: 120},
+			defaultInterval:     DefaultActivationPollInterval,
+			expectedInterval:    2 * time.Minute,
+			expectedMaxInterval: 2 * time.Minute,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			d := schema.TestResourceDataRaw(t, resourceSchema, test.givenData)
+			poller, err := NewActivationPoller(d, test.defaultInterval, logger, "test")
+			require.NoError(t, err)
+			assert.Equal(t, test.expectedInterval, poller.interval)
+			assert.Equal(t, test.expectedMaxInterval, poller.maxInterval)
+		})
+	}
+}
+
+func TestActivationPollerNext(t *testing.T) {
+	poller := &ActivationPoller{interval: 10 * time.Second, maxInterval: time.Minute}
+
+	expected := []time.Duration{
+		10 * time.Second,
+		15 * time.Second,
+		22500 * time.Millisecond,
+		33750 * time.Millisecond,
+		50625 * time.Millisecond,
+		time.Minute,
+		time.Minute,
+	}
+	for i, interval := range expected {
+		wait := poller.next()
+		assert.GreaterOrEqual(t, int64(wait), int64(float64(interval)*(1-pollJitter)), "check %d", i)
+		assert.LessOrEqual(t, int64(wait), int64(float64(interval)*(1+pollJitter)), "check %d", i)
+	}
+	assert.Equal(t, len(expected), poller.checks)
+}
+
+func TestActivationPollerWait(t *testing.T) {
+	logger := &log.Logger{Handler: discard.New()}
+
+	t.Run("waits for the interval", func(t *testing.T) {
+		poller := &ActivationPoller{interval: time.Millisecond, maxInterval: time.Millisecond, log: logger, start: time.Now()}
+		assert.NoError(t, poller.Wait(context.Background(), "PENDING"))
+	})
+
+	t.Run("context done", func(t *testing.T) {
+		poller := &ActivationPoller{interval: time.Hour, maxInterval: time.Hour, log: logger, start: time.Now()}
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+		assert.True(t, errors.Is(poller.Wait(ctx, "PENDING"), context.Canceled))
+	})
+}
@@ -0,0 +1,450 @@
+package akamai
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/allegro/bigcache/v2"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+
+	"github.com/akamai/terraform-provider-akamai/v2/pkg/tools"
+)
+
+type (
+	// cacheBackend is implemented by the storages used by the operation cache
+	cacheBackend interface {
+		// Get returns the data stored for the key, ErrCacheEntryNotFound is returned for missing entries
+		// and errCacheEntryExpired for entries evicted because their time to live has passed
+		Get(key string) ([]byte, error)
+
+		// Set stores the data for the key for the given time
+		Set(key string, data []byte, ttl time.Duration) error
+	}
+
+	// cacheSettings holds the time to live of cache entries for each subprovider
+	// and the subproviders for which caching is disabled
+	cacheSettings struct {
+		defaultTTL     time.Duration
+		subproviderTTL map[string]time.Duration
+		disabled       map[string]bool
+	}
+
+	// cacheStats counts cache hits, misses and evictions per subprovider
+	cacheStats struct {
+		mu   sync.Mutex
+		subs map[string]*cacheCounters
+	}
+
+	cacheCounters struct {
+		hits      int
+		misses    int
+		evictions int
+	}
+
+	// memoryCache stores the entries in the process memory, they are lost when terraform command ends
+	memoryCache struct {
+		cache *bigcache.BigCache
+	}
+
+	// fileCache stores the entries in files, so they can be reused between terraform commands
+	fileCache struct {
+		dir       string
+		namespace string
+	}
+
+	fileCacheEntry struct {
+		Key     string          `json:"key"`
+		Expires time.Time       `json:"expires"`
+		Data    json.RawMessage `json:"data"`
+	}
+)
+
+const (
+	// CacheBackendMemory is the name of the in-memory cache backend
+	CacheBackendMemory = "memory"
+
+	// CacheBackendFile is the name of the file cache backend
+	CacheBackendFile = "file"
+
+	// DefaultCacheTTL is the default time to live of cache entries
+	DefaultCacheTTL = 10 * time.Minute
+
+	// memoryCacheLifeWindow is the upper bound of the time entries are kept in the memory cache,
+	// the entries expire according to their own time to live
+	memoryCacheLifeWindow = 24 * time.Hour
+
+	fileCacheExtension = ".json"
+)
+
+// cacheOptions returns the schema of the provider cache block
+func cacheOptions() *schema.Resource {
+	return &schema.Resource{
+		Schema: map[string]*schema.Schema{
+			"backend": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      CacheBackendMemory,
+				ValidateFunc: validation.StringInSlice([]string{CacheBackendMemory, CacheBackendFile}, false),
+				Description:  "The storage of cached API responses, either 'memory' or 'file'",
+			},
+			"directory": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "The directory used by the file backend, defaults to the terraform-provider-akamai directory in the user cache directory",
+			},
+			"ttl": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      int(DefaultCacheTTL / time.Second),
+				ValidateFunc: validation.IntAtLeast(1),
+				Description:  "The time in seconds cached entries are valid for",
+			},
+			"subprovider_ttl": {
+				Type:        schema.TypeMap,
+				Optional:    true,
+				Elem:        &schema.Schema{Type: schema.TypeInt},
+				Description: "The time in seconds cached entries are valid for, keyed by subprovider name",
+			},
+			"disabled_subproviders": {
+				Type:        schema.TypeSet,
+				Optional:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description: "The names of subproviders for which caching is disabled",
+			},
+		},
+	}
+}
+
+// getCacheConfig reads the cache block from the provider configuration and returns the selected backend
+// along with the per subprovider settings. The namespace is used to separate file cache entries of different credentials.
+func getCacheConfig(d *schema.ResourceData, subproviders map[string]Subprovider, namespace string) (cacheBackend, *cacheSettings, error) {
+	ttl := &cacheSettings{
+		defaultTTL:     DefaultCacheTTL,
+		subproviderTTL: make(map[string]time.Duration),
+		disabled:       make(map[string]bool),
+	}
+	memory := &memoryCache{cache: instance.cache}
+
+	cache, err := tools.GetSetValue("cache", d)
+	if err != nil {
+		if errors.Is(err, tools.ErrNotFound) {
+			return memory, ttl, nil
+		}
+		return nil, nil, err
+	}
+	if cache.Len() == 0 {
+		return memory, ttl, nil
+	}
+
+	cacheMap, ok := cache.List()[0].(map[string]interface{})
+	if !ok {
+		return nil, nil, fmt.Errorf("%w: %s, %q", tools.ErrInvalidType, "cache", "map[string]interface{}")
+	}
+	defaultTTL, ok := cacheMap["ttl"].(int)
+	if !ok {
+		return nil, nil, fmt.Errorf("%w: %s, %q", tools.ErrInvalidType, "ttl", "int")
+	}
+	ttl.defaultTTL = time.Duration(defaultTTL) * time.Second
+
+	subproviderTTL, ok := cacheMap["subprovider_ttl"].(map[string]interface{})
+	if !ok && cacheMap["subprovider_ttl"] != nil {
+		return nil, nil, fmt.Errorf("%w: %s, %q", tools.ErrInvalidType, "subprovider_ttl", "map[string]interface{}")
+	}
+	for name, val := range subproviderTTL {
+		if _, ok := subproviders[name]; !ok {
+			return nil, nil, fmt.Errorf("cache: unknown subprovider %q, expected one of: %s", name, strings.Join(subproviderNames(subproviders), ", "))
+		}
+		seconds, ok := val.(int)
+		if !ok {
+			return nil, nil, fmt.Errorf("%w: %s, %q", tools.ErrInvalidType, name, "int")
+		}
+		if seconds <= 0 {
+			return nil, nil, fmt.Errorf("cache: ttl for subprovider %q must be greater than 0", name)
+		}
+		ttl.subproviderTTL[name] = time.Duration(seconds) * time.Second
+	}
+
+	if disabled, ok := cacheMap["disabled_subproviders"].(*schema.Set); ok {
+		for _, val := range disabled.List() {
+			name, ok := val.(string)
+			if !ok {
+				return nil, nil, fmt.Errorf("%w: %s, %q", tools.ErrInvalidType, "disabled_subproviders", "string")
+			}
+			if _, ok := subproviders[name]; !ok {
+				return nil, nil, fmt.Errorf("cache: unknown subprovider %q, expected one of: %s", name, strings.Join(subproviderNames(subproviders), ", "))
+			}
+			ttl.disabled[name] = true
+		}
+	}
+
+	backend, _ := cacheMap["backend"].(string)
+	if backend != CacheBackendFile {
+		return memory, ttl, nil
+	}
+
+	dir, _ := cacheMap["directory"].(string)
+	fc, err := newFileCache(dir, namespace)
+	if err != nil {
+		return nil, nil, err
+	}
+	return fc, ttl, nil
+}
+
+// ttl returns the time to live of the subprovider entries
+func (c *cacheSettings) ttl(name string) time.Duration {
+	if ttl, ok := c.subproviderTTL[name]; ok {
+		return ttl
+	}
+	return c.defaultTTL
+}
+
+// enabled returns false when caching is disabled for the subprovider
+func (c *cacheSettings) enabled(name string) bool {
+	return !c.disabled[name]
+}
+
+func newCacheStats() *cacheStats {
+	return &cacheStats{subs: make(map[string]*cacheCounters)}
+}
+
+func (s *cacheStats) counters(name string) *cacheCounters {
+	c, ok := s.subs[name]
+	if !ok {
+		c = &cacheCounters{}
+		s.subs[name] = c
+	}
+	return c
+}
+
+func (s *cacheStats) hit(name string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.counters(name).hits++
+}
+
+func (s *cacheStats) miss(name string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.counters(name).misses++
+}
+
+func (s *cacheStats) evict(name string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.counters(name).evictions++
+}
+
+// get returns a copy of the subprovider counters
+func (s *cacheStats) get(name string) cacheCounters {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if c, ok := s.subs[name]; ok {
+		return *c
+	}
+	return cacheCounters{}
+}
+
+// withCacheStatsLogging wraps the resource functions, so that the cache statistics of the subprovider
+// are logged when the function returns
+func withCacheStatsLogging(r *schema.Resource, name string) {
+	wrapCRUD(r, func(f crudFunc) crudFunc {
+		return func(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+			diags := f(ctx, d, m)
+			if providerMeta, ok := m.(*meta); ok {
+				providerMeta.logCacheStats(name)
+			}
+			return diags
+		}
+	})
+}
+
+// newMemoryCacheStorage returns the bigcache instance shared by all memory caches of the provider
+func newMemoryCacheStorage() (*bigcache.BigCache, error) {
+	return bigcache.NewBigCache(bigcache.DefaultConfig(memoryCacheLifeWindow))
+}
+
+// Get returns the entry from the memory cache
+func (c *memoryCache) Get(key string) ([]byte, error) {
+	data, err := c.cache.Get(key)
+	if err != nil {
+		if errors.Is(err, bigcache.ErrEntryNotFound) {
+			return nil, ErrCacheEntryNotFound
+		}
+		return nil, err
+	}
+	if len(data) < 8 {
+		return nil, ErrCacheEntryNotFound
+	}
+
+	expires := time.Unix(0, int64(binary.BigEndian.Uint64(data[:8])))
+	if time.Now().After(expires) {
+		if err := c.cache.Delete(key); err != nil && !errors.Is(err, bigcache.ErrEntryNotFound) {
+			return nil, err
+		}
+		return nil, errCacheEntryExpired
+	}
+
+	return data[8:], nil
+}
+
+// Set stores the entry in the memory cache, prefixed with its expiration time
+func (c *memoryCache) Set(key string, data []byte, ttl time.Duration) error {
+	entry := make([]byte, 8+len(data))
+	binary.BigEndian.PutUint64(entry[:8], uint64(time.Now().Add(ttl).UnixNano()))
+	copy(entry[8:], data)
+
+	return c.cache.Set(key, entry)
+}
+
+// newFileCache returns a file cache storing entries in the directory, expired entries are removed from the directory
+func newFileCache(dir, namespace string) (*fileCache, error) {
+	if dir == "" {
+		userCacheDir, err := os.UserCacheDir()
+		if err != nil {
+			return nil, fmt.Errorf("cache: unable to determine the user cache directory: %w", err)
+		}
+		dir = filepath.Join(userCacheDir, ProviderName)
+	} else if strings.HasPrefix(dir, "~/") {
+		home, err := os.UserHomeDir()
+		if err != nil {
+			return nil, fmt.Errorf("cache: unable to determine the home directory: %w", err)
+		}
+		dir = filepath.Join(home, dir[2:])
+	}
+
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		return nil, fmt.Errorf("cache: unable to create cache directory: %w", err)
+	}
+
+	c := &fileCache{
+		dir:       dir,
+		namespace: namespace,
+	}
+	if err := c.prune(); err != nil {
+		return nil, err
+	}
+
+	return c, nil
+}
+
+// Get reads the entry from its file
+func (c *fileCache) Get(key string) ([]byte, error) {
+	path := c.path(key)
+	entry, err := readFileCacheEntry(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, ErrCacheEntryNotFound
+		}
+		// unreadable entries are dropped, they will be replaced by the next Set
+		if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+			return nil, err
+		}
+		return nil, ErrCacheEntryNotFound
+	}
+
+	// the file name is a hash of the key, so the key is compared to rule out collisions
+	if entry.Key != c.namespacedKey(key) {
+		return nil, ErrCacheEntryNotFound
+	}
+	if time.Now().After(entry.Expires) {
+		if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+			return nil, err
+		}
+		return nil, errCacheEntryExpired
+	}
+
+	return entry.Data, nil
+}
+
+// Set writes the entry to a temporary file which then replaces the entry file, so that readers never see partial entries
+func (c *fileCache) Set(key string, data []byte, ttl time.Duration) error {
+	content, err := json.Marshal(fileCacheEntry{
+		Key:     c.namespacedKey(key),
+		Expires: time.Now().Add(ttl),
+		Data:    data,
+	})
+	if err != nil {
+		return err
+	}
+
+	tmp, err := ioutil.TempFile(c.dir, "*.tmp")
+	if err != nil {
+		return err
+	}
+	if _, err := tmp.Write(content); err != nil {
+		_ = tmp.Close()
+		_ = os.Remove(tmp.Name())
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		_ = os.Remove(tmp.Name())
+		return err
+	}
+
+	return os.Rename(tmp.Name(), c.path(key))
+}
+
+// prune removes expired entries of all namespaces from the cache directory
+func (c *fileCache) prune() error {
+	files, err := ioutil.ReadDir(c.dir)
+	if err != nil {
+		return fmt.Errorf("cache: unable to read cache directory: %w", err)
+	}
+
+	now := time.Now()
+	for _, f := range files {
+		if f.IsDir() || filepath.Ext(f.Name()) != fileCacheExtension {
+			continue
+		}
+		path := filepath.Join(c.dir, f.Name())
+		entry, err := readFileCacheEntry(path)
+		if err == nil && now.Before(entry.Expires) {
+			continue
+		}
+		if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+			return fmt.Errorf("cache: unable to remove expired entry: %w", err)
+		}
+	}
+	return nil
+}
+
+func (c *fileCache) namespacedKey(key string) string {
+	return fmt.Sprintf("%s:%s", c.namespace, key)
+}
+
+func (c *fileCache) path(key string) string {
+	sum := sha256.Sum256([]byte(c.namespacedKey(key)))
+	return filepath.Join(c.dir, hex.EncodeToString(sum[:])+fileCacheExtension)
+}
+
+func readFileCacheEntry(path string) (*fileCacheEntry, error) {
+	content, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var entry fileCacheEntry
+	if err := json.Unmarshal(content, &entry); err != nil {
+		return nil, fmt.Errorf("cache: invalid entry %s: %w", filepath.Base(path), err)
+	}
+	return &entry, nil
+}
+
+// cacheNamespace returns an identifier of the credentials and provider version,
+// so that cache entries are never shared between different API clients or provider releases
+func cacheNamespace(providerVersion, host, clientToken, accessToken string) string {
+	sum := sha256.Sum256([]byte(strings.Join([]string{providerVersion, host, clientToken, accessToken}, "\x00")))
+	return hex.EncodeToString(sum[:8])
+}
@@ -2,14 +2,20 @@ package akamai
 
 import (
 	"context"
+	"io/ioutil"
+	"os"
+	"path/filepath"
 	"regexp"
 	"testing"
+	"time"
 
 	"github.com/apex/log"
 	"github.com/hashicorp/go-hclog"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/stretchr/testify/require"
+	"github.com/tj/assert"
 )
 
 type (
@@ -285,3 +291,223 @@ func (d *cacheSubprovider) Configure(log log.Interface, _ *schema.ResourceData)
 
 	return nil
 }
+
+func TestMemoryCache(t *testing.T) {
+	storage, err := newMemoryCacheStorage()
+	require.NoError(t, err)
+	c := &memoryCache{cache: storage}
+
+	_, err = c.Get("foo")
+	assert.Equal(t, ErrCacheEntryNotFound, err)
+
+	require.NoError(t, c.Set("foo", []byte(`"bar"`), time.Minute))
+	data, err := c.Get("foo")
+	require.NoError(t, err)
+	assert.Equal(t, `"bar"`, string(data))
+
+	require.NoError(t, c.Set("expired", []byte(`"bar"`), -time.Second))
+	_, err = c.Get("expired")
+	assert.Equal(t, errCacheEntryExpired, err)
+	_, err = c.Get("expired")
+	assert.Equal(t, ErrCacheEntryNotFound, err)
+}
+
+func TestFileCache(t *testing.T) {
+	dir, err := ioutil.TempDir("", "akamai-cache")
+	require.NoError(t, err)
+	defer func() {
+		require.NoError(t, os.RemoveAll(dir))
+	}()
+
+	c, err := newFileCache(dir, "ns1")
+	require.NoError(t, err)
+
+	_, err = c.Get("foo")
+	assert.Equal(t, ErrCacheEntryNotFound, err)
+
+	require.NoError(t, c.Set("foo", []byte(`{"bar":1}`), time.Minute))
+	require.NoError(t, c.Set("expired", []byte(`"bar"`), -time.Second))
+
+	data, err := c.Get("foo")
+	require.NoError(t, err)
+	assert.Equal(t, `{"bar":1}`, string(data))
+
+	_, err = c.Get("expired")
+	assert.Equal(t, errCacheEntryExpired, err)
+	_, err = os.Stat(c.path("expired"))
+	assert.True(t, os.IsNotExist(err))
+
+	// entries are not shared between namespaces
+	other, err := newFileCache(dir, "ns2")
+	require.NoError(t, err)
+	_, err = other.Get("foo")
+	assert.Equal(t, ErrCacheEntryNotFound, err)
+
+	// entries survive a new cache instance, expired ones are pruned
+	require.NoError(t, c.Set("expired", []byte(`"bar"`), -time.Second))
+	reopened, err := newFileCache(dir, "ns1")
+	require.NoError(t, err)
+	data, err = reopened.Get("foo")
+	require.NoError(t, err)
+	assert.Equal(t, `{"bar":1}`, string(data))
+	files, err := filepath.Glob(filepath.Join(dir, "*"+fileCacheExtension))
+	require.NoError(t, err)
+	assert.Len(t, files, 1)
+
+	// corrupted entries are treated as a miss
+	require.NoError(t, ioutil.WriteFile(c.path("foo"), []byte("not json"), 0600))
+	_, err = c.Get("foo")
+	assert.Equal(t, ErrCacheEntryNotFound, err)
+}
+
+func TestGetCacheConfig(t *testing.T) {
+	resourceSchema := map[string]*schema.Schema{
+		"cache": {
+			Type:     schema.TypeSet,
+			Optional: true,
+			Elem:     cacheOptions(),
+		},
+	}
+	subproviders := map[string]Subprovider{"test": testInst}
+
+	dir, err := ioutil.TempDir("", "akamai-cache")
+	require.NoError(t, err)
+	defer func() {
+		require.NoError(t, os.RemoveAll(dir))
+	}()
+
+	tests := map[string]struct {
+		givenData        map[string]interface{}
+		expectedBackend  cacheBackend
+		expectedTTL      time.Duration
+		expectedDisabled bool
+		withError        bool
+	}{
+		"cache not configured": {
+			givenData:       map[string]interface{}{},
+			expectedBackend: &memoryCache{},
+			expectedTTL:     DefaultCacheTTL,
+		},
+		"file cache with subprovider ttl": {
+			givenData: map[string]interface{}{
+				"cache": []interface{}{map[string]interface{}{
+					"backend":         CacheBackendFile,
+					"directory":       dir,
+					"ttl":             60,
+					"subprovider_ttl": map[string]interface{}{"test": 3600},
+				}},
+			},
+			expectedBackend: &fileCache{},
+			expectedTTL:     time.Hour,
+		},
+		"disabled subprovider": {
+			givenData: map[string]interface{}{
+				"cache": []interface{}{map[string]interface{}{
+					"disabled_subproviders": []interface{}{"test"},
+				}},
+			},
+			expectedBackend:  &memoryCache{},
+			expectedTTL:      DefaultCacheTTL,
+			expectedDisabled: true,
+		},
+		"unknown subprovider": {
+			givenData: map[string]interface{}{
+				"cache": []interface{}{map[string]interface{}{
+					"subprovider_ttl": map[string]interface{}{"foo": 3600},
+				}},
+			},
+			withError: true,
+		},
+		"unknown disabled subprovider": {
+			givenData: map[string]interface{}{
+				"cache": []interface{}{map[string]interface{}{
+					"disabled_subproviders": []interface{}{"foo"},
+				}},
+			},
+			withError: true,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			d := schema.TestResourceDataRaw(t, resourceSchema, test.givenData)
+			backend, ttl, err := getCacheConfig(d, subproviders, "ns")
+			if test.withError {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.IsType(t, test.expectedBackend, backend)
+			assert.Equal(t, test.expectedTTL, ttl.ttl(testInst.Name()))
+			assert.Equal(t, !test.expectedDisabled, ttl.enabled(testInst.Name()))
+		})
+	}
+}
+
+func TestMetaCacheStats(t *testing.T) {
+	storage, err := newMemoryCacheStorage()
+	require.NoError(t, err)
+
+	m := &meta{
+		log:          hclog.NewNullLogger(),
+		cacheEnabled: true,
+		cache:        &memoryCache{cache: storage},
+		cacheSettings: &cacheSettings{
+			defaultTTL:     time.Minute,
+			subproviderTTL: map[string]time.Duration{},
+			disabled:       map[string]bool{},
+		},
+		cacheStats: newCacheStats(),
+	}
+
+	var out string
+	assert.Equal(t, ErrCacheEntryNotFound, m.CacheGet(testInst, "stats", &out))
+	require.NoError(t, m.CacheSet(testInst, "stats", "value"))
+	require.NoError(t, m.CacheGet(testInst, "stats", &out))
+	assert.Equal(t, "value", out)
+
+	m.cacheSettings.subproviderTTL[testInst.Name()] = -time.Second
+	require.NoError(t, m.CacheSet(testInst, "expired", "value"))
+	assert.Equal(t, ErrCacheEntryNotFound, m.CacheGet(testInst, "expired", &out))
+
+	assert.Equal(t, cacheCounters{hits: 1, misses: 2, evictions: 1}, m.cacheStats.get(testInst.Name()))
+
+	m.cacheSettings.disabled[testInst.Name()] = true
+	assert.Equal(t, ErrCacheDisabled, m.CacheGet(testInst, "stats", &out))
+	assert.Equal(t, ErrCacheDisabled, m.CacheSet(testInst, "stats", "value"))
+}
+
+func TestMetaRunCache(t *testing.T) {
+	storage, err := newMemoryCacheStorage()
+	require.NoError(t, err)
+	dir, err := ioutil.TempDir("", "akamai-cache")
+	require.NoError(t, err)
+	defer func() {
+		require.NoError(t, os.RemoveAll(dir))
+	}()
+	files, err := newFileCache(dir, "ns")
+	require.NoError(t, err)
+
+	m := &meta{
+		log:          hclog.NewNullLogger(),
+		cacheEnabled: true,
+		cache:        files,
+		runCache:     &memoryCache{cache: storage},
+		cacheSettings: &cacheSettings{
+			defaultTTL:     time.Minute,
+			subproviderTTL: map[string]time.Duration{},
+			disabled:       map[string]bool{},
+		},
+		cacheStats: newCacheStats(),
+	}
+
+	var out string
+	require.NoError(t, m.RunCacheSet(testInst, "version", "value"))
+	require.NoError(t, m.RunCacheGet(testInst, "version", &out))
+	assert.Equal(t, "value", out)
+	assert.Equal(t, ErrCacheEntryNotFound, m.CacheGet(testInst, "version", &out))
+
+	entries, err := filepath.Glob(filepath.Join(files.dir, "*"+fileCacheExtension))
+	require.NoError(t, err)
+	assert.Empty(t, entries)
+}
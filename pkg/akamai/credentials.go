@@ -0,0 +1,328 @@
+package akamai
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"os/exec"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v2/pkg/edgegrid"
+	"github.com/apex/log"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+
+	"github.com/akamai/terraform-provider-akamai/v2/pkg/tools"
+)
+
+type (
+	// credentials provides the EdgeGrid configuration used to sign requests
+	credentials interface {
+		config() *edgegrid.Config
+	}
+
+	// staticCredentials are loaded once from the edgerc file, env variables or the credential files
+	staticCredentials struct {
+		edgerc *edgegrid.Config
+	}
+
+	// processCredentials are returned by an external command and loaded again when they expire
+	processCredentials struct {
+		command string
+		args    []string
+		log     log.Interface
+
+		mu      sync.Mutex
+		edgerc  *edgegrid.Config
+		expires time.Time
+		// refreshing is closed when the running refresh ends, it is nil when no refresh is running
+		refreshing chan struct{}
+	}
+
+	// processCredentialsOutput is the JSON document the credential process writes to its standard output
+	processCredentialsOutput struct {
+		Host         string     `json:"host"`
+		ClientToken  string     `json:"client_token"`
+		ClientSecret string     `json:"client_secret"`
+		AccessToken  string     `json:"access_token"`
+		AccountKey   string     `json:"account_key"`
+		MaxBody      int        `json:"max_body"`
+		Expiration   *time.Time `json:"expiration"`
+	}
+
+	// accountSigner signs requests with the current credentials, optionally overriding the account switch key
+	accountSigner struct {
+		creds      credentials
+		accountKey string
+	}
+)
+
+const (
+	// credentialProcessTimeout is the time the credential process has to return the credentials
+	credentialProcessTimeout = time.Minute
+
+	// credentialExpiryWindow is the time before the expiration at which the credentials are refreshed
+	credentialExpiryWindow = time.Minute
+)
+
+var (
+	// ErrCredentialProcess is returned when credentials cannot be obtained from the credential process
+	ErrCredentialProcess = errors.New("credential process")
+
+	// ErrCredentialFiles is returned when credentials cannot be read from the credential files
+	ErrCredentialFiles = errors.New("credential files")
+)
+
+// credentialProcessOptions returns the schema of the provider credential_process block
+func credentialProcessOptions() *schema.Resource {
+	return &schema.Resource{
+		Schema: map[string]*schema.Schema{
+			"command": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "The command returning the credentials as a JSON object on its standard output",
+			},
+			"args": {
+				Type:        schema.TypeList,
+				Optional:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description: "The arguments of the command",
+			},
+		},
+	}
+}
+
+// credentialFilesOptions returns the schema of the provider credential_files block
+func credentialFilesOptions() *schema.Resource {
+	return &schema.Resource{
+		Schema: map[string]*schema.Schema{
+			"host": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "The path of the file containing the host",
+			},
+			"client_token": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "The path of the file containing the client token",
+			},
+			"client_secret": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "The path of the file containing the client secret",
+			},
+			"access_token": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "The path of the file containing the access token",
+			},
+			"account_key": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "The path of the file containing the account switch key",
+			},
+		},
+	}
+}
+
+// getExternalCredentials returns the credentials configured with either credential_process or credential_files,
+// nil is returned when none of them is configured
+func getExternalCredentials(ctx context.Context, d *schema.ResourceData, logger log.Interface) (credentials, error) {
+	process, err := getSingleBlock(d, "credential_process")
+	if err != nil {
+		return nil, err
+	}
+	files, err := getSingleBlock(d, "credential_files")
+	if err != nil {
+		return nil, err
+	}
+
+	switch {
+	case process != nil && files != nil:
+		return nil, fmt.Errorf("only one of credential_process and credential_files can be specified")
+	case process != nil:
+		command, ok := process["command"].(string)
+		if !ok {
+			return nil, fmt.Errorf("%w: %s, %q", tools.ErrInvalidType, "command", "string")
+		}
+		rawArgs, _ := process["args"].([]interface{})
+		args := make([]string, 0, len(rawArgs))
+		for _, arg := range rawArgs {
+			s, ok := arg.(string)
+			if !ok {
+				return nil, fmt.Errorf("%w: %s, %q", tools.ErrInvalidType, "args", "string")
+			}
+			args = append(args, s)
+		}
+		creds := &processCredentials{
+			command: command,
+			args:    args,
+			log:     logger,
+		}
+		if err := creds.refresh(ctx); err != nil {
+			return nil, err
+		}
+		return creds, nil
+	case files != nil:
+		edgerc, err := readCredentialFiles(files)
+		if err != nil {
+			return nil, err
+		}
+		return &staticCredentials{edgerc: edgerc}, nil
+	}
+
+	return nil, nil
+}
+
+// getSingleBlock returns the attributes of a block with at most one item, nil is returned when the block is not set
+func getSingleBlock(d *schema.ResourceData, key string) (map[string]interface{}, error) {
+	set, err := tools.GetSetValue(key, d)
+	if err != nil {
+		if errors.Is(err, tools.ErrNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	if set.Len() == 0 {
+		return nil, nil
+	}
+	block, ok := set.List()[0].(map[string]interface{})
+	if !ok {
+		return nil, fmt.Errorf("%w: %s, %q", tools.ErrInvalidType, key, "map[string]interface{}")
+	}
+	return block, nil
+}
+
+// readCredentialFiles reads each of the credential values from its own file
+func readCredentialFiles(files map[string]interface{}) (*edgegrid.Config, error) {
+	values := make(map[string]string, len(files))
+	for _, key := range []string{"host", "client_token", "client_secret", "access_token", "account_key"} {
+		path, _ := files[key].(string)
+		if path == "" {
+			continue
+		}
+		content, err := ioutil.ReadFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("%w: reading %s: %s", ErrCredentialFiles, key, err)
+		}
+		values[key] = strings.TrimSpace(string(content))
+		if values[key] == "" {
+			return nil, fmt.Errorf("%w: file for %s is empty", ErrCredentialFiles, key)
+		}
+	}
+
+	edgerc := &edgegrid.Config{
+		Host:         values["host"],
+		ClientToken:  values["client_token"],
+		ClientSecret: values["client_secret"],
+		AccessToken:  values["access_token"],
+		AccountKey:   values["account_key"],
+		MaxBody:      edgegrid.MaxBodySize,
+	}
+	if err := edgerc.Validate(); err != nil {
+		return nil, fmt.Errorf("%w: %s", ErrCredentialFiles, err)
+	}
+	return edgerc, nil
+}
+
+func (c *staticCredentials) config() *edgegrid.Config {
+	return c.edgerc
+}
+
+// config returns the current credentials, running the credential process again when they are about to expire.
+// Only one refresh runs at a time, concurrent callers wait for it and use its result.
+// If the refresh fails, the previous credentials are returned and the API will reject the request.
+func (c *processCredentials) config() *edgegrid.Config {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.expires.IsZero() || !time.Now().Add(credentialExpiryWindow).After(c.expires) {
+		return c.edgerc
+	}
+
+	if done := c.refreshing; done != nil {
+		c.mu.Unlock()
+		<-done
+		c.mu.Lock()
+		return c.edgerc
+	}
+
+	done := make(chan struct{})
+	c.refreshing = done
+	c.mu.Unlock()
+	if err := c.refresh(context.Background()); err != nil {
+		c.log.WithError(err).Error("failed to refresh expired credentials")
+	}
+	c.mu.Lock()
+	c.refreshing = nil
+	close(done)
+	return c.edgerc
+}
+
+// refresh runs the credential process and stores the returned credentials
+func (c *processCredentials) refresh(ctx context.Context) error {
+	ctx, cancel := context.WithTimeout(ctx, credentialProcessTimeout)
+	defer cancel()
+
+	var stdout, stderr bytes.Buffer
+	cmd := exec.CommandContext(ctx, c.command, c.args...)
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		return fmt.Errorf("%w: running %q: %s: %s", ErrCredentialProcess, c.command, err, strings.TrimSpace(stderr.String()))
+	}
+
+	var out processCredentialsOutput
+	if err := json.Unmarshal(stdout.Bytes(), &out); err != nil {
+		return fmt.Errorf("%w: invalid output: %s", ErrCredentialProcess, err)
+	}
+	for name, val := range map[string]string{
+		"host":          out.Host,
+		"client_token":  out.ClientToken,
+		"client_secret": out.ClientSecret,
+		"access_token":  out.AccessToken,
+	} {
+		if val == "" {
+			return fmt.Errorf("%w: output is missing %q", ErrCredentialProcess, name)
+		}
+	}
+
+	edgerc := &edgegrid.Config{
+		Host:         out.Host,
+		ClientToken:  out.ClientToken,
+		ClientSecret: out.ClientSecret,
+		AccessToken:  out.AccessToken,
+		AccountKey:   out.AccountKey,
+		MaxBody:      out.MaxBody,
+	}
+	if edgerc.MaxBody <= 0 {
+		edgerc.MaxBody = edgegrid.MaxBodySize
+	}
+	if err := edgerc.Validate(); err != nil {
+		return fmt.Errorf("%w: %s", ErrCredentialProcess, err)
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.edgerc = edgerc
+	c.expires = time.Time{}
+	if out.Expiration != nil {
+		c.expires = *out.Expiration
+		c.log.Debugf("credentials from credential process expire at %s", c.expires)
+	}
+	return nil
+}
+
+// SignRequest signs the request with the current credentials
+func (s *accountSigner) SignRequest(r *http.Request) {
+	edgerc := *s.creds.config()
+	if s.accountKey != "" {
+		edgerc.AccountKey = s.accountKey
+	}
+	edgerc.SignRequest(r)
+}
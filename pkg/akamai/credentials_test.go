@@ -0,0 +1,248 @@
+package akamai
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v2/pkg/edgegrid"
+	"github.com/apex/log"
+	"github.com/apex/log/handlers/discard"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/stretchr/testify/require"
+	"github.com/tj/assert"
+)
+
+var credentialsSchema = map[string]*schema.Schema{
+	"credential_process": {
+		Type:     schema.TypeSet,
+		Optional: true,
+		Elem:     credentialProcessOptions(),
+		MaxItems: 1,
+	},
+	"credential_files": {
+		Type:     schema.TypeSet,
+		Optional: true,
+		Elem:     credentialFilesOptions(),
+		MaxItems: 1,
+	},
+}
+
+func writeTestFile(t *testing.T, dir, name, content string) string {
+	path := filepath.Join(dir, name)
+	require.NoError(t, ioutil.WriteFile(path, []byte(content), 0700))
+	return path
+}
+
+// credentialScript returns the path of a script printing the given output,
+// the number of times the script was run is appended to the counter file
+func credentialScript(t *testing.T, dir, output string) (string, string) {
+	counter := filepath.Join(dir, "counter")
+	script := fmt.Sprintf("#!/bin/sh\necho run >> %s\ncat <<'EOF'\n%s\nEOF\n", counter, output)
+	return writeTestFile(t, dir, "creds.sh", script), counter
+}
+
+func TestGetExternalCredentials(t *testing.T) {
+	dir, err := ioutil.TempDir("", "credentials")
+	require.NoError(t, err)
+	defer func() {
+		require.NoError(t, os.RemoveAll(dir))
+	}()
+
+	validOutput := `{"host": "process-host", "client_token": "ctoken", "client_secret": "secret", "access_token": "atoken", "account_key": "process-account"}`
+	script, _ := credentialScript(t, dir, validOutput)
+	files := map[string]interface{}{
+		"host":          writeTestFile(t, dir, "host", "files-host\n"),
+		"client_token":  writeTestFile(t, dir, "client_token", "ctoken"),
+		"client_secret": writeTestFile(t, dir, "client_secret", "secret"),
+		"access_token":  writeTestFile(t, dir, "access_token", " atoken "),
+	}
+	emptyFile := writeTestFile(t, dir, "empty", "")
+
+	tests := map[string]struct {
+		givenData    map[string]interface{}
+		expectedHost string
+		expectedKey  string
+		expectedNil  bool
+		withError    error
+	}{
+		"no external credentials": {
+			givenData:   map[string]interface{}{},
+			expectedNil: true,
+		},
+		"credential process": {
+			givenData: map[string]interface{}{
+				"credential_process": []interface{}{map[string]interface{}{"command": script}},
+			},
+			expectedHost: "process-host",
+			expectedKey:  "process-account",
+		},
+		"credential process fails": {
+			givenData: map[string]interface{}{
+				"credential_process": []interface{}{map[string]interface{}{
+					"command": "sh",
+					"args":    []interface{}{"-c", "exit 1"},
+				}},
+			},
+			withError: ErrCredentialProcess,
+		},
+		"credential process returns incomplete credentials": {
+			givenData: map[string]interface{}{
+				"credential_process": []interface{}{map[string]interface{}{
+					"command": "echo",
+					"args":    []interface{}{`{"host": "host"}`},
+				}},
+			},
+			withError: ErrCredentialProcess,
+		},
+		"credential files": {
+			givenData: map[string]interface{}{
+				"credential_files": []interface{}{files},
+			},
+			expectedHost: "files-host",
+		},
+		"empty credential file": {
+			givenData: map[string]interface{}{
+				"credential_files": []interface{}{map[string]interface{}{
+					"host":          files["host"],
+					"client_token":  files["client_token"],
+					"client_secret": emptyFile,
+					"access_token":  files["access_token"],
+				}},
+			},
+			withError: ErrCredentialFiles,
+		},
+		"missing credential file": {
+			givenData: map[string]interface{}{
+				"credential_files": []interface{}{map[string]interface{}{
+					"host":          files["host"],
+					"client_token":  files["client_token"],
+					"client_secret": filepath.Join(dir, "missing"),
+					"access_token":  files["access_token"],
+				}},
+			},
+			withError: ErrCredentialFiles,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			d := schema.TestResourceDataRaw(t, credentialsSchema, test.givenData)
+			creds, err := getExternalCredentials(context.Background(), d, log.Log)
+			if test.withError != nil {
+				assert.True(t, errors.Is(err, test.withError), "want: %s; got: %s", test.withError, err)
+				return
+			}
+			require.NoError(t, err)
+			if test.expectedNil {
+				assert.Nil(t, creds)
+				return
+			}
+			assert.Equal(t, test.expectedHost, creds.config().Host)
+			assert.Equal(t, "atoken", creds.config().AccessToken)
+			assert.Equal(t, test.expectedKey, creds.config().AccountKey)
+		})
+	}
+}
+
+func TestProcessCredentials_Refresh(t *testing.T) {
+	dir, err := ioutil.TempDir("", "credentials")
+	require.NoError(t, err)
+	defer func() {
+		require.NoError(t, os.RemoveAll(dir))
+	}()
+
+	expiration := time.Now().Add(30 * time.Second).UTC().Format(time.RFC3339)
+	output := fmt.Sprintf(`{"host": "host", "client_token": "ctoken", "client_secret": "secret", "access_token": "atoken", "expiration": %q}`, expiration)
+	script, counter := credentialScript(t, dir, output)
+
+	creds := &processCredentials{command: script, log: &log.Logger{Handler: discard.New()}}
+	require.NoError(t, creds.refresh(context.Background()))
+
+	// the credentials expire within the refresh window, so every use runs the process again
+	assert.Equal(t, "host", creds.config().Host)
+	assert.Equal(t, "host", creds.config().Host)
+
+	runs, err := ioutil.ReadFile(counter)
+	require.NoError(t, err)
+	assert.Equal(t, "run\nrun\nrun\n", string(runs))
+
+	// when the refresh fails the previous credentials are still used
+	require.NoError(t, os.Remove(script))
+	assert.Equal(t, "host", creds.config().Host)
+}
+
+func TestProcessCredentials_ConcurrentRefresh(t *testing.T) {
+	dir, err := ioutil.TempDir("", "credentials")
+	require.NoError(t, err)
+	defer func() {
+		require.NoError(t, os.RemoveAll(dir))
+	}()
+
+	expiration := time.Now().Add(time.Hour).UTC().Format(time.RFC3339)
+	output := fmt.Sprintf(`{"host": "new-host", "client_token": "ctoken", "client_secret": "secret", "access_token": "atoken", "expiration": %q}`, expiration)
+	// the process is slow, so that all the callers find the credentials expired while it runs
+	counter := filepath.Join(dir, "counter")
+	script := writeTestFile(t, dir, "creds.sh", fmt.Sprintf("#!/bin/sh\nsleep 0.5\necho run >> %s\ncat <<'EOF'\n%s\nEOF\n", counter, output))
+
+	creds := &processCredentials{
+		command: script,
+		log:     &log.Logger{Handler: discard.New()},
+		edgerc:  &edgegrid.Config{Host: "old-host"},
+		expires: time.Now(),
+	}
+
+	var wg sync.WaitGroup
+	hosts := make([]string, 10)
+	for i := range hosts {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			hosts[i] = creds.config().Host
+		}(i)
+	}
+	wg.Wait()
+
+	for _, host := range hosts {
+		assert.Equal(t, "new-host", host)
+	}
+	runs, err := ioutil.ReadFile(counter)
+	require.NoError(t, err)
+	assert.Equal(t, "run\n", string(runs))
+}
+
+func TestAccountSigner_SignRequest(t *testing.T) {
+	creds := &staticCredentials{edgerc: &edgegrid.Config{Host: "host", AccountKey: "provider-account"}}
+
+	tests := map[string]struct {
+		accountKey  string
+		expectedKey string
+	}{
+		"provider account": {
+			expectedKey: "provider-account",
+		},
+		"account override": {
+			accountKey:  "resource-account",
+			expectedKey: "resource-account",
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			req, err := http.NewRequest(http.MethodGet, "https://host/papi/v1/groups", nil)
+			require.NoError(t, err)
+			signer := &accountSigner{creds: creds, accountKey: test.accountKey}
+			signer.SignRequest(req)
+			assert.Equal(t, []string{test.expectedKey}, req.URL.Query()["accountSwitchKey"])
+			assert.Contains(t, req.Header.Get("Authorization"), "EG1-HMAC-SHA256")
+			assert.Equal(t, "provider-account", creds.config().AccountKey)
+		})
+	}
+}
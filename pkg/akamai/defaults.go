@@ -0,0 +1,60 @@
+package akamai
+
+import (
+	"fmt"
+
+	"github.com/akamai/terraform-provider-akamai/v2/pkg/tools"
+)
+
+var (
+	// ErrNoContractID is returned when neither the resource nor the provider default_contract_id sets the contract
+	ErrNoContractID = &Error{"contract is not set, set the contract ID on the resource or default_contract_id on the provider", false}
+
+	// ErrNoGroupID is returned when neither the resource nor the provider default_group_id sets the group
+	ErrNoGroupID = &Error{"group is not set, set the group ID on the resource or default_group_id on the provider", false}
+)
+
+// GetContractID returns the contract ID from the first of the keys set on the resource, or the default_contract_id
+// of the provider. The ID is returned with the ctr_ prefix, use tools.NormalizeID for APIs expecting it without.
+func GetContractID(d tools.ResourceDataFetcher, m interface{}, keys ...string) (string, error) {
+	id, err := getIDOrDefault(d, keys, Meta(m).DefaultContractID(), tools.ContractPrefix)
+	if err != nil {
+		return "", err
+	}
+	if id == "" {
+		return "", ErrNoContractID
+	}
+	return id, nil
+}
+
+// GetGroupID returns the group ID from the first of the keys set on the resource, or the default_group_id
+// of the provider. The ID is returned with the grp_ prefix, use tools.NormalizeID or tools.NormalizeIntID
+// for APIs expecting it without.
+func GetGroupID(d tools.ResourceDataFetcher, m interface{}, keys ...string) (string, error) {
+	id, err := getIDOrDefault(d, keys, Meta(m).DefaultGroupID(), tools.GroupPrefix)
+	if err != nil {
+		return "", err
+	}
+	if id == "" {
+		return "", ErrNoGroupID
+	}
+	return id, nil
+}
+
+func getIDOrDefault(d tools.ResourceDataFetcher, keys []string, defaultID, prefix string) (string, error) {
+	for _, key := range keys {
+		val, ok := d.GetOk(key)
+		if !ok {
+			continue
+		}
+		switch v := val.(type) {
+		case string:
+			return tools.AddPrefix(v, prefix), nil
+		case int:
+			return tools.AddPrefix(fmt.Sprint(v), prefix), nil
+		default:
+			return "", fmt.Errorf("%w: %s, %q", tools.ErrInvalidType, key, "string")
+		}
+	}
+	return tools.AddPrefix(defaultID, prefix), nil
+}
@@ -0,0 +1,74 @@
+package akamai
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/stretchr/testify/require"
+	"github.com/tj/assert"
+)
+
+func TestGetContractAndGroupID(t *testing.T) {
+	resourceSchema := map[string]*schema.Schema{
+		"contract_id": {Type: schema.TypeString, Optional: true},
+		"contract":    {Type: schema.TypeString, Optional: true},
+		"group_id":    {Type: schema.TypeInt, Optional: true},
+	}
+
+	tests := map[string]struct {
+		givenData        map[string]interface{}
+		meta             *meta
+		expectedContract string
+		expectedGroup    string
+		contractErr      error
+		groupErr         error
+	}{
+		"set on the resource": {
+			givenData:        map[string]interface{}{"contract_id": "1-AB123", "group_id": 123},
+			meta:             &meta{contractID: "ctr_2-CD456", groupID: "grp_456"},
+			expectedContract: "ctr_1-AB123",
+			expectedGroup:    "grp_123",
+		},
+		"first key set is used": {
+			givenData:        map[string]interface{}{"contract": "ctr_3-EF789"},
+			meta:             &meta{contractID: "ctr_2-CD456", groupID: "grp_456"},
+			expectedContract: "ctr_3-EF789",
+			expectedGroup:    "grp_456",
+		},
+		"provider defaults": {
+			givenData:        map[string]interface{}{},
+			meta:             &meta{contractID: "ctr_2-CD456", groupID: "grp_456"},
+			expectedContract: "ctr_2-CD456",
+			expectedGroup:    "grp_456",
+		},
+		"not set": {
+			givenData:   map[string]interface{}{},
+			meta:        &meta{},
+			contractErr: ErrNoContractID,
+			groupErr:    ErrNoGroupID,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			d := schema.TestResourceDataRaw(t, resourceSchema, test.givenData)
+
+			contractID, err := GetContractID(d, test.meta, "contract_id", "contract")
+			if test.contractErr != nil {
+				assert.True(t, errors.Is(err, test.contractErr), "want: %s; got: %s", test.contractErr, err)
+			} else {
+				require.NoError(t, err)
+				assert.Equal(t, test.expectedContract, contractID)
+			}
+
+			groupID, err := GetGroupID(d, test.meta, "group_id")
+			if test.groupErr != nil {
+				assert.True(t, errors.Is(err, test.groupErr), "want: %s; got: %s", test.groupErr, err)
+			} else {
+				require.NoError(t, err)
+				assert.Equal(t, test.expectedGroup, groupID)
+			}
+		})
+	}
+}
@@ -21,6 +21,9 @@ var (
 	// ErrCacheEntryNotFound returns a cache entry error
 	ErrCacheEntryNotFound = &Error{"cache entry not found", true}
 
+	// errCacheEntryExpired is returned by cache backends for entries removed because their time to live has passed
+	errCacheEntryExpired = &Error{"cache entry expired", true}
+
 	// ErrCacheDisabled is returned when the cache is disabled
 	ErrCacheDisabled = &Error{"cache is disabled", false}
 
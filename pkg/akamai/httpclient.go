@@ -0,0 +1,200 @@
+package akamai
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+
+	"github.com/akamai/terraform-provider-akamai/v2/pkg/tools"
+)
+
+type (
+	// httpClientConfig holds the settings of the provider http block
+	httpClientConfig struct {
+		proxy             string
+		proxyUsername     string
+		proxyPassword     string
+		caBundle          string
+		requestTimeout    time.Duration
+		keepAlive         time.Duration
+		idleConnTimeout   time.Duration
+		disableKeepAlives bool
+	}
+)
+
+const (
+	defaultKeepAlive       = 30 * time.Second
+	defaultIdleConnTimeout = 90 * time.Second
+)
+
+// httpClientOptions returns the schema of the provider http block
+func httpClientOptions() *schema.Resource {
+	return &schema.Resource{
+		Schema: map[string]*schema.Schema{
+			"proxy": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "The URL of the HTTP(S) proxy for API requests, the HTTPS_PROXY and NO_PROXY env variables are used if not set",
+			},
+			"proxy_username": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "The user name for proxy authentication",
+			},
+			"proxy_password": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Sensitive:   true,
+				Description: "The password for proxy authentication",
+			},
+			"ca_bundle": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "The path of a PEM file with certificates trusted in addition to the system certificates",
+			},
+			"request_timeout": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      0,
+				ValidateFunc: validation.IntAtLeast(0),
+				Description:  "The time limit in seconds for a single API request, 0 means no limit",
+			},
+			"keep_alive": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      int(defaultKeepAlive / time.Second),
+				ValidateFunc: validation.IntAtLeast(0),
+				Description:  "The interval in seconds between TCP keep-alive probes, 0 disables them",
+			},
+			"idle_conn_timeout": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      int(defaultIdleConnTimeout / time.Second),
+				ValidateFunc: validation.IntAtLeast(0),
+				Description:  "The time in seconds an idle connection is kept open for reuse, 0 means no limit",
+			},
+			"disable_keep_alives": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Open a new connection for every API request",
+			},
+		},
+	}
+}
+
+// getHTTPClientConfig reads the http block from the provider configuration, nil is returned when the block is not set
+func getHTTPClientConfig(d *schema.ResourceData) (*httpClientConfig, error) {
+	block, err := getSingleBlock(d, "http")
+	if err != nil || block == nil {
+		return nil, err
+	}
+
+	conf := &httpClientConfig{}
+	for key, target := range map[string]*string{
+		"proxy":          &conf.proxy,
+		"proxy_username": &conf.proxyUsername,
+		"proxy_password": &conf.proxyPassword,
+		"ca_bundle":      &conf.caBundle,
+	} {
+		val, ok := block[key].(string)
+		if !ok && block[key] != nil {
+			return nil, fmt.Errorf("%w: %s, %q", tools.ErrInvalidType, key, "string")
+		}
+		*target = val
+	}
+	for key, target := range map[string]*time.Duration{
+		"request_timeout":   &conf.requestTimeout,
+		"keep_alive":        &conf.keepAlive,
+		"idle_conn_timeout": &conf.idleConnTimeout,
+	} {
+		val, ok := block[key].(int)
+		if !ok {
+			return nil, fmt.Errorf("%w: %s, %q", tools.ErrInvalidType, key, "int")
+		}
+		*target = time.Duration(val) * time.Second
+	}
+	disableKeepAlives, ok := block["disable_keep_alives"].(bool)
+	if !ok {
+		return nil, fmt.Errorf("%w: %s, %q", tools.ErrInvalidType, "disable_keep_alives", "bool")
+	}
+	conf.disableKeepAlives = disableKeepAlives
+
+	if (conf.proxyUsername != "" || conf.proxyPassword != "") && conf.proxy == "" {
+		return nil, fmt.Errorf("http: proxy_username and proxy_password require proxy to be set")
+	}
+
+	return conf, nil
+}
+
+// newHTTPClient returns the HTTP client used by the API sessions,
+// the default client settings are used when conf is nil
+func newHTTPClient(conf *httpClientConfig) (*http.Client, error) {
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	if conf == nil {
+		return &http.Client{Transport: transport}, nil
+	}
+
+	if conf.proxy != "" {
+		proxyURL, err := url.Parse(conf.proxy)
+		if err != nil || proxyURL.Host == "" {
+			return nil, fmt.Errorf("http: invalid proxy URL %q", conf.proxy)
+		}
+		if conf.proxyUsername != "" || conf.proxyPassword != "" {
+			proxyURL.User = url.UserPassword(conf.proxyUsername, conf.proxyPassword)
+		}
+		transport.Proxy = http.ProxyURL(proxyURL)
+	}
+
+	if conf.caBundle != "" {
+		pool, err := certPoolWithBundle(conf.caBundle)
+		if err != nil {
+			return nil, err
+		}
+		transport.TLSClientConfig = &tls.Config{
+			RootCAs:    pool,
+			MinVersion: tls.VersionTLS12,
+		}
+	}
+
+	keepAlive := conf.keepAlive
+	if keepAlive == 0 {
+		// a negative interval disables keep-alive probes, while 0 means the default interval
+		keepAlive = -1
+	}
+	transport.DialContext = (&net.Dialer{
+		Timeout:   30 * time.Second,
+		KeepAlive: keepAlive,
+	}).DialContext
+	transport.IdleConnTimeout = conf.idleConnTimeout
+	transport.DisableKeepAlives = conf.disableKeepAlives
+
+	return &http.Client{
+		Transport: transport,
+		Timeout:   conf.requestTimeout,
+	}, nil
+}
+
+// certPoolWithBundle returns the system certificate pool extended with the certificates from the PEM file
+func certPoolWithBundle(path string) (*x509.CertPool, error) {
+	pool, err := x509.SystemCertPool()
+	if err != nil || pool == nil {
+		pool = x509.NewCertPool()
+	}
+	pem, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("http: reading CA bundle: %w", err)
+	}
+	if !pool.AppendCertsFromPEM(pem) {
+		return nil, fmt.Errorf("http: no certificates found in CA bundle %q", path)
+	}
+	return pool, nil
+}
@@ -0,0 +1,159 @@
+package akamai
+
+import (
+	"encoding/base64"
+	"encoding/pem"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/stretchr/testify/require"
+	"github.com/tj/assert"
+)
+
+func TestGetHTTPClientConfig(t *testing.T) {
+	resourceSchema := map[string]*schema.Schema{
+		"http": {
+			Type:     schema.TypeSet,
+			Optional: true,
+			Elem:     httpClientOptions(),
+		},
+	}
+
+	tests := map[string]struct {
+		givenData map[string]interface{}
+		expected  *httpClientConfig
+		withError bool
+	}{
+		"http not configured": {
+			givenData: map[string]interface{}{},
+		},
+		"defaults": {
+			givenData: map[string]interface{}{
+				"http": []interface{}{map[string]interface{}{}},
+			},
+			expected: &httpClientConfig{
+				keepAlive:       defaultKeepAlive,
+				idleConnTimeout: defaultIdleConnTimeout,
+			},
+		},
+		"all settings": {
+			givenData: map[string]interface{}{
+				"http": []interface{}{map[string]interface{}{
+					"proxy":               "http://proxy:3128",
+					"proxy_username":      "user",
+					"proxy_password":      "pass",
+					"ca_bundle":           "ca.pem",
+					"request_timeout":     60,
+					"keep_alive":          0,
+					"idle_conn_timeout":   10,
+					"disable_keep_alives": true,
+				}},
+			},
+			expected: &httpClientConfig{
+				proxy:             "http://proxy:3128",
+				proxyUsername:     "user",
+				proxyPassword:     "pass",
+				caBundle:          "ca.pem",
+				requestTimeout:    time.Minute,
+				idleConnTimeout:   10 * time.Second,
+				disableKeepAlives: true,
+			},
+		},
+		"proxy auth without proxy": {
+			givenData: map[string]interface{}{
+				"http": []interface{}{map[string]interface{}{
+					"proxy_username": "user",
+				}},
+			},
+			withError: true,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			d := schema.TestResourceDataRaw(t, resourceSchema, test.givenData)
+			conf, err := getHTTPClientConfig(d)
+			if test.withError {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, test.expected, conf)
+		})
+	}
+}
+
+func TestNewHTTPClient(t *testing.T) {
+	t.Run("proxy with authentication", func(t *testing.T) {
+		var proxyAuth, requestURL string
+		proxy := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			proxyAuth = r.Header.Get("Proxy-Authorization")
+			requestURL = r.URL.String()
+			w.WriteHeader(http.StatusOK)
+		}))
+		defer proxy.Close()
+
+		client, err := newHTTPClient(&httpClientConfig{proxy: proxy.URL, proxyUsername: "user", proxyPassword: "pass"})
+		require.NoError(t, err)
+		resp, err := client.Get("http://akab-host.luna.akamaiapis.net/papi/v1/groups")
+		require.NoError(t, err)
+		require.NoError(t, resp.Body.Close())
+
+		assert.Equal(t, "Basic "+base64.StdEncoding.EncodeToString([]byte("user:pass")), proxyAuth)
+		assert.Equal(t, "http://akab-host.luna.akamaiapis.net/papi/v1/groups", requestURL)
+	})
+
+	t.Run("invalid proxy URL", func(t *testing.T) {
+		_, err := newHTTPClient(&httpClientConfig{proxy: "proxy"})
+		assert.Error(t, err)
+	})
+
+	t.Run("CA bundle", func(t *testing.T) {
+		srv := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusOK)
+		}))
+		defer srv.Close()
+
+		dir, err := ioutil.TempDir("", "ca")
+		require.NoError(t, err)
+		defer func() {
+			require.NoError(t, os.RemoveAll(dir))
+		}()
+		bundle := filepath.Join(dir, "ca.pem")
+		certPEM := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: srv.Certificate().Raw})
+		require.NoError(t, ioutil.WriteFile(bundle, certPEM, 0600))
+
+		client, err := newHTTPClient(nil)
+		require.NoError(t, err)
+		_, err = client.Get(srv.URL)
+		assert.Error(t, err)
+
+		client, err = newHTTPClient(&httpClientConfig{caBundle: bundle})
+		require.NoError(t, err)
+		resp, err := client.Get(srv.URL)
+		require.NoError(t, err)
+		require.NoError(t, resp.Body.Close())
+
+		_, err = newHTTPClient(&httpClientConfig{caBundle: filepath.Join(dir, "missing.pem")})
+		assert.Error(t, err)
+	})
+
+	t.Run("request timeout", func(t *testing.T) {
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			time.Sleep(100 * time.Millisecond)
+			w.WriteHeader(http.StatusOK)
+		}))
+		defer srv.Close()
+
+		client, err := newHTTPClient(&httpClientConfig{requestTimeout: 10 * time.Millisecond})
+		require.NoError(t, err)
+		_, err = client.Get(srv.URL)
+		assert.Error(t, err)
+	})
+}
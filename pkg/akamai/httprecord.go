@@ -0,0 +1,314 @@
+package akamai
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v2/pkg/edgegrid"
+)
+
+type (
+	// httpInteraction is a sanitized request and response pair written to a recording
+	httpInteraction struct {
+		OperationID string           `json:"operation_id"`
+		Sequence    int              `json:"sequence"`
+		Time        time.Time        `json:"time"`
+		Request     recordedRequest  `json:"request"`
+		Response    recordedResponse `json:"response"`
+	}
+
+	recordedRequest struct {
+		Method string      `json:"method"`
+		URL    string      `json:"url"`
+		Header http.Header `json:"header,omitempty"`
+		Body   string      `json:"body,omitempty"`
+	}
+
+	recordedResponse struct {
+		StatusCode int         `json:"status_code"`
+		Header     http.Header `json:"header,omitempty"`
+		Body       string      `json:"body,omitempty"`
+	}
+
+	// recordTransport sends requests using the next transport and appends each interaction to a recording file
+	recordTransport struct {
+		next        http.RoundTripper
+		path        string
+		operationID string
+
+		mu       sync.Mutex
+		sequence int
+	}
+
+	// replayTransport serves recorded responses without sending any request
+	replayTransport struct {
+		mu           sync.Mutex
+		interactions map[string][]*httpInteraction
+	}
+)
+
+const (
+	// httpRecordEnv is the env variable with the directory requests and responses are recorded to
+	httpRecordEnv = "AKAMAI_HTTP_RECORD"
+
+	// httpReplayEnv is the env variable with the directory recorded responses are served from
+	httpReplayEnv = "AKAMAI_HTTP_REPLAY"
+
+	recordingExtension = ".jsonl"
+)
+
+var (
+	// ErrHTTPReplay is returned when there is no recorded response matching a request
+	ErrHTTPReplay = errors.New("http replay")
+
+	// sanitizedQueryParams are removed from the recorded URLs and ignored when matching requests
+	sanitizedQueryParams = []string{"accountSwitchKey"}
+)
+
+// getRecordingTransport wraps the transport for recording or replaying API calls,
+// depending on the AKAMAI_HTTP_RECORD and AKAMAI_HTTP_REPLAY env variables.
+// The transport is returned unchanged if none of them is set.
+func getRecordingTransport(next http.RoundTripper, operationID string) (http.RoundTripper, error) {
+	recordDir, replayDir := os.Getenv(httpRecordEnv), os.Getenv(httpReplayEnv)
+	switch {
+	case recordDir != "" && replayDir != "":
+		return nil, fmt.Errorf("only one of %s and %s can be set", httpRecordEnv, httpReplayEnv)
+	case recordDir != "":
+		return newRecordTransport(next, recordDir, operationID)
+	case replayDir != "":
+		return newReplayTransport(replayDir)
+	}
+	return next, nil
+}
+
+// isReplaying returns true if API calls are served from a recording
+func isReplaying() bool {
+	return os.Getenv(httpReplayEnv) != ""
+}
+
+// replayConfig returns placeholder credentials used to sign requests which are never sent
+func replayConfig() *edgegrid.Config {
+	return &edgegrid.Config{
+		Host:         "replay.luna.akamaiapis.net",
+		ClientToken:  "replay",
+		ClientSecret: "replay",
+		AccessToken:  "replay",
+		MaxBody:      edgegrid.MaxBodySize,
+	}
+}
+
+func newRecordTransport(next http.RoundTripper, dir, operationID string) (*recordTransport, error) {
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		return nil, fmt.Errorf("creating http recording directory: %w", err)
+	}
+	return &recordTransport{
+		next:        next,
+		path:        filepath.Join(dir, operationID+recordingExtension),
+		operationID: operationID,
+	}, nil
+}
+
+// RoundTrip sends the request and records the sanitized request and response
+func (t *recordTransport) RoundTrip(r *http.Request) (*http.Response, error) {
+	var reqBody []byte
+	if r.Body != nil {
+		var err error
+		reqBody, err = ioutil.ReadAll(r.Body)
+		_ = r.Body.Close()
+		if err != nil {
+			return nil, err
+		}
+		r = r.Clone(r.Context())
+		r.Body = ioutil.NopCloser(bytes.NewReader(reqBody))
+	}
+
+	resp, err := t.next.RoundTrip(r)
+	if err != nil {
+		return nil, err
+	}
+
+	respBody, err := ioutil.ReadAll(resp.Body)
+	_ = resp.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+	resp.Body = ioutil.NopCloser(bytes.NewReader(respBody))
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.sequence++
+	interaction := httpInteraction{
+		OperationID: t.operationID,
+		Sequence:    t.sequence,
+		Time:        time.Now().UTC(),
+		Request: recordedRequest{
+			Method: r.Method,
+			URL:    sanitizeURL(r.URL),
+			Header: redactHeader(r.Header),
+			Body:   string(redactBody(r.URL.Path, reqBody)),
+		},
+		Response: recordedResponse{
+			StatusCode: resp.StatusCode,
+			Header:     redactHeader(resp.Header),
+			Body:       string(redactBody(r.URL.Path, respBody)),
+		},
+	}
+	if err := t.write(interaction); err != nil {
+		return nil, fmt.Errorf("recording %s %s: %w", r.Method, r.URL.Path, err)
+	}
+
+	return resp, nil
+}
+
+func (t *recordTransport) write(interaction httpInteraction) error {
+	data, err := json.Marshal(interaction)
+	if err != nil {
+		return err
+	}
+	f, err := os.OpenFile(t.path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0600)
+	if err != nil {
+		return err
+	}
+	if _, err := f.Write(append(data, '\n')); err != nil {
+		_ = f.Close()
+		return err
+	}
+	return f.Close()
+}
+
+// newReplayTransport loads all recordings from the directory, the interactions of
+// all operations are served in the order they were recorded in
+func newReplayTransport(dir string) (*replayTransport, error) {
+	paths, err := filepath.Glob(filepath.Join(dir, "*"+recordingExtension))
+	if err != nil {
+		return nil, err
+	}
+	if len(paths) == 0 {
+		return nil, fmt.Errorf("%w: no recordings found in %q", ErrHTTPReplay, dir)
+	}
+
+	var all []*httpInteraction
+	for _, path := range paths {
+		interactions, err := readRecording(path)
+		if err != nil {
+			return nil, err
+		}
+		all = append(all, interactions...)
+	}
+	sort.SliceStable(all, func(i, j int) bool {
+		if all[i].Time.Equal(all[j].Time) {
+			return all[i].Sequence < all[j].Sequence
+		}
+		return all[i].Time.Before(all[j].Time)
+	})
+
+	t := &replayTransport{
+		interactions: make(map[string][]*httpInteraction),
+	}
+	for _, interaction := range all {
+		key := interaction.Request.Method + " " + interaction.Request.URL
+		t.interactions[key] = append(t.interactions[key], interaction)
+	}
+	return t, nil
+}
+
+func readRecording(path string) ([]*httpInteraction, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		_ = f.Close()
+	}()
+
+	var interactions []*httpInteraction
+	scanner := bufio.NewScanner(f)
+	scanner.Buffer(nil, 64*1024*1024)
+	for line := 1; scanner.Scan(); line++ {
+		if len(bytes.TrimSpace(scanner.Bytes())) == 0 {
+			continue
+		}
+		var interaction httpInteraction
+		if err := json.Unmarshal(scanner.Bytes(), &interaction); err != nil {
+			return nil, fmt.Errorf("%w: %s:%d: %s", ErrHTTPReplay, path, line, err)
+		}
+		interactions = append(interactions, &interaction)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("%w: %s: %s", ErrHTTPReplay, path, err)
+	}
+	return interactions, nil
+}
+
+// RoundTrip returns the next recorded response for the request. Recorded requests with the same body
+// are preferred, so that different updates of the same object are told apart. Once all recorded
+// responses for the request are served, the last one is repeated, since polling may run longer.
+func (t *replayTransport) RoundTrip(r *http.Request) (*http.Response, error) {
+	var body []byte
+	if r.Body != nil {
+		var err error
+		body, err = ioutil.ReadAll(r.Body)
+		_ = r.Body.Close()
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	key := r.Method + " " + sanitizeURL(r.URL)
+
+	t.mu.Lock()
+	candidates := t.interactions[key]
+	if len(candidates) == 0 {
+		t.mu.Unlock()
+		return nil, fmt.Errorf("%w: no recorded response for %s", ErrHTTPReplay, key)
+	}
+	index := 0
+	for i, candidate := range candidates {
+		if candidate.Request.Body == string(redactBody(r.URL.Path, body)) {
+			index = i
+			break
+		}
+	}
+	interaction := candidates[index]
+	if len(candidates) > 1 {
+		t.interactions[key] = append(candidates[:index:index], candidates[index+1:]...)
+	}
+	t.mu.Unlock()
+
+	header := interaction.Response.Header.Clone()
+	if header == nil {
+		header = http.Header{}
+	}
+	return &http.Response{
+		Status:        fmt.Sprintf("%d %s", interaction.Response.StatusCode, http.StatusText(interaction.Response.StatusCode)),
+		StatusCode:    interaction.Response.StatusCode,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        header,
+		Body:          ioutil.NopCloser(bytes.NewBufferString(interaction.Response.Body)),
+		ContentLength: int64(len(interaction.Response.Body)),
+		Request:       r,
+	}, nil
+}
+
+// sanitizeURL returns the path and query of the URL without the host and the sanitized query parameters
+func sanitizeURL(u *url.URL) string {
+	query := u.Query()
+	for _, param := range sanitizedQueryParams {
+		query.Del(param)
+	}
+	sanitized := url.URL{Path: u.Path, RawQuery: query.Encode()}
+	return sanitized.RequestURI()
+}
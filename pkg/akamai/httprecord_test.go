@@ -0,0 +1,135 @@
+package akamai
+
+import (
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v2/pkg/edgegrid"
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v2/pkg/session"
+	"github.com/stretchr/testify/require"
+	"github.com/tj/assert"
+)
+
+func TestHTTPRecordReplay(t *testing.T) {
+	dir, err := ioutil.TempDir("", "recording")
+	require.NoError(t, err)
+	defer func() {
+		require.NoError(t, os.RemoveAll(dir))
+	}()
+
+	var activationStatus = []string{"PENDING", "ACTIVE"}
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("Set-Cookie", "session=secret")
+		switch r.URL.Path {
+		case "/activation":
+			status := activationStatus[0]
+			activationStatus = activationStatus[1:]
+			_, _ = w.Write([]byte(`{"status": "` + status + `"}`))
+		case "/token":
+			w.WriteHeader(http.StatusCreated)
+			_, _ = w.Write([]byte(`{"name": "token1", "token": "super-secret-value"}`))
+		}
+	}))
+
+	edgerc := &edgegrid.Config{
+		Host:         strings.TrimPrefix(srv.URL, "http://"),
+		ClientToken:  "ctoken",
+		ClientSecret: "csecret",
+		AccessToken:  "atoken",
+		AccountKey:   "1-ABCDE",
+		MaxBody:      edgegrid.MaxBodySize,
+	}
+
+	require.NoError(t, os.Setenv(httpRecordEnv, dir))
+	transport, err := getRecordingTransport(http.DefaultTransport, "opid")
+	require.NoError(t, os.Unsetenv(httpRecordEnv))
+	require.NoError(t, err)
+
+	exec := func(sess session.Session, method, path string, in ...interface{}) (*http.Response, map[string]interface{}, error) {
+		req, err := http.NewRequest(method, srv.URL+path, nil)
+		require.NoError(t, err)
+		out := make(map[string]interface{})
+		resp, err := sess.Exec(req, &out, in...)
+		return resp, out, err
+	}
+
+	builder := &sessionBuilder{opts: []session.Option{session.WithClient(&http.Client{Transport: transport})}}
+	sess, err := builder.build(edgerc)
+	require.NoError(t, err)
+
+	_, out, err := exec(sess, http.MethodGet, "/activation")
+	require.NoError(t, err)
+	assert.Equal(t, "PENDING", out["status"])
+	_, out, err = exec(sess, http.MethodGet, "/activation")
+	require.NoError(t, err)
+	assert.Equal(t, "ACTIVE", out["status"])
+	_, out, err = exec(sess, http.MethodPost, "/token", map[string]string{"name": "token1", "client_secret": "csecret"})
+	require.NoError(t, err)
+	assert.Equal(t, "super-secret-value", out["token"])
+	srv.Close()
+
+	recording, err := ioutil.ReadFile(filepath.Join(dir, "opid"+recordingExtension))
+	require.NoError(t, err)
+	assert.Equal(t, 3, strings.Count(string(recording), "\n"))
+	for _, secret := range []string{"EG1-HMAC-SHA256", "ctoken", "csecret", "atoken", "1-ABCDE", "super-secret-value", "session=secret"} {
+		assert.NotContains(t, string(recording), secret)
+	}
+	assert.Contains(t, string(recording), `"operation_id":"opid"`)
+
+	t.Run("replay", func(t *testing.T) {
+		require.NoError(t, os.Setenv(httpReplayEnv, dir))
+		defer func() {
+			require.NoError(t, os.Unsetenv(httpReplayEnv))
+		}()
+		assert.True(t, isReplaying())
+		transport, err := getRecordingTransport(http.DefaultTransport, "replay-opid")
+		require.NoError(t, err)
+
+		builder := &sessionBuilder{opts: []session.Option{session.WithClient(&http.Client{Transport: transport})}}
+		sess, err := builder.build(replayConfig())
+		require.NoError(t, err)
+
+		for _, expected := range []string{"PENDING", "ACTIVE", "ACTIVE"} {
+			_, out, err := exec(sess, http.MethodGet, "/activation")
+			require.NoError(t, err)
+			assert.Equal(t, expected, out["status"])
+		}
+
+		resp, out, err := exec(sess, http.MethodPost, "/token", map[string]string{"name": "token1", "client_secret": "csecret"})
+		require.NoError(t, err)
+		assert.Equal(t, http.StatusCreated, resp.StatusCode)
+		assert.Equal(t, "token1", out["name"])
+		assert.Equal(t, redactedValue, out["token"])
+
+		_, _, err = exec(sess, http.MethodDelete, "/token")
+		assert.True(t, errors.Is(err, ErrHTTPReplay), "want: %s; got: %s", ErrHTTPReplay, err)
+	})
+}
+
+func TestGetRecordingTransport(t *testing.T) {
+	require.NoError(t, os.Setenv(httpRecordEnv, "record"))
+	require.NoError(t, os.Setenv(httpReplayEnv, "replay"))
+	defer func() {
+		require.NoError(t, os.Unsetenv(httpRecordEnv))
+		require.NoError(t, os.Unsetenv(httpReplayEnv))
+	}()
+
+	_, err := getRecordingTransport(http.DefaultTransport, "opid")
+	assert.Error(t, err)
+
+	require.NoError(t, os.Unsetenv(httpRecordEnv))
+	_, err = getRecordingTransport(http.DefaultTransport, "opid")
+	assert.True(t, errors.Is(err, ErrHTTPReplay), "want: %s; got: %s", ErrHTTPReplay, err)
+
+	require.NoError(t, os.Unsetenv(httpReplayEnv))
+	transport, err := getRecordingTransport(http.DefaultTransport, "opid")
+	require.NoError(t, err)
+	assert.Equal(t, http.DefaultTransport, transport)
+}
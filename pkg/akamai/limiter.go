@@ -0,0 +1,201 @@
+package akamai
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v2/pkg/session"
+	"github.com/apex/log"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+
+	"github.com/akamai/terraform-provider-akamai/v2/pkg/tools"
+)
+
+type (
+	// requestLimiter caps the number of in-flight API requests globally and per subprovider
+	requestLimiter struct {
+		global chan struct{}
+		subs   map[string]chan struct{}
+	}
+
+	// limitSession is a session which waits for a free slot in the limiter before sending a request
+	limitSession struct {
+		session.Session
+		limiter *requestLimiter
+	}
+
+	// subproviderSession tags the requests with the name of the subprovider sending them
+	subproviderSession struct {
+		session.Session
+		name string
+	}
+
+	subproviderContextKey struct{}
+)
+
+// concurrencyOptions returns the schema of the provider concurrency block
+func concurrencyOptions() *schema.Resource {
+	return &schema.Resource{
+		Schema: map[string]*schema.Schema{
+			"max_requests": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      0,
+				ValidateFunc: validation.IntAtLeast(0),
+				Description:  "The maximum number of concurrent API requests for all subproviders, 0 means no limit",
+			},
+			"subprovider_max_requests": {
+				Type:        schema.TypeMap,
+				Optional:    true,
+				Elem:        &schema.Schema{Type: schema.TypeInt},
+				Description: "The maximum number of concurrent API requests per subprovider, keyed by subprovider name",
+			},
+		},
+	}
+}
+
+// getRequestLimiter reads the concurrency block from the provider configuration, nil is returned when no limits are set
+func getRequestLimiter(d *schema.ResourceData, subproviders map[string]Subprovider) (*requestLimiter, error) {
+	concurrency, err := tools.GetSetValue("concurrency", d)
+	if err != nil {
+		if errors.Is(err, tools.ErrNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	if concurrency.Len() == 0 {
+		return nil, nil
+	}
+
+	concurrencyMap, ok := concurrency.List()[0].(map[string]interface{})
+	if !ok {
+		return nil, fmt.Errorf("%w: %s, %q", tools.ErrInvalidType, "concurrency", "map[string]interface{}")
+	}
+	maxRequests, ok := concurrencyMap["max_requests"].(int)
+	if !ok {
+		return nil, fmt.Errorf("%w: %s, %q", tools.ErrInvalidType, "max_requests", "int")
+	}
+	subMaxRequests, ok := concurrencyMap["subprovider_max_requests"].(map[string]interface{})
+	if !ok && concurrencyMap["subprovider_max_requests"] != nil {
+		return nil, fmt.Errorf("%w: %s, %q", tools.ErrInvalidType, "subprovider_max_requests", "map[string]interface{}")
+	}
+
+	limits := make(map[string]int, len(subMaxRequests))
+	for name, val := range subMaxRequests {
+		if _, ok := subproviders[name]; !ok {
+			return nil, fmt.Errorf("concurrency: unknown subprovider %q, expected one of: %s", name, strings.Join(subproviderNames(subproviders), ", "))
+		}
+		limit, ok := val.(int)
+		if !ok {
+			return nil, fmt.Errorf("%w: %s, %q", tools.ErrInvalidType, name, "int")
+		}
+		if limit < 0 {
+			return nil, fmt.Errorf("concurrency: limit for subprovider %q cannot be negative", name)
+		}
+		limits[name] = limit
+	}
+
+	return newRequestLimiter(maxRequests, limits), nil
+}
+
+func subproviderNames(subproviders map[string]Subprovider) []string {
+	names := make([]string, 0, len(subproviders))
+	for name := range subproviders {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// newRequestLimiter returns a limiter for the given limits, limits equal to 0 are ignored
+func newRequestLimiter(maxRequests int, subproviderLimits map[string]int) *requestLimiter {
+	l := &requestLimiter{
+		subs: make(map[string]chan struct{}),
+	}
+	if maxRequests > 0 {
+		l.global = make(chan struct{}, maxRequests)
+	}
+	for name, limit := range subproviderLimits {
+		if limit > 0 {
+			l.subs[name] = make(chan struct{}, limit)
+		}
+	}
+	return l
+}
+
+// acquire waits for a free slot for the subprovider. The subprovider slot is taken first,
+// so that requests queued for a busy subprovider do not hold global slots needed by the others.
+// The returned function releases the slots.
+func (l *requestLimiter) acquire(ctx context.Context, name string) (func(), error) {
+	var taken []chan struct{}
+	release := func() {
+		for _, sem := range taken {
+			<-sem
+		}
+	}
+
+	for _, sem := range []chan struct{}{l.subs[name], l.global} {
+		if sem == nil {
+			continue
+		}
+		select {
+		case sem <- struct{}{}:
+			taken = append(taken, sem)
+		case <-ctx.Done():
+			release()
+			return nil, ctx.Err()
+		}
+	}
+
+	return release, nil
+}
+
+// withLimiter wraps the session so that every request waits for a slot in the limiter
+func withLimiter(sess session.Session, limiter *requestLimiter) session.Session {
+	return &limitSession{
+		Session: sess,
+		limiter: limiter,
+	}
+}
+
+// Exec waits for a slot in the limiter and executes the request
+func (s *limitSession) Exec(r *http.Request, out interface{}, in ...interface{}) (*http.Response, error) {
+	ctx := r.Context()
+	name, _ := ctx.Value(subproviderContextKey{}).(string)
+
+	start := time.Now()
+	release, err := s.limiter.acquire(ctx, name)
+	if err != nil {
+		return nil, err
+	}
+	defer release()
+
+	if wait := time.Since(start); wait > time.Millisecond {
+		s.Log(ctx).WithFields(log.Fields{
+			"subprovider": name,
+			"queue_wait":  wait.String(),
+		}).Debugf("%s %s waited %s for a free request slot", r.Method, r.URL.Path, wait)
+	}
+
+	return s.Session.Exec(r, out, in...)
+}
+
+// withSubprovider wraps the session so that all requests are attributed to the given subprovider
+func withSubprovider(sess session.Session, name string) session.Session {
+	return &subproviderSession{
+		Session: sess,
+		name:    name,
+	}
+}
+
+// Exec adds the subprovider name to the request context and executes the request
+func (s *subproviderSession) Exec(r *http.Request, out interface{}, in ...interface{}) (*http.Response, error) {
+	ctx := context.WithValue(r.Context(), subproviderContextKey{}, s.name)
+	return s.Session.Exec(r.WithContext(ctx), out, in...)
+}
@@ -0,0 +1,142 @@
+package akamai
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v2/pkg/edgegrid"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/stretchr/testify/require"
+	"github.com/tj/assert"
+)
+
+func TestRequestLimiter_Acquire(t *testing.T) {
+	l := newRequestLimiter(2, map[string]int{"property": 1})
+
+	releaseProperty, err := l.acquire(context.Background(), "property")
+	require.NoError(t, err)
+
+	// property is at its limit, but other subproviders can still use the remaining global slot
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	_, err = l.acquire(ctx, "property")
+	assert.Equal(t, context.DeadlineExceeded, err)
+
+	releaseDNS, err := l.acquire(context.Background(), "dns")
+	require.NoError(t, err)
+
+	// all global slots are taken
+	ctx, cancel = context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	_, err = l.acquire(ctx, "gtm")
+	assert.Equal(t, context.DeadlineExceeded, err)
+
+	releaseProperty()
+	releaseDNS()
+	assert.Len(t, l.global, 0)
+	assert.Len(t, l.subs["property"], 0)
+}
+
+func TestLimitSession_Exec(t *testing.T) {
+	var inFlight, maxInFlight int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		current := atomic.AddInt32(&inFlight, 1)
+		for {
+			max := atomic.LoadInt32(&maxInFlight)
+			if current <= max || atomic.CompareAndSwapInt32(&maxInFlight, max, current) {
+				break
+			}
+		}
+		time.Sleep(5 * time.Millisecond)
+		atomic.AddInt32(&inFlight, -1)
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer srv.Close()
+
+	builder := &sessionBuilder{limiter: newRequestLimiter(0, map[string]int{"test": 2})}
+	sess, err := builder.build(&edgegrid.Config{MaxBody: edgegrid.MaxBodySize})
+	require.NoError(t, err)
+	sess = withSubprovider(sess, "test")
+
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			req, err := http.NewRequest(http.MethodGet, srv.URL, nil)
+			require.NoError(t, err)
+			_, err = sess.Exec(req, nil)
+			assert.NoError(t, err)
+		}()
+	}
+	wg.Wait()
+
+	assert.True(t, maxInFlight <= 2)
+}
+
+func TestGetRequestLimiter(t *testing.T) {
+	resourceSchema := map[string]*schema.Schema{
+		"concurrency": {
+			Type:     schema.TypeSet,
+			Optional: true,
+			Elem:     concurrencyOptions(),
+		},
+	}
+	subproviders := map[string]Subprovider{"test": testInst}
+
+	tests := map[string]struct {
+		givenData      map[string]interface{}
+		expectedGlobal int
+		expectedSubs   map[string]int
+		expectedNil    bool
+		withError      bool
+	}{
+		"concurrency not configured": {
+			givenData:   map[string]interface{}{},
+			expectedNil: true,
+		},
+		"global and subprovider limits": {
+			givenData: map[string]interface{}{
+				"concurrency": []interface{}{map[string]interface{}{
+					"max_requests":             10,
+					"subprovider_max_requests": map[string]interface{}{"test": 2},
+				}},
+			},
+			expectedGlobal: 10,
+			expectedSubs:   map[string]int{"test": 2},
+		},
+		"unknown subprovider": {
+			givenData: map[string]interface{}{
+				"concurrency": []interface{}{map[string]interface{}{
+					"subprovider_max_requests": map[string]interface{}{"foo": 2},
+				}},
+			},
+			withError: true,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			d := schema.TestResourceDataRaw(t, resourceSchema, test.givenData)
+			limiter, err := getRequestLimiter(d, subproviders)
+			if test.withError {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			if test.expectedNil {
+				assert.Nil(t, limiter)
+				return
+			}
+			assert.Equal(t, test.expectedGlobal, cap(limiter.global))
+			for name, limit := range test.expectedSubs {
+				assert.Equal(t, limit, cap(limiter.subs[name]))
+			}
+		})
+	}
+}
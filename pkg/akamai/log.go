@@ -2,12 +2,24 @@ package akamai
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
 	"os"
 	"strings"
+	"sync"
+	"time"
 
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v2/pkg/session"
 	"github.com/apex/log"
 	"github.com/hashicorp/go-hclog"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/logging"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+
+	"github.com/akamai/terraform-provider-akamai/v2/pkg/tools"
 )
 
 type (
@@ -71,23 +83,231 @@ func LogFromContext(ctx context.Context, args ...interface{}) log.Interface {
 
 func (h *logger) HandleLog(e *log.Entry) error {
 	fields := make([]interface{}, 0)
+	sensitive := sensitiveFields()
 
 	for k, v := range e.Fields {
+		if isSensitiveField(k, sensitive) {
+			v = redactedValue
+		} else if str, ok := v.(string); ok {
+			v = redactMessage(str)
+		}
 		fields = append(fields, k, v)
 	}
 
+	msg := redactMessage(e.Message)
+
 	switch e.Level {
 	case log.DebugLevel:
-		h.l.Debug(e.Message, fields...)
+		h.l.Debug(msg, fields...)
 	case log.InfoLevel:
-		h.l.Info(e.Message, fields...)
+		h.l.Info(msg, fields...)
 	case log.WarnLevel:
-		h.l.Warn(e.Message, fields...)
+		h.l.Warn(msg, fields...)
 	case log.ErrorLevel:
-		h.l.Error(e.Message, fields...)
+		h.l.Error(msg, fields...)
 	case log.FatalLevel:
 		panic(e.Message)
 	}
 
 	return nil
 }
+
+type (
+	// logConfig holds the settings of the provider logging block
+	logConfig struct {
+		format         string
+		file           string
+		redactedFields []string
+	}
+
+	// logSession is a session which logs the outcome and duration of each API request
+	logSession struct {
+		session.Session
+	}
+)
+
+var (
+	// logFiles holds the open log files by path. A file is opened once and shared by all the configurations
+	// of the provider writing to it, it is closed when the provider process exits.
+	logFiles   = make(map[string]*os.File)
+	logFilesMu sync.Mutex
+)
+
+const (
+	logFormatText = "text"
+	logFormatJSON = "json"
+)
+
+// loggingOptions returns the schema of the provider logging block
+func loggingOptions() *schema.Resource {
+	return &schema.Resource{
+		Schema: map[string]*schema.Schema{
+			"format": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      logFormatText,
+				ValidateFunc: validation.StringInSlice([]string{logFormatText, logFormatJSON}, false),
+				Description:  "The format of the provider logs, either text or json",
+			},
+			"file": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "The file JSON logs are appended to, instead of the standard error",
+			},
+			"redacted_fields": {
+				Type:        schema.TypeSet,
+				Optional:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description: "Names of additional fields whose values are redacted from logs and HTTP recordings",
+			},
+		},
+	}
+}
+
+// getLogConfig reads the logging block from the provider configuration
+func getLogConfig(d *schema.ResourceData) (*logConfig, error) {
+	conf := &logConfig{format: logFormatText}
+
+	logging, err := tools.GetSetValue("logging", d)
+	if err != nil {
+		if errors.Is(err, tools.ErrNotFound) {
+			return conf, nil
+		}
+		return nil, err
+	}
+	if logging.Len() == 0 {
+		return conf, nil
+	}
+
+	loggingMap, ok := logging.List()[0].(map[string]interface{})
+	if !ok {
+		return nil, fmt.Errorf("%w: %s, %q", tools.ErrInvalidType, "logging", "map[string]interface{}")
+	}
+	if format, ok := loggingMap["format"].(string); ok && format != "" {
+		conf.format = format
+	}
+	if conf.file, ok = loggingMap["file"].(string); !ok && loggingMap["file"] != nil {
+		return nil, fmt.Errorf("%w: %s, %q", tools.ErrInvalidType, "file", "string")
+	}
+	if conf.file != "" && conf.format != logFormatJSON {
+		return nil, fmt.Errorf("logging: file can only be set with the %q format", logFormatJSON)
+	}
+	if fields, ok := loggingMap["redacted_fields"].(*schema.Set); ok {
+		for _, field := range fields.List() {
+			name, ok := field.(string)
+			if !ok {
+				return nil, fmt.Errorf("%w: %s, %q", tools.ErrInvalidType, "redacted_fields", "string")
+			}
+			conf.redactedFields = append(conf.redactedFields, name)
+		}
+	}
+
+	return conf, nil
+}
+
+// newLogger returns the hclog logger of the provider. With the text format, the logs are written to
+// the Terraform logs. With the json format, the logs are written as JSON lines to the file or the standard error.
+func (c *logConfig) newLogger(ctx context.Context) (hclog.Logger, error) {
+	if c.format != logFormatJSON {
+		return hclog.FromContext(ctx), nil
+	}
+
+	var output io.Writer = os.Stderr
+	if c.file != "" {
+		f, err := openLogFile(c.file)
+		if err != nil {
+			return nil, err
+		}
+		output = f
+	}
+
+	// the level is filtered by the log.Interface returned by LogFromHCLog
+	return hclog.New(&hclog.LoggerOptions{
+		Name:       ProviderName,
+		Level:      hclog.Trace,
+		Output:     output,
+		JSONFormat: true,
+		TimeFormat: time.RFC3339Nano,
+	}), nil
+}
+
+// openLogFile returns the log file at the path, opening it for appending if it isn't open yet
+func openLogFile(path string) (*os.File, error) {
+	logFilesMu.Lock()
+	defer logFilesMu.Unlock()
+
+	if f, ok := logFiles[path]; ok {
+		return f, nil
+	}
+	f, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0600)
+	if err != nil {
+		return nil, fmt.Errorf("opening log file: %w", err)
+	}
+	logFiles[path] = f
+	return f, nil
+}
+
+// withRequestLogging wraps the session so that the outcome and duration of each request is logged
+func withRequestLogging(sess session.Session) session.Session {
+	return &logSession{
+		Session: sess,
+	}
+}
+
+// Exec executes the request and logs its status and duration along with the subprovider and resource sending it
+func (s *logSession) Exec(r *http.Request, out interface{}, in ...interface{}) (*http.Response, error) {
+	start := time.Now()
+	resp, err := s.Session.Exec(r, out, in...)
+
+	fields := log.Fields{
+		"method":   r.Method,
+		"path":     r.URL.Path,
+		"duration": time.Since(start).String(),
+	}
+	if name, ok := r.Context().Value(subproviderContextKey{}).(string); ok {
+		fields["subprovider"] = name
+	}
+	if name, ok := r.Context().Value(resourceContextKey{}).(string); ok {
+		fields["resource"] = name
+	}
+	if resp != nil {
+		fields["status"] = resp.StatusCode
+	}
+	logger := s.Log(r.Context()).WithFields(fields)
+	if err != nil {
+		logger.WithError(err).Debugf("API request %s %s failed", r.Method, r.URL.Path)
+	} else {
+		logger.Debugf("API request %s %s completed", r.Method, r.URL.Path)
+	}
+
+	return resp, err
+}
+
+// withOperationLogging logs the outcome and duration of each operation of the resource or data source
+func withOperationLogging(r *schema.Resource, name, subprovider string) {
+	for operation, f := range map[string]*crudFunc{
+		"create": (*crudFunc)(&r.CreateContext),
+		"read":   (*crudFunc)(&r.ReadContext),
+		"update": (*crudFunc)(&r.UpdateContext),
+		"delete": (*crudFunc)(&r.DeleteContext),
+	} {
+		if *f == nil {
+			continue
+		}
+		next, operation := *f, operation
+		*f = func(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+			start := time.Now()
+			diags := next(ctx, d, m)
+			if providerMeta, ok := m.(*meta); ok {
+				providerMeta.Log().WithFields(log.Fields{
+					"subprovider": subprovider,
+					"resource":    name,
+					"operation":   operation,
+					"duration":    time.Since(start).String(),
+					"failed":      diags.HasError(),
+				}).Debugf("%s %s finished", name, operation)
+			}
+			return diags
+		}
+	}
+}
@@ -0,0 +1,130 @@
+package akamai
+
+import (
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/apex/log"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/stretchr/testify/require"
+	"github.com/tj/assert"
+)
+
+func TestGetLogConfig(t *testing.T) {
+	resourceSchema := map[string]*schema.Schema{
+		"logging": {
+			Type:     schema.TypeSet,
+			Optional: true,
+			Elem:     loggingOptions(),
+		},
+	}
+
+	tests := map[string]struct {
+		givenData map[string]interface{}
+		expected  *logConfig
+		withError bool
+	}{
+		"logging not configured": {
+			givenData: map[string]interface{}{},
+			expected:  &logConfig{format: logFormatText},
+		},
+		"json to file with redacted fields": {
+			givenData: map[string]interface{}{
+				"logging": []interface{}{map[string]interface{}{
+					"format":          "json",
+					"file":            "provider.log",
+					"redacted_fields": []interface{}{"connector_secret"},
+				}},
+			},
+			expected: &logConfig{format: logFormatJSON, file: "provider.log", redactedFields: []string{"connector_secret"}},
+		},
+		"file with text format": {
+			givenData: map[string]interface{}{
+				"logging": []interface{}{map[string]interface{}{
+					"file": "provider.log",
+				}},
+			},
+			withError: true,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			d := schema.TestResourceDataRaw(t, resourceSchema, test.givenData)
+			conf, err := getLogConfig(d)
+			if test.withError {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, test.expected, conf)
+		})
+	}
+}
+
+func TestJSONLogger(t *testing.T) {
+	dir, err := ioutil.TempDir("", "logs")
+	require.NoError(t, err)
+	defer func() {
+		require.NoError(t, os.RemoveAll(dir))
+	}()
+	path := filepath.Join(dir, "provider.log")
+	defer closeTestLogFile(t, path)
+
+	conf := &logConfig{format: logFormatJSON, file: path}
+	hclogger, err := conf.newLogger(context.Background())
+	require.NoError(t, err)
+
+	logger := LogFromHCLog(hclogger.With("OperationID", "opid"))
+	logger.WithFields(log.Fields{
+		"subprovider":   "property",
+		"resource":      "akamai_property",
+		"duration":      "1s",
+		"client_secret": "abc",
+	}).Info(`{"password": "abc"}`)
+
+	data, err := ioutil.ReadFile(path)
+	require.NoError(t, err)
+	var entry map[string]interface{}
+	require.NoError(t, json.Unmarshal(data, &entry))
+
+	assert.Equal(t, "opid", entry["OperationID"])
+	assert.Equal(t, "property", entry["subprovider"])
+	assert.Equal(t, "akamai_property", entry["resource"])
+	assert.Equal(t, "1s", entry["duration"])
+	assert.Equal(t, redactedValue, entry["client_secret"])
+	assert.Equal(t, `{"password":"REDACTED"}`, entry["@message"])
+}
+
+func TestOpenLogFile(t *testing.T) {
+	dir, err := ioutil.TempDir("", "logs")
+	require.NoError(t, err)
+	defer func() {
+		require.NoError(t, os.RemoveAll(dir))
+	}()
+	path := filepath.Join(dir, "provider.log")
+	defer closeTestLogFile(t, path)
+
+	first, err := openLogFile(path)
+	require.NoError(t, err)
+	second, err := openLogFile(path)
+	require.NoError(t, err)
+	assert.Same(t, first, second)
+
+	_, err = openLogFile(filepath.Join(dir, "missing", "provider.log"))
+	assert.Error(t, err)
+}
+
+// closeTestLogFile closes the log file opened by a test so that the next test opens it again
+func closeTestLogFile(t *testing.T, path string) {
+	logFilesMu.Lock()
+	defer logFilesMu.Unlock()
+	if f, ok := logFiles[path]; ok {
+		require.NoError(t, f.Close())
+		delete(logFiles, path)
+	}
+}
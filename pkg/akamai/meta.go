@@ -2,10 +2,10 @@ package akamai
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
 
 	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v2/pkg/session"
-	"github.com/allegro/bigcache/v2"
 	"github.com/apex/log"
 	"github.com/hashicorp/go-hclog"
 )
@@ -23,18 +23,44 @@ type (
 		// Session returns the operation API session
 		Session() session.Session
 
+		// SubproviderSession returns the operation API session attributing requests to the subprovider
+		SubproviderSession(prov Subprovider) session.Session
+
 		// CacheGet returns an object from the cache
 		CacheGet(prov Subprovider, key string, out interface{}) error
 
 		// CacheSet sets a value in the cache
 		CacheSet(prov Subprovider, key string, val interface{}) error
+
+		// RunCacheGet returns an object from the in-memory cache of the current run
+		RunCacheGet(prov Subprovider, key string, out interface{}) error
+
+		// RunCacheSet sets a value in the in-memory cache of the current run, the value is never stored in the
+		// persistent cache backend. It is used for values which can become stale between terraform commands.
+		RunCacheSet(prov Subprovider, key string, val interface{}) error
+
+		// DefaultContractID returns the provider default_contract_id with the ctr_ prefix, or an empty string if not set
+		DefaultContractID() string
+
+		// DefaultGroupID returns the provider default_group_id with the grp_ prefix, or an empty string if not set
+		DefaultGroupID() string
 	}
 
 	meta struct {
-		operationID  string
-		log          hclog.Logger
-		sess         session.Session
-		cacheEnabled bool
+		operationID   string
+		log           hclog.Logger
+		sess          session.Session
+		sessBuilder   *sessionBuilder
+		creds         credentials
+		accountKey    string
+		cacheEnabled  bool
+		cache         cacheBackend
+		runCache      cacheBackend
+		cacheSettings *cacheSettings
+		cacheStats    *cacheStats
+		tracer        *tracer
+		contractID    string
+		groupID       string
 	}
 )
 
@@ -58,15 +84,86 @@ func (m *meta) Session() session.Session {
 	return m.sess
 }
 
+// SubproviderSession returns the meta session with requests attributed to the subprovider
+func (m *meta) SubproviderSession(prov Subprovider) session.Session {
+	return withSubprovider(m.sess, prov.Name())
+}
+
+// DefaultContractID returns the provider default contract ID
+func (m *meta) DefaultContractID() string {
+	return m.contractID
+}
+
+// DefaultGroupID returns the provider default group ID
+func (m *meta) DefaultGroupID() string {
+	return m.groupID
+}
+
+// withAccountKey returns a copy of the meta with a session signing requests for the given account switch key
+func (m *meta) withAccountKey(accountKey string) (*meta, error) {
+	sess, err := m.sessBuilder.build(&accountSigner{creds: m.creds, accountKey: accountKey})
+	if err != nil {
+		return nil, err
+	}
+
+	accountMeta := *m
+	accountMeta.log = m.log.With("AccountKey", accountKey)
+	accountMeta.sess = sess
+	accountMeta.accountKey = accountKey
+
+	return &accountMeta, nil
+}
+
+// cacheKey scopes the cache key to the subprovider and the account the meta is configured for
+func (m *meta) cacheKey(prov Subprovider, key string) string {
+	key = fmt.Sprintf("%s:%s", key, prov.Name())
+	if m.accountKey != "" {
+		key = fmt.Sprintf("%s:%s", key, m.accountKey)
+	}
+	return key
+}
+
+// logCacheStats logs the cache counters of the subprovider for the operation
+func (m *meta) logCacheStats(name string) {
+	stats := m.cacheStats.get(name)
+	if stats == (cacheCounters{}) {
+		return
+	}
+	m.Log("meta", "CacheStats").WithFields(log.Fields{
+		"subprovider": name,
+		"hits":        stats.hits,
+		"misses":      stats.misses,
+		"evictions":   stats.evictions,
+	}).Debugf("cache statistics for %s: %d hits, %d misses, %d evictions", name, stats.hits, stats.misses, stats.evictions)
+}
+
 func (m *meta) CacheSet(prov Subprovider, key string, val interface{}) error {
+	return m.cacheSet(m.cache, prov, key, val)
+}
+
+func (m *meta) CacheGet(prov Subprovider, key string, out interface{}) error {
+	return m.cacheGet(m.cache, prov, key, out)
+}
+
+// RunCacheSet sets a value in the in-memory cache of the current run
+func (m *meta) RunCacheSet(prov Subprovider, key string, val interface{}) error {
+	return m.cacheSet(m.runCache, prov, key, val)
+}
+
+// RunCacheGet returns an object from the in-memory cache of the current run
+func (m *meta) RunCacheGet(prov Subprovider, key string, out interface{}) error {
+	return m.cacheGet(m.runCache, prov, key, out)
+}
+
+func (m *meta) cacheSet(cache cacheBackend, prov Subprovider, key string, val interface{}) error {
 	log := m.Log("meta", "CacheSet")
 
-	if !m.cacheEnabled {
+	if !m.cacheEnabled || !m.cacheSettings.enabled(prov.Name()) {
 		log.Debug("cache disabled")
 		return ErrCacheDisabled
 	}
 
-	key = fmt.Sprintf("%s:%s", key, prov.Name())
+	key = m.cacheKey(prov, key)
 
 	data, err := json.Marshal(val)
 	if err != nil {
@@ -75,28 +172,35 @@ func (m *meta) CacheSet(prov Subprovider, key string, val interface{}) error {
 
 	log.Debugf("cache set for for key %s [%d bytes]", key, len(data))
 
-	return instance.cache.Set(key, data)
+	return cache.Set(key, data, m.cacheSettings.ttl(prov.Name()))
 }
 
-func (m *meta) CacheGet(prov Subprovider, key string, out interface{}) error {
+func (m *meta) cacheGet(cache cacheBackend, prov Subprovider, key string, out interface{}) error {
 	log := m.Log("meta", "CacheGet")
 
-	if !m.cacheEnabled {
+	if !m.cacheEnabled || !m.cacheSettings.enabled(prov.Name()) {
 		log.Debug("cache disabled")
 		return ErrCacheDisabled
 	}
 
-	key = fmt.Sprintf("%s:%s", key, prov.Name())
+	key = m.cacheKey(prov, key)
 
-	data, err := instance.cache.Get(key)
+	data, err := cache.Get(key)
 	if err != nil {
-		if err == bigcache.ErrEntryNotFound {
+		if errors.Is(err, errCacheEntryExpired) {
+			log.Debugf("cache entry expired for key %s", key)
+			m.cacheStats.evict(prov.Name())
+			err = ErrCacheEntryNotFound
+		}
+		if errors.Is(err, ErrCacheEntryNotFound) {
 			log.Debugf("cache miss for for key %s", key)
+			m.cacheStats.miss(prov.Name())
 
 			return ErrCacheEntryNotFound
 		}
 		return err
 	}
+	m.cacheStats.hit(prov.Name())
 
 	log.Debugf("cache get for for key %s: [%d bytes]", key, len(data))
 
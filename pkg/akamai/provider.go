@@ -8,12 +8,10 @@ import (
 	"strconv"
 	"strings"
 	"sync"
-	"time"
 
 	"github.com/allegro/bigcache/v2"
 	"github.com/apex/log"
 	"github.com/google/uuid"
-	"github.com/hashicorp/go-hclog"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/plugin"
@@ -60,6 +58,9 @@ type (
 		Configure(log.Interface, *schema.ResourceData) diag.Diagnostics
 	}
 
+	// crudFunc is the common signature of resource create, read, update and delete functions
+	crudFunc = func(context.Context, *schema.ResourceData, interface{}) diag.Diagnostics
+
 	provider struct {
 		schema.Provider
 		subs  map[string]Subprovider
@@ -95,11 +96,83 @@ func Provider(provs ...Subprovider) plugin.ProviderFunc {
 						Elem:     config.Options("config"),
 						MaxItems: 1,
 					},
+					"credential_process": {
+						Description: "Command returning the credentials, used instead of the edgerc file and env variables",
+						Optional:    true,
+						Type:        schema.TypeSet,
+						Elem:        credentialProcessOptions(),
+						MaxItems:    1,
+					},
+					"credential_files": {
+						Description: "Files containing each of the credential values, used instead of the edgerc file and env variables",
+						Optional:    true,
+						Type:        schema.TypeSet,
+						Elem:        credentialFilesOptions(),
+						MaxItems:    1,
+					},
+					"default_contract_id": {
+						Description: "The contract ID used by resources which do not set one, with or without the ctr_ prefix",
+						Optional:    true,
+						Type:        schema.TypeString,
+					},
+					"default_group_id": {
+						Description: "The group ID used by resources which do not set one, with or without the grp_ prefix",
+						Optional:    true,
+						Type:        schema.TypeString,
+					},
+					"read_only": {
+						Description: "Reject all API calls which could modify Akamai configurations",
+						Optional:    true,
+						Default:     false,
+						Type:        schema.TypeBool,
+					},
+					"logging": {
+						Description: "Settings of the provider logs",
+						Optional:    true,
+						Type:        schema.TypeSet,
+						Elem:        loggingOptions(),
+						MaxItems:    1,
+					},
+					"http": {
+						Description: "Settings of the HTTP client sending API requests",
+						Optional:    true,
+						Type:        schema.TypeSet,
+						Elem:        httpClientOptions(),
+						MaxItems:    1,
+					},
+					"tracing": {
+						Description: "Export traces of the provider operations and API requests to an OpenTelemetry collector",
+						Optional:    true,
+						Type:        schema.TypeSet,
+						Elem:        tracingOptions(),
+						MaxItems:    1,
+					},
 					"cache_enabled": {
 						Optional: true,
 						Default:  true,
 						Type:     schema.TypeBool,
 					},
+					"cache": {
+						Description: "Settings of the cache used for API lookups",
+						Optional:    true,
+						Type:        schema.TypeSet,
+						Elem:        cacheOptions(),
+						MaxItems:    1,
+					},
+					"concurrency": {
+						Description: "Limits of concurrent API requests",
+						Optional:    true,
+						Type:        schema.TypeSet,
+						Elem:        concurrencyOptions(),
+						MaxItems:    1,
+					},
+					"retry": {
+						Description: "Retry settings for API requests rejected with throttling or transient errors",
+						Optional:    true,
+						Type:        schema.TypeSet,
+						Elem:        retryOptions(),
+						MaxItems:    1,
+					},
 				},
 				ResourcesMap:       make(map[string]*schema.Resource),
 				DataSourcesMap:     make(map[string]*schema.Resource),
@@ -108,7 +181,7 @@ func Provider(provs ...Subprovider) plugin.ProviderFunc {
 			subs: make(map[string]Subprovider),
 		}
 
-		cache, err := bigcache.NewBigCache(bigcache.DefaultConfig(10 * time.Minute))
+		cache, err := newMemoryCacheStorage()
 		if err != nil {
 			panic(err)
 		}
@@ -133,6 +206,30 @@ func Provider(provs ...Subprovider) plugin.ProviderFunc {
 			instance.DataSourcesMap = dataSources
 
 			instance.subs[p.Name()] = p
+
+			for name, r := range p.Resources() {
+				withCacheStatsLogging(r, p.Name())
+				withOperationLogging(r, name, p.Name())
+				withOperationTracing(r, name, p.Name())
+			}
+			for name, r := range p.DataSources() {
+				withCacheStatsLogging(r, p.Name())
+				withOperationLogging(r, name, p.Name())
+				withOperationTracing(r, name, p.Name())
+			}
+		}
+
+		for name, r := range instance.ResourcesMap {
+			if err := withAccountKeyOverride(r); err != nil {
+				panic(err)
+			}
+			withResourceName(r, name)
+		}
+		for name, r := range instance.DataSourcesMap {
+			if err := withAccountKeyOverride(r); err != nil {
+				panic(err)
+			}
+			withResourceName(r, name)
 		}
 
 		instance.ConfigureContextFunc = func(ctx context.Context, d *schema.ResourceData) (interface{}, diag.Diagnostics) {
@@ -149,8 +246,18 @@ func configureContext(ctx context.Context, d *schema.ResourceData) (interface{},
 	// generate an operation id so we can correlate all calls to this provider
 	opid := uuid.Must(uuid.NewRandom()).String()
 
-	// create a log from the hclog in the context
-	log := hclog.FromContext(ctx).With(
+	logConf, err := getLogConfig(d)
+	if err != nil {
+		return nil, diag.FromErr(err)
+	}
+	addSensitiveFields(logConf.redactedFields)
+
+	// create a log from the hclog in the context, or a JSON logger if configured
+	baseLog, err := logConf.newLogger(ctx)
+	if err != nil {
+		return nil, diag.FromErr(err)
+	}
+	log := baseLog.With(
 		"OperationID", opid,
 	)
 
@@ -166,6 +273,124 @@ func configureContext(ctx context.Context, d *schema.ResourceData) (interface{},
 		return nil, diag.FromErr(err)
 	}
 
+	// PROVIDER_VERSION env value must be updated in version file, for every new release.
+	userAgent := instance.UserAgent(ProviderName, version.ProviderVersion)
+	logger := LogFromHCLog(log)
+	logger.Infof("Provider version: %s", version.ProviderVersion)
+
+	// credentials from an external source take precedence over the edgerc file and env variables
+	creds, err := getExternalCredentials(ctx, d, logger)
+	if err != nil {
+		return nil, diag.FromErr(err)
+	}
+	if creds == nil {
+		edgerc, diags := getEdgercConfig(d)
+		if diags != nil {
+			// recorded API calls can be replayed without access to the account
+			if !isReplaying() {
+				return nil, diags
+			}
+			logger.Warn("No credentials configured, replaying recorded API calls with placeholder credentials")
+			edgerc = replayConfig()
+		}
+		creds = &staticCredentials{edgerc: edgerc}
+	}
+	edgerc := creds.config()
+
+	readOnly, err := tools.GetBoolValue("read_only", d)
+	if err != nil && !IsNotFoundError(err) {
+		return nil, diag.FromErr(err)
+	}
+	if readOnly {
+		logger.Info("Provider is in read-only mode, API calls which could modify configurations are rejected")
+	}
+
+	retryConf, err := getRetryConfig(d)
+	if err != nil {
+		return nil, diag.FromErr(err)
+	}
+
+	limiter, err := getRequestLimiter(d, instance.subs)
+	if err != nil {
+		return nil, diag.FromErr(err)
+	}
+
+	httpConf, err := getHTTPClientConfig(d)
+	if err != nil {
+		return nil, diag.FromErr(err)
+	}
+	httpClient, err := newHTTPClient(httpConf)
+	if err != nil {
+		return nil, diag.FromErr(err)
+	}
+	httpClient.Transport, err = getRecordingTransport(httpClient.Transport, opid)
+	if err != nil {
+		return nil, diag.FromErr(err)
+	}
+
+	tracingConf, err := getTracingConfig(d)
+	if err != nil {
+		return nil, diag.FromErr(err)
+	}
+	tracer := newTracer(tracingConf, opid, logger)
+	if tracer != nil {
+		logger.Infof("Exporting traces to %s", tracingConf.endpoint)
+	}
+
+	sessBuilder := &sessionBuilder{
+		opts: []session.Option{
+			session.WithClient(httpClient),
+			session.WithUserAgent(userAgent),
+			session.WithLog(logger),
+			session.WithHTTPTracing(cast.ToBool(os.Getenv("AKAMAI_HTTP_TRACE_ENABLED"))),
+		},
+		retry:    retryConf,
+		limiter:  limiter,
+		readOnly: readOnly,
+		tracer:   tracer,
+	}
+
+	sess, err := sessBuilder.build(&accountSigner{creds: creds})
+	if err != nil {
+		return nil, diag.FromErr(err)
+	}
+
+	defaultContractID, err := tools.GetStringValue("default_contract_id", d)
+	if err != nil && !errors.Is(err, tools.ErrNotFound) {
+		return nil, diag.FromErr(err)
+	}
+	defaultGroupID, err := tools.GetStringValue("default_group_id", d)
+	if err != nil && !errors.Is(err, tools.ErrNotFound) {
+		return nil, diag.FromErr(err)
+	}
+
+	cache, cacheSettings, err := getCacheConfig(d, instance.subs, cacheNamespace(version.ProviderVersion, edgerc.Host, edgerc.ClientToken, edgerc.AccessToken))
+	if err != nil {
+		return nil, diag.FromErr(err)
+	}
+
+	meta := &meta{
+		log:           log,
+		operationID:   opid,
+		sess:          sess,
+		sessBuilder:   sessBuilder,
+		creds:         creds,
+		accountKey:    edgerc.AccountKey,
+		cacheEnabled:  cacheEnabled,
+		cache:         cache,
+		runCache:      &memoryCache{cache: instance.cache},
+		cacheSettings: cacheSettings,
+		cacheStats:    newCacheStats(),
+		tracer:        tracer,
+		contractID:    tools.NormalizeID(defaultContractID, tools.ContractPrefix, true),
+		groupID:       tools.NormalizeID(defaultGroupID, tools.GroupPrefix, true),
+	}
+
+	return meta, nil
+}
+
+// getEdgercConfig loads the edgegrid configuration from the edgerc file, the config block and env variables
+func getEdgercConfig(d *schema.ResourceData) (*edgegrid.Config, diag.Diagnostics) {
 	edgercOps := []edgegrid.Option{edgegrid.WithEnv(true)}
 
 	edgercPath, err := tools.GetStringValue("edgerc", d)
@@ -206,26 +431,12 @@ func configureContext(ctx context.Context, d *schema.ResourceData) (interface{},
 		return nil, diag.Errorf(err.Error())
 	}
 
-	// PROVIDER_VERSION env value must be updated in version file, for every new release.
-	userAgent := instance.UserAgent(ProviderName, version.ProviderVersion)
-	logger := LogFromHCLog(log)
-	logger.Infof("Provider version: %s", version.ProviderVersion)
-
-	sess, err := session.New(
-		session.WithSigner(edgerc),
-		session.WithUserAgent(userAgent),
-		session.WithLog(logger),
-		session.WithHTTPTracing(cast.ToBool(os.Getenv("AKAMAI_HTTP_TRACE_ENABLED"))),
-	)
-
-	meta := &meta{
-		log:          log,
-		operationID:  opid,
-		sess:         sess,
-		cacheEnabled: cacheEnabled,
+	// the edgegrid library does not read the account key from env, so it is applied here
+	if accountKey := accountKeyFromEnv(edgercSection); accountKey != "" {
+		edgerc.AccountKey = accountKey
 	}
 
-	return meta, nil
+	return edgerc, nil
 }
 
 func getEdgercPath(edgercPath string) string {
@@ -236,7 +447,7 @@ func getEdgercPath(edgercPath string) string {
 }
 
 func setEdgegridEnvs(envsMap map[string]interface{}, section string) error {
-	configEnvs := []string{"ACCESS_TOKEN", "CLIENT_TOKEN", "HOST", "CLIENT_SECRET", "MAX_BODY"}
+	configEnvs := []string{"ACCESS_TOKEN", "CLIENT_TOKEN", "HOST", "CLIENT_SECRET", "MAX_BODY", "ACCOUNT_KEY"}
 	prefix := "AKAMAI"
 	if section != "" {
 		prefix = fmt.Sprintf("%s_%s", prefix, strings.ToUpper(section))
@@ -271,6 +482,18 @@ func setEdgegridEnvs(envsMap map[string]interface{}, section string) error {
 				return fmt.Errorf("%w: %s, %q", tools.ErrInvalidType, "max_body", "int")
 			}
 			value = strconv.Itoa(maxBody)
+		case "ACCOUNT_KEY":
+			accountKey, ok := envsMap["account_key"]
+			if !ok || accountKey == nil {
+				continue
+			}
+			value, ok = accountKey.(string)
+			if !ok {
+				return fmt.Errorf("%w: %s, %q", tools.ErrInvalidType, "account_key", "string")
+			}
+			if value == "" {
+				continue
+			}
 		}
 		env = fmt.Sprintf("%s_%s", prefix, env)
 		if os.Getenv(env) != "" {
@@ -283,6 +506,20 @@ func setEdgegridEnvs(envsMap map[string]interface{}, section string) error {
 	return nil
 }
 
+// wrapCRUD replaces each of the resource create, read, update and delete functions with the wrapped function
+func wrapCRUD(r *schema.Resource, wrap func(crudFunc) crudFunc) {
+	for _, f := range []*crudFunc{
+		(*crudFunc)(&r.CreateContext),
+		(*crudFunc)(&r.ReadContext),
+		(*crudFunc)(&r.UpdateContext),
+		(*crudFunc)(&r.DeleteContext),
+	} {
+		if *f != nil {
+			*f = wrap(*f)
+		}
+	}
+}
+
 func mergeSchema(from, to map[string]*schema.Schema) (map[string]*schema.Schema, error) {
 	for k, v := range from {
 		if _, ok := to[k]; ok {
@@ -86,6 +86,25 @@ func TestSetEdgegridEnvs(t *testing.T) {
 				"AKAMAI_TEST_MAX_BODY":      "123",
 			},
 		},
+		"account key provided": {
+			givenMap: map[string]interface{}{
+				"access_token":  "test_access_token",
+				"client_token":  "test_client_token",
+				"client_secret": "test_client_secret",
+				"host":          "test_host",
+				"max_body":      123,
+				"account_key":   "test_account_key",
+			},
+			givenSection: "test",
+			expectedEnvs: map[string]string{
+				"AKAMAI_TEST_ACCESS_TOKEN":  "test_access_token",
+				"AKAMAI_TEST_CLIENT_TOKEN":  "test_client_token",
+				"AKAMAI_TEST_CLIENT_SECRET": "test_client_secret",
+				"AKAMAI_TEST_HOST":          "test_host",
+				"AKAMAI_TEST_MAX_BODY":      "123",
+				"AKAMAI_TEST_ACCOUNT_KEY":   "test_account_key",
+			},
+		},
 		"envs are already set": {
 			givenMap: map[string]interface{}{
 				"access_token":  "test_access_token",
@@ -162,6 +181,16 @@ func TestSetWrongTypeForEdgegridEnvs(t *testing.T) {
 		"wrong type of max_body value": {
 			environmentVars: map[string]interface{}{"max_body": "not a number"},
 		},
+		"wrong type of account_key value": {
+			environmentVars: map[string]interface{}{
+				"access_token":  "test_access_token",
+				"client_token":  "test_client_token",
+				"client_secret": "test_client_secret",
+				"host":          "test_host",
+				"max_body":      123,
+				"account_key":   123,
+			},
+		},
 	}
 	for name, test := range tests {
 		t.Run(name, func(t *testing.T) {
@@ -0,0 +1,78 @@
+package akamai
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v2/pkg/session"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+type (
+	// readOnlySession is a session which rejects all requests which could modify an Akamai configuration
+	readOnlySession struct {
+		session.Session
+	}
+
+	resourceContextKey struct{}
+)
+
+var (
+	// ErrReadOnly is returned when a mutating API call is made while the provider is in read-only mode
+	ErrReadOnly = errors.New("provider is in read-only mode")
+
+	// readOnlyPOSTPaths lists the endpoints which use POST to read data without modifying anything
+	readOnlyPOSTPaths = map[string]struct{}{
+		"/papi/v1/search/find-by-value": {},
+	}
+)
+
+// withReadOnly wraps the session so that only requests which do not modify anything are sent
+func withReadOnly(sess session.Session) session.Session {
+	return &readOnlySession{
+		Session: sess,
+	}
+}
+
+// Exec executes the request, or returns ErrReadOnly when it could modify an Akamai configuration
+func (s *readOnlySession) Exec(r *http.Request, out interface{}, in ...interface{}) (*http.Response, error) {
+	if !isReadOnlyRequest(r) {
+		name, ok := r.Context().Value(resourceContextKey{}).(string)
+		if !ok {
+			name = "provider"
+		}
+		s.Log(r.Context()).Errorf("read-only mode: blocked %s %s for %s", r.Method, r.URL.Path, name)
+		return nil, fmt.Errorf("%w: %s %s for %s is not allowed", ErrReadOnly, r.Method, r.URL.Path, name)
+	}
+
+	return s.Session.Exec(r, out, in...)
+}
+
+func isReadOnlyRequest(r *http.Request) bool {
+	switch r.Method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions:
+		return true
+	case http.MethodPost:
+		_, ok := readOnlyPOSTPaths[r.URL.Path]
+		return ok
+	}
+	return false
+}
+
+// withResourceName adds the name of the resource or data source to the context of all its operations,
+// so that the API calls made for it can be attributed to it
+func withResourceName(r *schema.Resource, name string) {
+	wrapCRUD(r, func(f crudFunc) crudFunc {
+		return func(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+			return f(context.WithValue(ctx, resourceContextKey{}, name), d, m)
+		}
+	})
+	if customizeDiff := r.CustomizeDiff; customizeDiff != nil {
+		r.CustomizeDiff = func(ctx context.Context, d *schema.ResourceDiff, m interface{}) error {
+			return customizeDiff(context.WithValue(ctx, resourceContextKey{}, name), d, m)
+		}
+	}
+}
@@ -0,0 +1,108 @@
+package akamai
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v2/pkg/edgegrid"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/stretchr/testify/require"
+	"github.com/tj/assert"
+)
+
+func TestReadOnlySession_Exec(t *testing.T) {
+	var calls int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&calls, 1)
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer srv.Close()
+
+	builder := &sessionBuilder{readOnly: true, retry: &retryConfig{maxAttempts: 3}}
+	sess, err := builder.build(&edgegrid.Config{MaxBody: edgegrid.MaxBodySize})
+	require.NoError(t, err)
+
+	tests := map[string]struct {
+		method    string
+		path      string
+		withError bool
+	}{
+		"GET is allowed": {
+			method: http.MethodGet,
+			path:   "/papi/v1/properties/prp_1",
+		},
+		"HEAD is allowed": {
+			method: http.MethodHead,
+			path:   "/papi/v1/properties/prp_1",
+		},
+		"POST to search is allowed": {
+			method: http.MethodPost,
+			path:   "/papi/v1/search/find-by-value",
+		},
+		"POST is blocked": {
+			method:    http.MethodPost,
+			path:      "/appsec/v1/configs/1/versions",
+			withError: true,
+		},
+		"PUT is blocked": {
+			method:    http.MethodPut,
+			path:      "/papi/v1/properties/prp_1/versions/1/rules",
+			withError: true,
+		},
+		"PATCH is blocked": {
+			method:    http.MethodPatch,
+			path:      "/papi/v1/properties/prp_1/versions/1/hostnames",
+			withError: true,
+		},
+		"DELETE is blocked": {
+			method:    http.MethodDelete,
+			path:      "/papi/v1/properties/prp_1",
+			withError: true,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			atomic.StoreInt32(&calls, 0)
+			req, err := http.NewRequest(test.method, srv.URL+test.path, nil)
+			require.NoError(t, err)
+			_, err = sess.Exec(req, nil)
+			if test.withError {
+				assert.True(t, errors.Is(err, ErrReadOnly), "want: %s; got: %s", ErrReadOnly, err)
+				assert.Contains(t, err.Error(), test.path)
+				assert.Equal(t, int32(0), atomic.LoadInt32(&calls))
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, int32(1), atomic.LoadInt32(&calls))
+		})
+	}
+}
+
+func TestWithResourceName(t *testing.T) {
+	builder := &sessionBuilder{readOnly: true}
+	sess, err := builder.build(&edgegrid.Config{MaxBody: edgegrid.MaxBodySize})
+	require.NoError(t, err)
+
+	var execErr error
+	res := &schema.Resource{
+		DeleteContext: func(ctx context.Context, _ *schema.ResourceData, _ interface{}) diag.Diagnostics {
+			req, err := http.NewRequestWithContext(ctx, http.MethodDelete, "https://host/papi/v1/properties/prp_1", nil)
+			require.NoError(t, err)
+			_, execErr = sess.Exec(req, nil)
+			return diag.FromErr(execErr)
+		},
+	}
+	withResourceName(res, "akamai_property")
+
+	diags := res.DeleteContext(context.Background(), nil, nil)
+	require.True(t, diags.HasError())
+	assert.True(t, errors.Is(execErr, ErrReadOnly))
+	assert.True(t, strings.HasSuffix(execErr.Error(), "DELETE /papi/v1/properties/prp_1 for akamai_property is not allowed"), execErr.Error())
+}
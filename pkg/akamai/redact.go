@@ -0,0 +1,191 @@
+package akamai
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"strings"
+	"sync"
+	"sync/atomic"
+)
+
+const redactedValue = "REDACTED"
+
+var (
+	// defaultSensitiveFields lists the fields whose values are never written to logs and recordings,
+	// it covers credentials, connector secrets and personal details of users and certificate contacts
+	defaultSensitiveFields = []string{
+		"access_key",
+		"access_token",
+		"address",
+		"address_line_one",
+		"address_line_two",
+		"auth_token",
+		"client_secret",
+		"client_token",
+		"email",
+		"event_collector_token",
+		"first_name",
+		"last_name",
+		"mobile_phone",
+		"password",
+		"phone",
+		"private_key",
+		"secondary_email",
+		"secret",
+		"secret_access_key",
+		"token",
+	}
+
+	// sensitiveHeaders are the HTTP headers whose values are never written to logs and recordings
+	sensitiveHeaders = []string{"Authorization", "Cookie", "Set-Cookie"}
+
+	// currentSensitiveFields holds the normalized set of sensitive fields, it is replaced rather than modified,
+	// so that the set can be read without locking
+	currentSensitiveFields atomic.Value
+	sensitiveFieldsMu      sync.Mutex
+)
+
+func init() {
+	currentSensitiveFields.Store(sensitiveFieldSet(defaultSensitiveFields))
+}
+
+// sensitiveFields returns the normalized set of the default sensitive fields and the fields added in the provider configuration
+func sensitiveFields() map[string]struct{} {
+	return currentSensitiveFields.Load().(map[string]struct{})
+}
+
+// addSensitiveFields adds fields to the set of sensitive fields. Fields are never removed, so that the
+// fields configured for one provider instance are still redacted when another one is configured.
+func addSensitiveFields(fields []string) {
+	sensitiveFieldsMu.Lock()
+	defer sensitiveFieldsMu.Unlock()
+
+	current := sensitiveFields()
+	updated := make(map[string]struct{}, len(current)+len(fields))
+	for field := range current {
+		updated[field] = struct{}{}
+	}
+	for field := range sensitiveFieldSet(fields) {
+		updated[field] = struct{}{}
+	}
+	currentSensitiveFields.Store(updated)
+}
+
+// normalizeFieldName makes field names comparable regardless of their case and word separators,
+// so that client_secret, clientSecret and client-secret are all the same field
+func normalizeFieldName(name string) string {
+	return strings.ToLower(strings.NewReplacer("_", "", "-", "").Replace(name))
+}
+
+// sensitiveFieldSet returns the set of normalized field names for the given fields
+func sensitiveFieldSet(fields []string) map[string]struct{} {
+	set := make(map[string]struct{}, len(fields))
+	for _, field := range fields {
+		set[normalizeFieldName(field)] = struct{}{}
+	}
+	return set
+}
+
+// isSensitiveField returns true if the value of the field has to be redacted
+func isSensitiveField(name string, fields map[string]struct{}) bool {
+	_, ok := fields[normalizeFieldName(name)]
+	return ok
+}
+
+// redactJSON replaces the values of all sensitive fields in the JSON document,
+// data which is not a JSON object or array is returned unchanged
+func redactJSON(data []byte, fields map[string]struct{}) []byte {
+	trimmed := bytes.TrimSpace(data)
+	if len(trimmed) == 0 || (trimmed[0] != '{' && trimmed[0] != '[') {
+		return data
+	}
+
+	dec := json.NewDecoder(bytes.NewReader(trimmed))
+	dec.UseNumber()
+	var doc interface{}
+	if err := dec.Decode(&doc); err != nil {
+		return data
+	}
+
+	redacted, err := json.Marshal(redactValue(doc, fields))
+	if err != nil {
+		return data
+	}
+	return redacted
+}
+
+func redactValue(val interface{}, fields map[string]struct{}) interface{} {
+	switch v := val.(type) {
+	case map[string]interface{}:
+		for key, item := range v {
+			if isSensitiveField(key, fields) {
+				v[key] = redactedValue
+				continue
+			}
+			v[key] = redactValue(item, fields)
+		}
+	case []interface{}:
+		for i, item := range v {
+			v[i] = redactValue(item, fields)
+		}
+	}
+	return val
+}
+
+// redactBody redacts the body of a request or response sent to the given path.
+// EdgeKV item values are not JSON documents with known fields, so they are redacted as a whole.
+func redactBody(path string, body []byte) []byte {
+	if len(body) == 0 {
+		return body
+	}
+	if strings.HasPrefix(path, "/edgekv/v1/") && strings.Contains(path, "/items/") {
+		return []byte(redactedValue)
+	}
+	return redactJSON(body, sensitiveFields())
+}
+
+// redactHeader returns a copy of the header with the values of sensitive headers redacted
+func redactHeader(header http.Header) http.Header {
+	redacted := header.Clone()
+	for _, name := range sensitiveHeaders {
+		if _, ok := redacted[name]; ok {
+			redacted.Set(name, redactedValue)
+		}
+	}
+	return redacted
+}
+
+// redactMessage redacts a log message, which can be a request or response dump written by the HTTP tracing
+func redactMessage(msg string) string {
+	head, body, isDump := splitHTTPDump(msg)
+	if !isDump {
+		return string(redactJSON([]byte(msg), sensitiveFields()))
+	}
+
+	lines := strings.Split(head, "\r\n")
+	for i, line := range lines[1:] {
+		name := strings.SplitN(line, ":", 2)[0]
+		for _, header := range sensitiveHeaders {
+			if strings.EqualFold(name, header) {
+				lines[i+1] = name + ": " + redactedValue
+			}
+		}
+	}
+
+	// the path is only known for requests, the first line of a response dump is the status line
+	var path string
+	if requestLine := strings.Fields(lines[0]); len(requestLine) == 3 && !strings.HasPrefix(requestLine[0], "HTTP/") {
+		path = requestLine[1]
+	}
+
+	return strings.Join(lines, "\r\n") + "\r\n\r\n" + string(redactBody(path, []byte(body)))
+}
+
+func splitHTTPDump(msg string) (string, string, bool) {
+	parts := strings.SplitN(msg, "\r\n\r\n", 2)
+	if len(parts) != 2 || !strings.Contains(strings.SplitN(parts[0], "\r\n", 2)[0], "HTTP/") {
+		return "", "", false
+	}
+	return parts[0], parts[1], true
+}
@@ -0,0 +1,86 @@
+package akamai
+
+import (
+	"testing"
+
+	"github.com/tj/assert"
+)
+
+func TestRedactJSON(t *testing.T) {
+	fields := sensitiveFieldSet(defaultSensitiveFields)
+
+	tests := map[string]struct {
+		given    string
+		expected string
+	}{
+		"object": {
+			given:    `{"name": "test", "clientSecret": "abc", "nested": {"Password": "def", "id": 123456789012345678}}`,
+			expected: `{"clientSecret":"REDACTED","name":"test","nested":{"Password":"REDACTED","id":123456789012345678}}`,
+		},
+		"array": {
+			given:    `[{"access-token": "abc"}, {"name": "test"}]`,
+			expected: `[{"access-token":"REDACTED"},{"name":"test"}]`,
+		},
+		"not JSON": {
+			given:    `client_secret=abc`,
+			expected: `client_secret=abc`,
+		},
+		"invalid JSON": {
+			given:    `{"client_secret": `,
+			expected: `{"client_secret": `,
+		},
+		"empty": {
+			given:    ``,
+			expected: ``,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, test.expected, string(redactJSON([]byte(test.given), fields)))
+		})
+	}
+}
+
+func TestRedactMessage(t *testing.T) {
+	tests := map[string]struct {
+		given    string
+		expected string
+	}{
+		"request dump": {
+			given:    "POST /datastream-config-api/v1/log/streams HTTP/1.1\r\nHost: host\r\nAuthorization: EG1-HMAC-SHA256 client_token=abc\r\n\r\n{\"connectors\":[{\"accessKey\":\"abc\",\"bucket\":\"logs\"}]}",
+			expected: "POST /datastream-config-api/v1/log/streams HTTP/1.1\r\nHost: host\r\nAuthorization: REDACTED\r\n\r\n{\"connectors\":[{\"accessKey\":\"REDACTED\",\"bucket\":\"logs\"}]}",
+		},
+		"response dump": {
+			given:    "HTTP/1.1 200 OK\r\nContent-Type: application/json\r\n\r\n{\"email\":\"user@example.com\",\"uiIdentityId\":\"A-B-123\"}",
+			expected: "HTTP/1.1 200 OK\r\nContent-Type: application/json\r\n\r\n{\"email\":\"REDACTED\",\"uiIdentityId\":\"A-B-123\"}",
+		},
+		"EdgeKV item": {
+			given:    "PUT /edgekv/v1/networks/staging/namespaces/ns/groups/g/items/key HTTP/1.1\r\nHost: host\r\n\r\nitem value",
+			expected: "PUT /edgekv/v1/networks/staging/namespaces/ns/groups/g/items/key HTTP/1.1\r\nHost: host\r\n\r\nREDACTED",
+		},
+		"JSON message": {
+			given:    `{"password": "abc"}`,
+			expected: `{"password":"REDACTED"}`,
+		},
+		"plain message": {
+			given:    "Provider version: dev",
+			expected: "Provider version: dev",
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, test.expected, redactMessage(test.given))
+		})
+	}
+}
+
+func TestAddSensitiveFields(t *testing.T) {
+	defer currentSensitiveFields.Store(sensitiveFields())
+
+	assert.False(t, isSensitiveField("connectorSecret", sensitiveFields()))
+	addSensitiveFields([]string{"connector_secret"})
+	assert.True(t, isSensitiveField("connectorSecret", sensitiveFields()))
+	assert.True(t, isSensitiveField("client_secret", sensitiveFields()))
+}
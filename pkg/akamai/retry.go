@@ -0,0 +1,245 @@
+package akamai
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v2/pkg/session"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+
+	"github.com/akamai/terraform-provider-akamai/v2/pkg/tools"
+)
+
+type (
+	// retryConfig holds the settings of the retry block
+	retryConfig struct {
+		maxAttempts int
+		minBackoff  time.Duration
+		maxBackoff  time.Duration
+	}
+
+	// retrySession is a session which retries requests rejected with throttling or transient errors
+	retrySession struct {
+		session.Session
+		conf retryConfig
+	}
+)
+
+const (
+	// rateLimitNextHeader is returned by Akamai APIs along with 429 responses
+	// and holds the time at which the next request will be accepted
+	rateLimitNextHeader = "X-RateLimit-Next"
+)
+
+// retryOptions returns the schema of the provider retry block
+func retryOptions() *schema.Resource {
+	return &schema.Resource{
+		Schema: map[string]*schema.Schema{
+			"max_attempts": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      5,
+				ValidateFunc: validation.IntAtLeast(1),
+				Description:  "The maximum number of attempts for a single API request",
+			},
+			"min_backoff": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      1,
+				ValidateFunc: validation.IntAtLeast(0),
+				Description:  "The minimum time in seconds to wait before a request is retried",
+			},
+			"max_backoff": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      30,
+				ValidateFunc: validation.IntAtLeast(0),
+				Description:  "The maximum time in seconds to wait before a request is retried, unless the API asks for a longer wait",
+			},
+		},
+	}
+}
+
+// getRetryConfig reads the retry block from the provider configuration, nil is returned when retries are not configured
+func getRetryConfig(d *schema.ResourceData) (*retryConfig, error) {
+	retry, err := tools.GetSetValue("retry", d)
+	if err != nil {
+		if errors.Is(err, tools.ErrNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	if retry.Len() == 0 {
+		return nil, nil
+	}
+
+	retryMap, ok := retry.List()[0].(map[string]interface{})
+	if !ok {
+		return nil, fmt.Errorf("%w: %s, %q", tools.ErrInvalidType, "retry", "map[string]interface{}")
+	}
+	maxAttempts, ok := retryMap["max_attempts"].(int)
+	if !ok {
+		return nil, fmt.Errorf("%w: %s, %q", tools.ErrInvalidType, "max_attempts", "int")
+	}
+	minBackoff, ok := retryMap["min_backoff"].(int)
+	if !ok {
+		return nil, fmt.Errorf("%w: %s, %q", tools.ErrInvalidType, "min_backoff", "int")
+	}
+	maxBackoff, ok := retryMap["max_backoff"].(int)
+	if !ok {
+		return nil, fmt.Errorf("%w: %s, %q", tools.ErrInvalidType, "max_backoff", "int")
+	}
+	if minBackoff > maxBackoff {
+		return nil, fmt.Errorf("retry: min_backoff (%d) cannot be greater than max_backoff (%d)", minBackoff, maxBackoff)
+	}
+
+	return &retryConfig{
+		maxAttempts: maxAttempts,
+		minBackoff:  time.Duration(minBackoff) * time.Second,
+		maxBackoff:  time.Duration(maxBackoff) * time.Second,
+	}, nil
+}
+
+// withRetries wraps the session so that every request is retried according to the configuration
+func withRetries(sess session.Session, conf retryConfig) session.Session {
+	return &retrySession{
+		Session: sess,
+		conf:    conf,
+	}
+}
+
+// Exec executes the request, retrying it when the response is throttled or a transient error occurs.
+// Idempotent requests are retried on server errors as well, while mutating requests are only retried
+// when the API explicitly rejected them with 429 Too Many Requests.
+func (s *retrySession) Exec(r *http.Request, out interface{}, in ...interface{}) (*http.Response, error) {
+	// the session modifies the request while signing it, so every attempt is made on a fresh copy
+	var body []byte
+	if r.Body != nil {
+		var err error
+		body, err = ioutil.ReadAll(r.Body)
+		if err != nil {
+			return nil, err
+		}
+		if err := r.Body.Close(); err != nil {
+			return nil, err
+		}
+	}
+	ctx := r.Context()
+	log := s.Log(ctx)
+
+	for attempt := 1; ; attempt++ {
+		req := r.Clone(ctx)
+		if body != nil {
+			req.Body = ioutil.NopCloser(bytes.NewReader(body))
+		}
+
+		resp, err := s.Session.Exec(req, out, in...)
+		if attempt >= s.conf.maxAttempts || !s.shouldRetry(req, resp, err) {
+			recordRetries(ctx, attempt-1)
+			return resp, err
+		}
+
+		wait := s.backoff(attempt, resp)
+		if err != nil {
+			log.WithError(err).Warnf("%s %s failed (attempt %d of %d), retrying in %s", r.Method, r.URL.Path, attempt, s.conf.maxAttempts, wait)
+		} else {
+			log.Warnf("%s %s returned %d (attempt %d of %d), retrying in %s", r.Method, r.URL.Path, resp.StatusCode, attempt, s.conf.maxAttempts, wait)
+			_, _ = io.Copy(ioutil.Discard, resp.Body)
+			_ = resp.Body.Close()
+		}
+
+		select {
+		case <-ctx.Done():
+			recordRetries(ctx, attempt-1)
+			return nil, ctx.Err()
+		case <-time.After(wait):
+		}
+	}
+}
+
+// shouldRetry decides whether the request can be sent again based on its method and outcome
+func (s *retrySession) shouldRetry(r *http.Request, resp *http.Response, err error) bool {
+	if r.Context().Err() != nil {
+		return false
+	}
+	if err == nil && resp.StatusCode == http.StatusTooManyRequests {
+		return true
+	}
+
+	switch r.Method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions:
+	default:
+		return false
+	}
+
+	if err != nil {
+		// marshaling and unmarshaling errors will not be fixed by sending the request again
+		return !errorsIsAny(err, session.ErrMarshaling, session.ErrUnmarshaling, session.ErrInvalidArgument)
+	}
+
+	switch resp.StatusCode {
+	case http.StatusInternalServerError, http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		return true
+	}
+	return false
+}
+
+// backoff returns the time to wait before the next attempt. Exponential backoff is used unless
+// the API told us when to come back using either Retry-After or X-RateLimit-Next headers.
+func (s *retrySession) backoff(attempt int, resp *http.Response) time.Duration {
+	if resp != nil {
+		if wait, ok := retryAfter(resp.Header, time.Now()); ok {
+			return wait
+		}
+	}
+
+	wait := s.conf.minBackoff
+	for i := 1; i < attempt && wait < s.conf.maxBackoff; i++ {
+		wait *= 2
+	}
+	if wait > s.conf.maxBackoff {
+		wait = s.conf.maxBackoff
+	}
+	return wait
+}
+
+// retryAfter parses the Retry-After and X-RateLimit-Next headers
+func retryAfter(h http.Header, now time.Time) (time.Duration, bool) {
+	if v := h.Get("Retry-After"); v != "" {
+		if seconds, err := strconv.Atoi(v); err == nil && seconds >= 0 {
+			return time.Duration(seconds) * time.Second, true
+		}
+		if t, err := http.ParseTime(v); err == nil {
+			return nonNegative(t.Sub(now)), true
+		}
+	}
+	if v := h.Get(rateLimitNextHeader); v != "" {
+		if t, err := time.Parse(time.RFC3339Nano, v); err == nil {
+			return nonNegative(t.Sub(now)), true
+		}
+	}
+	return 0, false
+}
+
+func nonNegative(d time.Duration) time.Duration {
+	if d < 0 {
+		return 0
+	}
+	return d
+}
+
+func errorsIsAny(err error, targets ...error) bool {
+	for _, target := range targets {
+		if errors.Is(err, target) {
+			return true
+		}
+	}
+	return false
+}
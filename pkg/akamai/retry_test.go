@@ -0,0 +1,204 @@
+package akamai
+
+import (
+	"context"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v2/pkg/edgegrid"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/stretchr/testify/require"
+	"github.com/tj/assert"
+)
+
+func TestRetrySession_Exec(t *testing.T) {
+	tests := map[string]struct {
+		method           string
+		responses        []int
+		headers          http.Header
+		expectedStatus   int
+		expectedAttempts int
+	}{
+		"GET is retried on server errors": {
+			method:           http.MethodGet,
+			responses:        []int{http.StatusServiceUnavailable, http.StatusInternalServerError, http.StatusOK},
+			expectedStatus:   http.StatusOK,
+			expectedAttempts: 3,
+		},
+		"GET is retried until max attempts": {
+			method:           http.MethodGet,
+			responses:        []int{http.StatusBadGateway, http.StatusBadGateway, http.StatusBadGateway, http.StatusOK},
+			expectedStatus:   http.StatusBadGateway,
+			expectedAttempts: 3,
+		},
+		"GET is not retried on client errors": {
+			method:           http.MethodGet,
+			responses:        []int{http.StatusNotFound, http.StatusOK},
+			expectedStatus:   http.StatusNotFound,
+			expectedAttempts: 1,
+		},
+		"POST is retried when throttled": {
+			method:           http.MethodPost,
+			responses:        []int{http.StatusTooManyRequests, http.StatusCreated},
+			headers:          http.Header{"Retry-After": []string{"0"}},
+			expectedStatus:   http.StatusCreated,
+			expectedAttempts: 2,
+		},
+		"POST is not retried on server errors": {
+			method:           http.MethodPost,
+			responses:        []int{http.StatusInternalServerError, http.StatusCreated},
+			expectedStatus:   http.StatusInternalServerError,
+			expectedAttempts: 1,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			var attempts int
+			var bodies []string
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				body, err := ioutil.ReadAll(r.Body)
+				require.NoError(t, err)
+				bodies = append(bodies, string(body))
+				assert.Len(t, r.URL.Query()["accountSwitchKey"], 1)
+
+				for k, v := range test.headers {
+					w.Header()[k] = v
+				}
+				w.WriteHeader(test.responses[attempts])
+				attempts++
+			}))
+			defer srv.Close()
+
+			builder := &sessionBuilder{
+				retry: &retryConfig{maxAttempts: 3, minBackoff: time.Millisecond, maxBackoff: 5 * time.Millisecond},
+			}
+			sess, err := builder.build(&edgegrid.Config{AccountKey: "test-account", MaxBody: edgegrid.MaxBodySize})
+			require.NoError(t, err)
+
+			req, err := http.NewRequestWithContext(context.Background(), test.method, srv.URL+"/test", nil)
+			require.NoError(t, err)
+
+			var in interface{}
+			if test.method == http.MethodPost {
+				in = map[string]string{"name": "test"}
+			}
+			var resp *http.Response
+			if in != nil {
+				resp, err = sess.Exec(req, nil, in)
+			} else {
+				resp, err = sess.Exec(req, nil)
+			}
+			require.NoError(t, err)
+			assert.Equal(t, test.expectedStatus, resp.StatusCode)
+			assert.Equal(t, test.expectedAttempts, attempts)
+			for _, body := range bodies {
+				assert.Equal(t, bodies[0], body)
+			}
+		})
+	}
+}
+
+func TestRetrySession_Backoff(t *testing.T) {
+	s := &retrySession{conf: retryConfig{maxAttempts: 10, minBackoff: time.Second, maxBackoff: 5 * time.Second}}
+
+	assert.Equal(t, time.Second, s.backoff(1, nil))
+	assert.Equal(t, 2*time.Second, s.backoff(2, nil))
+	assert.Equal(t, 4*time.Second, s.backoff(3, nil))
+	assert.Equal(t, 5*time.Second, s.backoff(4, nil))
+	assert.Equal(t, 5*time.Second, s.backoff(9, nil))
+
+	resp := &http.Response{Header: http.Header{"Retry-After": []string{"42"}}}
+	assert.Equal(t, 42*time.Second, s.backoff(1, resp))
+}
+
+func TestRetryAfter(t *testing.T) {
+	now := time.Date(2021, 1, 1, 10, 0, 0, 0, time.UTC)
+	tests := map[string]struct {
+		headers      http.Header
+		expectedWait time.Duration
+		expectedOK   bool
+	}{
+		"no headers": {
+			headers: http.Header{},
+		},
+		"retry after in seconds": {
+			headers:      http.Header{"Retry-After": []string{"7"}},
+			expectedWait: 7 * time.Second,
+			expectedOK:   true,
+		},
+		"retry after as date": {
+			headers:      http.Header{"Retry-After": []string{"Fri, 01 Jan 2021 10:00:10 GMT"}},
+			expectedWait: 10 * time.Second,
+			expectedOK:   true,
+		},
+		"rate limit next": {
+			headers:      http.Header{"X-Ratelimit-Next": []string{"2021-01-01T10:00:03.5Z"}},
+			expectedWait: 3500 * time.Millisecond,
+			expectedOK:   true,
+		},
+		"rate limit next in the past": {
+			headers:    http.Header{"X-Ratelimit-Next": []string{"2021-01-01T09:00:00Z"}},
+			expectedOK: true,
+		},
+		"invalid retry after": {
+			headers: http.Header{"Retry-After": []string{"soon"}},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			wait, ok := retryAfter(test.headers, now)
+			assert.Equal(t, test.expectedOK, ok)
+			assert.Equal(t, test.expectedWait, wait)
+		})
+	}
+}
+
+func TestGetRetryConfig(t *testing.T) {
+	resourceSchema := map[string]*schema.Schema{
+		"retry": {
+			Type:     schema.TypeSet,
+			Optional: true,
+			Elem:     retryOptions(),
+		},
+	}
+
+	tests := map[string]struct {
+		givenData      map[string]interface{}
+		expectedConfig *retryConfig
+		withError      bool
+	}{
+		"retry not configured": {
+			givenData: map[string]interface{}{},
+		},
+		"retry configured": {
+			givenData: map[string]interface{}{
+				"retry": []interface{}{map[string]interface{}{"max_attempts": 3, "min_backoff": 2, "max_backoff": 10}},
+			},
+			expectedConfig: &retryConfig{maxAttempts: 3, minBackoff: 2 * time.Second, maxBackoff: 10 * time.Second},
+		},
+		"min backoff greater than max backoff": {
+			givenData: map[string]interface{}{
+				"retry": []interface{}{map[string]interface{}{"max_attempts": 3, "min_backoff": 20, "max_backoff": 10}},
+			},
+			withError: true,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			d := schema.TestResourceDataRaw(t, resourceSchema, test.givenData)
+			conf, err := getRetryConfig(d)
+			if test.withError {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, test.expectedConfig, conf)
+		})
+	}
+}
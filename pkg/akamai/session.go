@@ -0,0 +1,44 @@
+package akamai
+
+import (
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v2/pkg/edgegrid"
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v2/pkg/session"
+)
+
+type (
+	// sessionBuilder creates the API sessions shared by all subproviders
+	sessionBuilder struct {
+		opts     []session.Option
+		retry    *retryConfig
+		limiter  *requestLimiter
+		readOnly bool
+		tracer   *tracer
+	}
+)
+
+// build returns a new session signing requests with the given signer
+func (b *sessionBuilder) build(signer edgegrid.Signer) (session.Session, error) {
+	sess, err := session.New(append(b.opts, session.WithSigner(signer))...)
+	if err != nil {
+		return nil, err
+	}
+	sess = withRequestLogging(sess)
+
+	// the limiter is applied to each attempt, so that no slot is held while waiting for a retry
+	if b.limiter != nil {
+		sess = withLimiter(sess, b.limiter)
+	}
+	if b.retry != nil {
+		sess = withRetries(sess, *b.retry)
+	}
+	// blocked requests fail immediately, without being retried or waiting for a slot
+	if b.readOnly {
+		sess = withReadOnly(sess)
+	}
+	// the request span covers all attempts, including requests rejected in read-only mode
+	if b.tracer != nil {
+		sess = withTracing(sess, b.tracer)
+	}
+
+	return sess, nil
+}
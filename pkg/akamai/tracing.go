@@ -0,0 +1,510 @@
+package akamai
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v2/pkg/session"
+	"github.com/apex/log"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+
+	"github.com/akamai/terraform-provider-akamai/v2/pkg/tools"
+	"github.com/akamai/terraform-provider-akamai/v2/version"
+)
+
+type (
+	// tracingConfig holds the settings of the provider tracing block
+	tracingConfig struct {
+		endpoint string
+		headers  map[string]string
+	}
+
+	// tracer collects the spans of a provider operation and exports them over OTLP/HTTP.
+	// All spans of the operation share one trace, so that a whole plan or apply can be inspected at once.
+	tracer struct {
+		conf        tracingConfig
+		client      *http.Client
+		log         log.Interface
+		traceID     [16]byte
+		operationID string
+
+		mu        sync.Mutex
+		pending   []*span
+		exporting bool
+		dropped   int
+	}
+
+	// span is a timed operation of the provider, either a resource operation or an API request
+	span struct {
+		tracer   *tracer
+		id       [8]byte
+		parentID [8]byte
+		isRoot   bool
+		name     string
+		kind     int
+		start    time.Time
+
+		mu         sync.Mutex
+		end        time.Time
+		attributes map[string]interface{}
+		failed     bool
+		message    string
+	}
+
+	// tracingSession creates a span for every API request
+	tracingSession struct {
+		session.Session
+		tracer *tracer
+	}
+
+	spanContextKey        struct{}
+	requestSpanContextKey struct{}
+)
+
+const (
+	// otlpTracesPath is appended to the endpoint, as with OTEL_EXPORTER_OTLP_ENDPOINT in the OpenTelemetry SDKs
+	otlpTracesPath = "/v1/traces"
+
+	otlpExportTimeout = 10 * time.Second
+
+	// otlpMaxBatchSize is the maximum number of spans sent in one export request
+	otlpMaxBatchSize = 512
+	// otlpMaxQueueSize is the maximum number of finished spans waiting to be exported, the oldest ones
+	// are dropped when the collector can't keep up
+	otlpMaxQueueSize = 2048
+
+	spanKindInternal = 1
+	spanKindClient   = 3
+
+	spanStatusOK    = 1
+	spanStatusError = 2
+)
+
+// tracingOptions returns the schema of the provider tracing block
+func tracingOptions() *schema.Resource {
+	return &schema.Resource{
+		Schema: map[string]*schema.Schema{
+			"endpoint": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "The base URL of the OTLP/HTTP collector receiving the traces, for example http://localhost:4318. The OTEL_EXPORTER_OTLP_ENDPOINT env variable is used if not set",
+			},
+			"headers": {
+				Type:        schema.TypeMap,
+				Optional:    true,
+				Sensitive:   true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description: "The headers sent with every export request, the OTEL_EXPORTER_OTLP_HEADERS env variable is used if not set",
+			},
+		},
+	}
+}
+
+// getTracingConfig reads the tracing block from the provider configuration, falling back to the OpenTelemetry
+// env variables. nil is returned when no endpoint is configured.
+func getTracingConfig(d *schema.ResourceData) (*tracingConfig, error) {
+	conf := &tracingConfig{
+		endpoint: os.Getenv("OTEL_EXPORTER_OTLP_ENDPOINT"),
+	}
+	headers, err := parseOTLPHeaders(os.Getenv("OTEL_EXPORTER_OTLP_HEADERS"))
+	if err != nil {
+		return nil, err
+	}
+	conf.headers = headers
+
+	block, err := getSingleBlock(d, "tracing")
+	if err != nil {
+		return nil, err
+	}
+	if block != nil {
+		endpoint, ok := block["endpoint"].(string)
+		if !ok && block["endpoint"] != nil {
+			return nil, fmt.Errorf("%w: %s, %q", tools.ErrInvalidType, "endpoint", "string")
+		}
+		if endpoint != "" {
+			conf.endpoint = endpoint
+		}
+		if blockHeaders, ok := block["headers"].(map[string]interface{}); ok && len(blockHeaders) > 0 {
+			conf.headers = make(map[string]string, len(blockHeaders))
+			for name, val := range blockHeaders {
+				conf.headers[name] = fmt.Sprint(val)
+			}
+		}
+	}
+
+	if conf.endpoint == "" {
+		return nil, nil
+	}
+	if !strings.HasPrefix(conf.endpoint, "http://") && !strings.HasPrefix(conf.endpoint, "https://") {
+		return nil, fmt.Errorf("tracing: endpoint %q must be an http or https URL", conf.endpoint)
+	}
+	return conf, nil
+}
+
+// parseOTLPHeaders parses the headers in the key1=value1,key2=value2 format of OTEL_EXPORTER_OTLP_HEADERS
+func parseOTLPHeaders(val string) (map[string]string, error) {
+	if strings.TrimSpace(val) == "" {
+		return nil, nil
+	}
+	headers := make(map[string]string)
+	for _, pair := range strings.Split(val, ",") {
+		parts := strings.SplitN(pair, "=", 2)
+		if len(parts) != 2 || strings.TrimSpace(parts[0]) == "" {
+			return nil, fmt.Errorf("tracing: invalid header %q in OTEL_EXPORTER_OTLP_HEADERS", pair)
+		}
+		headers[strings.TrimSpace(parts[0])] = strings.TrimSpace(parts[1])
+	}
+	return headers, nil
+}
+
+// newTracer returns a tracer exporting to the configured endpoint, or nil if tracing is not configured
+func newTracer(conf *tracingConfig, operationID string, logger log.Interface) *tracer {
+	if conf == nil {
+		return nil
+	}
+	t := &tracer{
+		conf:        *conf,
+		client:      &http.Client{Timeout: otlpExportTimeout},
+		log:         logger,
+		operationID: operationID,
+	}
+	_, _ = rand.Read(t.traceID[:])
+	return t
+}
+
+// start begins a span which is a child of the span in the context, if any
+func (t *tracer) start(ctx context.Context, name string, kind int) (context.Context, *span) {
+	s := &span{
+		tracer:     t,
+		name:       name,
+		kind:       kind,
+		start:      time.Now(),
+		attributes: make(map[string]interface{}),
+	}
+	_, _ = rand.Read(s.id[:])
+	if parent, ok := ctx.Value(spanContextKey{}).(*span); ok && parent.tracer == t {
+		s.parentID = parent.id
+	} else {
+		s.isRoot = true
+	}
+	return context.WithValue(ctx, spanContextKey{}, s), s
+}
+
+// setAttribute sets an attribute of the span, the value can be a string, an int or a bool
+func (s *span) setAttribute(key string, val interface{}) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.attributes[key] = val
+}
+
+// setError marks the span as failed
+func (s *span) setError(message string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.failed = true
+	s.message = message
+}
+
+// finish ends the span. Spans are exported in the background, in batches, once a root span ends or
+// a full batch is pending, so that provider operations never wait for the collector.
+func (s *span) finish() {
+	s.mu.Lock()
+	s.end = time.Now()
+	s.mu.Unlock()
+
+	t := s.tracer
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.pending = append(t.pending, s)
+	if len(t.pending) > otlpMaxQueueSize {
+		t.dropped += len(t.pending) - otlpMaxQueueSize
+		t.pending = t.pending[len(t.pending)-otlpMaxQueueSize:]
+	}
+	if (s.isRoot || len(t.pending) >= otlpMaxBatchSize) && !t.exporting {
+		t.exporting = true
+		go t.exportPending()
+	}
+}
+
+// exportPending exports the finished spans batch by batch until none is left
+func (t *tracer) exportPending() {
+	for {
+		t.mu.Lock()
+		if len(t.pending) == 0 {
+			t.exporting = false
+			t.mu.Unlock()
+			return
+		}
+		n := len(t.pending)
+		if n > otlpMaxBatchSize {
+			n = otlpMaxBatchSize
+		}
+		spans := t.pending[:n:n]
+		t.pending = t.pending[n:]
+		dropped := t.dropped
+		t.dropped = 0
+		t.mu.Unlock()
+
+		if dropped > 0 {
+			t.log.Warnf("dropped %d spans, the collector can't keep up", dropped)
+		}
+		t.flush(spans)
+	}
+}
+
+// flush exports the spans, failures are logged and do not affect the provider operations
+func (t *tracer) flush(spans []*span) {
+	body, err := json.Marshal(t.export(spans))
+	if err != nil {
+		t.log.WithError(err).Warn("failed to encode traces")
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), otlpExportTimeout)
+	defer cancel()
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, strings.TrimSuffix(t.conf.endpoint, "/")+otlpTracesPath, bytes.NewReader(body))
+	if err != nil {
+		t.log.WithError(err).Warn("failed to export traces")
+		return
+	}
+	req.Header.Set("Content-Type", "application/json")
+	for name, val := range t.conf.headers {
+		req.Header.Set(name, val)
+	}
+
+	resp, err := t.client.Do(req)
+	if err != nil {
+		t.log.WithError(err).Warnf("failed to export %d spans", len(spans))
+		return
+	}
+	defer func() {
+		_, _ = io.Copy(ioutil.Discard, resp.Body)
+		_ = resp.Body.Close()
+	}()
+	if resp.StatusCode >= http.StatusBadRequest {
+		t.log.Warnf("failed to export %d spans: collector returned %d", len(spans), resp.StatusCode)
+	}
+}
+
+// withTracing wraps the session so that a span is created for every API request
+func withTracing(sess session.Session, t *tracer) session.Session {
+	return &tracingSession{
+		Session: sess,
+		tracer:  t,
+	}
+}
+
+// Exec executes the request within a span tagged with the request, its outcome and the number of retries
+func (s *tracingSession) Exec(r *http.Request, out interface{}, in ...interface{}) (*http.Response, error) {
+	ctx, span := s.tracer.start(r.Context(), "HTTP "+r.Method, spanKindClient)
+	defer span.finish()
+
+	span.setAttribute("http.method", r.Method)
+	span.setAttribute("http.target", sanitizeURL(r.URL))
+	span.setAttribute("http.retry_count", 0)
+	if name, ok := ctx.Value(subproviderContextKey{}).(string); ok {
+		span.setAttribute("akamai.subprovider", name)
+	}
+	if name, ok := ctx.Value(resourceContextKey{}).(string); ok {
+		span.setAttribute("akamai.resource", name)
+	}
+
+	resp, err := s.Session.Exec(r.WithContext(context.WithValue(ctx, requestSpanContextKey{}, span)), out, in...)
+	if resp != nil {
+		span.setAttribute("http.status_code", resp.StatusCode)
+	}
+	switch {
+	case err != nil:
+		span.setError(redactMessage(err.Error()))
+	case resp.StatusCode >= http.StatusBadRequest:
+		span.setError(http.StatusText(resp.StatusCode))
+	}
+
+	return resp, err
+}
+
+// recordRetries tags the span of the API request in the context with the number of retries made for it
+func recordRetries(ctx context.Context, retries int) {
+	if span, ok := ctx.Value(requestSpanContextKey{}).(*span); ok {
+		span.setAttribute("http.retry_count", retries)
+	}
+}
+
+// withOperationTracing creates a span for each operation of the resource or data source
+func withOperationTracing(r *schema.Resource, name, subprovider string) {
+	for operation, f := range map[string]*crudFunc{
+		"create": (*crudFunc)(&r.CreateContext),
+		"read":   (*crudFunc)(&r.ReadContext),
+		"update": (*crudFunc)(&r.UpdateContext),
+		"delete": (*crudFunc)(&r.DeleteContext),
+	} {
+		if *f == nil {
+			continue
+		}
+		next, operation := *f, operation
+		*f = func(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+			providerMeta, ok := m.(*meta)
+			if !ok || providerMeta.tracer == nil {
+				return next(ctx, d, m)
+			}
+
+			ctx, span := providerMeta.tracer.start(ctx, fmt.Sprintf("%s.%s", name, operation), spanKindInternal)
+			defer span.finish()
+			span.setAttribute("akamai.resource", name)
+			span.setAttribute("akamai.subprovider", subprovider)
+			span.setAttribute("akamai.operation", operation)
+
+			diags := next(ctx, d, m)
+			if d.Id() != "" {
+				span.setAttribute("terraform.id", d.Id())
+			}
+			for _, diagnostic := range diags {
+				if diagnostic.Severity == diag.Error {
+					span.setError(redactMessage(diagnostic.Summary))
+					break
+				}
+			}
+			return diags
+		}
+	}
+}
+
+type (
+	otlpExport struct {
+		ResourceSpans []otlpResourceSpans `json:"resourceSpans"`
+	}
+
+	otlpResourceSpans struct {
+		Resource   otlpResource     `json:"resource"`
+		ScopeSpans []otlpScopeSpans `json:"scopeSpans"`
+	}
+
+	otlpResource struct {
+		Attributes []otlpKeyValue `json:"attributes"`
+	}
+
+	otlpScopeSpans struct {
+		Scope otlpScope  `json:"scope"`
+		Spans []otlpSpan `json:"spans"`
+	}
+
+	otlpScope struct {
+		Name    string `json:"name"`
+		Version string `json:"version"`
+	}
+
+	otlpSpan struct {
+		TraceID           string         `json:"traceId"`
+		SpanID            string         `json:"spanId"`
+		ParentSpanID      string         `json:"parentSpanId,omitempty"`
+		Name              string         `json:"name"`
+		Kind              int            `json:"kind"`
+		StartTimeUnixNano string         `json:"startTimeUnixNano"`
+		EndTimeUnixNano   string         `json:"endTimeUnixNano"`
+		Attributes        []otlpKeyValue `json:"attributes,omitempty"`
+		Status            otlpStatus     `json:"status"`
+	}
+
+	otlpKeyValue struct {
+		Key   string       `json:"key"`
+		Value otlpAnyValue `json:"value"`
+	}
+
+	otlpAnyValue struct {
+		StringValue *string `json:"stringValue,omitempty"`
+		IntValue    *string `json:"intValue,omitempty"`
+		BoolValue   *bool   `json:"boolValue,omitempty"`
+	}
+
+	otlpStatus struct {
+		Code    int    `json:"code,omitempty"`
+		Message string `json:"message,omitempty"`
+	}
+)
+
+// export returns the OTLP JSON encoding of the spans
+func (t *tracer) export(spans []*span) otlpExport {
+	otlpSpans := make([]otlpSpan, 0, len(spans))
+	for _, s := range spans {
+		otlpSpans = append(otlpSpans, s.otlp())
+	}
+	return otlpExport{
+		ResourceSpans: []otlpResourceSpans{{
+			Resource: otlpResource{
+				Attributes: otlpAttributes(map[string]interface{}{
+					"service.name":        ProviderName,
+					"service.version":     version.ProviderVersion,
+					"akamai.operation_id": t.operationID,
+				}),
+			},
+			ScopeSpans: []otlpScopeSpans{{
+				Scope: otlpScope{Name: ProviderRegistryPath, Version: version.ProviderVersion},
+				Spans: otlpSpans,
+			}},
+		}},
+	}
+}
+
+func (s *span) otlp() otlpSpan {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	encoded := otlpSpan{
+		TraceID:           hex.EncodeToString(s.tracer.traceID[:]),
+		SpanID:            hex.EncodeToString(s.id[:]),
+		Name:              s.name,
+		Kind:              s.kind,
+		StartTimeUnixNano: strconv.FormatInt(s.start.UnixNano(), 10),
+		EndTimeUnixNano:   strconv.FormatInt(s.end.UnixNano(), 10),
+		Attributes:        otlpAttributes(s.attributes),
+		Status:            otlpStatus{Code: spanStatusOK},
+	}
+	if !s.isRoot {
+		encoded.ParentSpanID = hex.EncodeToString(s.parentID[:])
+	}
+	if s.failed {
+		encoded.Status = otlpStatus{Code: spanStatusError, Message: s.message}
+	}
+	return encoded
+}
+
+// otlpAttributes encodes the attributes sorted by key, values of other types than string, int and bool are formatted as strings
+func otlpAttributes(attributes map[string]interface{}) []otlpKeyValue {
+	keys := make([]string, 0, len(attributes))
+	for key := range attributes {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	encoded := make([]otlpKeyValue, 0, len(attributes))
+	for _, key := range keys {
+		var val otlpAnyValue
+		switch v := attributes[key].(type) {
+		case int:
+			i := strconv.Itoa(v)
+			val.IntValue = &i
+		case bool:
+			b := v
+			val.BoolValue = &b
+		default:
+			str := fmt.Sprint(v)
+			val.StringValue = &str
+		}
+		encoded = append(encoded, otlpKeyValue{Key: key, Value: val})
+	}
+	return encoded
+}
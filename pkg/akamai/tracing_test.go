@@ -0,0 +1,231 @@
+package akamai
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v2/pkg/edgegrid"
+	"github.com/apex/log"
+	"github.com/apex/log/handlers/discard"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/stretchr/testify/require"
+	"github.com/tj/assert"
+)
+
+func TestGetTracingConfig(t *testing.T) {
+	resourceSchema := map[string]*schema.Schema{
+		"tracing": {
+			Type:     schema.TypeSet,
+			Optional: true,
+			Elem:     tracingOptions(),
+		},
+	}
+
+	tests := map[string]struct {
+		givenData map[string]interface{}
+		env       map[string]string
+		expected  *tracingConfig
+		withError bool
+	}{
+		"tracing not configured": {
+			givenData: map[string]interface{}{},
+		},
+		"endpoint from env": {
+			givenData: map[string]interface{}{},
+			env: map[string]string{
+				"OTEL_EXPORTER_OTLP_ENDPOINT": "http://localhost:4318",
+				"OTEL_EXPORTER_OTLP_HEADERS":  "x-api-key=abc, x-tenant=test",
+			},
+			expected: &tracingConfig{
+				endpoint: "http://localhost:4318",
+				headers:  map[string]string{"x-api-key": "abc", "x-tenant": "test"},
+			},
+		},
+		"block overrides env": {
+			givenData: map[string]interface{}{
+				"tracing": []interface{}{map[string]interface{}{
+					"endpoint": "https://collector:4318",
+					"headers":  map[string]interface{}{"x-api-key": "def"},
+				}},
+			},
+			env: map[string]string{
+				"OTEL_EXPORTER_OTLP_ENDPOINT": "http://localhost:4318",
+				"OTEL_EXPORTER_OTLP_HEADERS":  "x-api-key=abc",
+			},
+			expected: &tracingConfig{
+				endpoint: "https://collector:4318",
+				headers:  map[string]string{"x-api-key": "def"},
+			},
+		},
+		"invalid endpoint": {
+			givenData: map[string]interface{}{
+				"tracing": []interface{}{map[string]interface{}{
+					"endpoint": "localhost:4318",
+				}},
+			},
+			withError: true,
+		},
+		"invalid headers": {
+			givenData: map[string]interface{}{},
+			env: map[string]string{
+				"OTEL_EXPORTER_OTLP_ENDPOINT": "http://localhost:4318",
+				"OTEL_EXPORTER_OTLP_HEADERS":  "x-api-key",
+			},
+			withError: true,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			for _, key := range []string{"OTEL_EXPORTER_OTLP_ENDPOINT", "OTEL_EXPORTER_OTLP_HEADERS"} {
+				require.NoError(t, os.Setenv(key, test.env[key]))
+				defer func(key string) {
+					require.NoError(t, os.Unsetenv(key))
+				}(key)
+			}
+
+			d := schema.TestResourceDataRaw(t, resourceSchema, test.givenData)
+			conf, err := getTracingConfig(d)
+			if test.withError {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, test.expected, conf)
+		})
+	}
+}
+
+func TestOperationTracing(t *testing.T) {
+	exports := make(chan otlpExport, 1)
+	collector := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, otlpTracesPath, r.URL.Path)
+		assert.Equal(t, "abc", r.Header.Get("x-api-key"))
+		var export otlpExport
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&export))
+		exports <- export
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer collector.Close()
+
+	var attempts int
+	api := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		attempts++
+		if attempts == 1 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer api.Close()
+
+	tr := newTracer(&tracingConfig{endpoint: collector.URL, headers: map[string]string{"x-api-key": "abc"}}, "test-operation", &log.Logger{Handler: discard.New()})
+	builder := &sessionBuilder{
+		retry:  &retryConfig{maxAttempts: 3, minBackoff: time.Millisecond, maxBackoff: 5 * time.Millisecond},
+		tracer: tr,
+	}
+	sess, err := builder.build(&edgegrid.Config{MaxBody: edgegrid.MaxBodySize})
+	require.NoError(t, err)
+
+	r := &schema.Resource{
+		Schema: map[string]*schema.Schema{},
+		ReadContext: func(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+			req, err := http.NewRequestWithContext(ctx, http.MethodGet, api.URL+"/papi/v1/groups", nil)
+			require.NoError(t, err)
+			_, err = Meta(m).SubproviderSession(testSubprovider{}).Exec(req, nil)
+			require.NoError(t, err)
+			d.SetId("grp_1")
+			return nil
+		},
+	}
+	withOperationTracing(r, "akamai_test", "TEST")
+
+	d := schema.TestResourceDataRaw(t, r.Schema, map[string]interface{}{})
+	diags := r.ReadContext(context.Background(), d, &meta{sess: sess, tracer: tr})
+	require.False(t, diags.HasError())
+
+	var export otlpExport
+	select {
+	case export = <-exports:
+	case <-time.After(5 * time.Second):
+		t.Fatal("spans were not exported")
+	}
+	require.Len(t, export.ResourceSpans, 1)
+	require.Len(t, export.ResourceSpans[0].ScopeSpans, 1)
+	spans := export.ResourceSpans[0].ScopeSpans[0].Spans
+	require.Len(t, spans, 2)
+
+	request, operation := spans[0], spans[1]
+	assert.Equal(t, "akamai_test.read", operation.Name)
+	assert.Equal(t, spanKindInternal, operation.Kind)
+	assert.Empty(t, operation.ParentSpanID)
+	assert.Equal(t, "HTTP GET", request.Name)
+	assert.Equal(t, spanKindClient, request.Kind)
+	assert.Equal(t, operation.SpanID, request.ParentSpanID)
+	assert.Equal(t, operation.TraceID, request.TraceID)
+	assert.Equal(t, spanStatusOK, request.Status.Code)
+
+	attributes := make(map[string]otlpAnyValue)
+	for _, attribute := range request.Attributes {
+		attributes[attribute.Key] = attribute.Value
+	}
+	assert.Equal(t, "1", *attributes["http.retry_count"].IntValue)
+	assert.Equal(t, "200", *attributes["http.status_code"].IntValue)
+	assert.Equal(t, "/papi/v1/groups", *attributes["http.target"].StringValue)
+	assert.Equal(t, "TEST", *attributes["akamai.subprovider"].StringValue)
+}
+
+func TestTracingExportInBackground(t *testing.T) {
+	release := make(chan struct{})
+	var mu sync.Mutex
+	var exported int
+	collector := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		<-release
+		var export otlpExport
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&export))
+		mu.Lock()
+		exported += len(export.ResourceSpans[0].ScopeSpans[0].Spans)
+		mu.Unlock()
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer collector.Close()
+
+	tr := newTracer(&tracingConfig{endpoint: collector.URL}, "test-operation", &log.Logger{Handler: discard.New()})
+
+	// the collector doesn't answer until released, finishing the spans must not wait for it
+	done := make(chan struct{})
+	go func() {
+		for i := 0; i < otlpMaxBatchSize+1; i++ {
+			_, s := tr.start(context.Background(), "akamai_test.read", spanKindInternal)
+			s.finish()
+		}
+		close(done)
+	}()
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		t.Fatal("finishing spans waited for the collector")
+	}
+	close(release)
+
+	assert.Eventually(t, func() bool {
+		mu.Lock()
+		defer mu.Unlock()
+		return exported == otlpMaxBatchSize+1
+	}, 5*time.Second, 10*time.Millisecond)
+}
+
+type testSubprovider struct {
+	Subprovider
+}
+
+func (testSubprovider) Name() string {
+	return "TEST"
+}
@@ -32,7 +32,7 @@ func getModifiableConfigVersion(ctx context.Context, configID int, resource stri
 	// If the version info is in the cache, return it immediately.
 	cacheKey := fmt.Sprintf("%s:%d", "getModifiableConfigVersion", configID)
 	configuration := &appsec.GetConfigurationResponse{}
-	if err := meta.CacheGet(inst, cacheKey, configuration); err == nil {
+	if err := meta.RunCacheGet(inst, cacheKey, configuration); err == nil {
 		logger.Debugf("Resource %s returning modifiable version %d from cache", resource, configuration.LatestVersion)
 		return configuration.LatestVersion, nil
 	}
@@ -45,7 +45,7 @@ func getModifiableConfigVersion(ctx context.Context, configID int, resource stri
 	}()
 
 	// If the version info is in the cache, return it immediately.
-	err := meta.CacheGet(inst, cacheKey, configuration)
+	err := meta.RunCacheGet(inst, cacheKey, configuration)
 	if err == nil {
 		logger.Debugf("Resource %s returning modifiable version %d from cache", resource, configuration.LatestVersion)
 		return configuration.LatestVersion, nil
@@ -69,7 +69,7 @@ func getModifiableConfigVersion(ctx context.Context, configID int, resource stri
 	stagingVersion := configuration.StagingVersion
 	productionVersion := configuration.ProductionVersion
 	if latestVersion != stagingVersion && latestVersion != productionVersion {
-		if err := meta.CacheSet(inst, cacheKey, configuration); err != nil {
+		if err := meta.RunCacheSet(inst, cacheKey, configuration); err != nil {
 			if !errors.Is(err, akamai.ErrCacheDisabled) {
 				logger.Errorf("unable to set latestVersion %d into cache")
 			}
@@ -91,7 +91,7 @@ func getModifiableConfigVersion(ctx context.Context, configID int, resource stri
 	}
 
 	configuration.LatestVersion = ccr.Version
-	if err := meta.CacheSet(inst, cacheKey, configuration); err != nil && !errors.Is(err, akamai.ErrCacheDisabled) {
+	if err := meta.RunCacheSet(inst, cacheKey, configuration); err != nil && !errors.Is(err, akamai.ErrCacheDisabled) {
 		logger.Errorf("unable to set latestVersion %d into cache: %s", err.Error())
 	}
 
@@ -110,7 +110,7 @@ func getLatestConfigVersion(ctx context.Context, configID int, m interface{}) (i
 	// Return the cached value if we have one
 	cacheKey := fmt.Sprintf("%s:%d", "getLatestConfigVersion", configID)
 	configuration := &appsec.GetConfigurationResponse{}
-	if err := meta.CacheGet(inst, cacheKey, configuration); err == nil {
+	if err := meta.RunCacheGet(inst, cacheKey, configuration); err == nil {
 		logger.Debugf("Found config %w, returning %d as its latest version", configuration.ID, configuration.LatestVersion)
 		return configuration.LatestVersion, nil
 	}
@@ -122,7 +122,7 @@ func getLatestConfigVersion(ctx context.Context, configID int, m interface{}) (i
 		latestVersionMutex.Unlock()
 	}()
 
-	err := meta.CacheGet(inst, cacheKey, configuration)
+	err := meta.RunCacheGet(inst, cacheKey, configuration)
 	if err == nil {
 		logger.Debugf("Found config %w, returning %d as its latest version", configuration.ID, configuration.LatestVersion)
 		return configuration.LatestVersion, nil
@@ -138,7 +138,7 @@ func getLatestConfigVersion(ctx context.Context, configID int, m interface{}) (i
 		logger.Errorf("error calling GetConfiguration: %s", err.Error())
 		return 0, err
 	}
-	if err := meta.CacheSet(inst, cacheKey, configuration); err != nil && !errors.Is(err, akamai.ErrCacheDisabled) {
+	if err := meta.RunCacheSet(inst, cacheKey, configuration); err != nil && !errors.Is(err, akamai.ErrCacheDisabled) {
 		logger.Errorf("error caching latestVersion into cache: %s", err.Error())
 	}
 
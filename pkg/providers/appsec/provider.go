@@ -166,7 +166,7 @@ func (p *provider) Client(meta akamai.OperationMeta) appsec.APPSEC {
 	if p.client != nil {
 		return p.client
 	}
-	return appsec.Client(meta.Session())
+	return appsec.Client(meta.SubproviderSession(p))
 }
 
 func getAPPSECV1Service(d *schema.ResourceData) (interface{}, error) {
@@ -58,18 +58,20 @@ func resourceActivations() *schema.Resource {
 				Type:     schema.TypeString,
 				Computed: true,
 			},
+			akamai.PollIntervalField: akamai.PollIntervalSchema(),
+		},
+		Timeouts: &schema.ResourceTimeout{
+			Default: &ActivationTimeout,
 		},
 	}
 }
 
-const (
-	// ActivationPollMinimum is the minumum polling interval for activation creation
-	ActivationPollMinimum = time.Minute
-)
-
 var (
-	// ActivationPollInterval is the interval for polling an activation status on creation
-	ActivationPollInterval = ActivationPollMinimum
+	// ActivationPollInterval is the first interval for polling an activation status when poll_interval is not set
+	ActivationPollInterval = akamai.DefaultActivationPollInterval
+
+	// ActivationTimeout is the default timeout for waiting on an activation
+	ActivationTimeout = 90 * time.Minute
 )
 
 func resourceActivationsCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
@@ -140,18 +142,19 @@ func resourceActivationsCreate(ctx context.Context, d *schema.ResourceData, m in
 	if err != nil {
 		return diag.FromErr(err)
 	}
+	poller, err := akamai.NewActivationPoller(d, ActivationPollInterval, logger, fmt.Sprintf("security configuration %d activation on %s", configID, network))
+	if err != nil {
+		return diag.FromErr(err)
+	}
 	for activation.Status != appsec.StatusActive {
-		select {
-		case <-time.After(tools.MaxDuration(ActivationPollInterval, ActivationPollMinimum)):
-			act, err := client.GetActivations(ctx, getActivationRequest)
-			if err != nil {
-				return diag.FromErr(err)
-			}
-			activation = act
-
-		case <-ctx.Done():
-			return diag.FromErr(fmt.Errorf("activation context terminated: %w", ctx.Err()))
+		if err := poller.Wait(ctx, string(activation.Status)); err != nil {
+			return diag.FromErr(fmt.Errorf("activation context terminated: %w", err))
+		}
+		act, err := client.GetActivations(ctx, getActivationRequest)
+		if err != nil {
+			return diag.FromErr(err)
 		}
+		activation = act
 	}
 
 	return resourceActivationsRead(ctx, d, m)
@@ -191,6 +194,10 @@ func resourceActivationsUpdate(ctx context.Context, d *schema.ResourceData, m in
 	logger := meta.Log("APPSEC", "resourceActivationsUpdate")
 	logger.Debug("in resourceActivationsUpdate")
 
+	if !d.HasChangeExcept(akamai.PollIntervalField) {
+		return resourceActivationsRead(ctx, d, m)
+	}
+
 	configID, err := tools.GetIntValue("config_id", d)
 	if err != nil {
 		return diag.FromErr(err)
@@ -253,19 +260,20 @@ func resourceActivationsUpdate(ctx context.Context, d *schema.ResourceData, m in
 	if err != nil {
 		return diag.FromErr(err)
 	}
+	poller, err := akamai.NewActivationPoller(d, ActivationPollInterval, logger, fmt.Sprintf("security configuration %d activation on %s", configID, network))
+	if err != nil {
+		return diag.FromErr(err)
+	}
 	for activation.Status != appsec.StatusActive {
-		select {
-		case <-time.After(tools.MaxDuration(ActivationPollInterval, ActivationPollMinimum)):
-			act, err := client.GetActivations(ctx, getActivationRequest)
-
-			if err != nil {
-				return diag.FromErr(err)
-			}
-			activation = act
+		if err := poller.Wait(ctx, string(activation.Status)); err != nil {
+			return diag.FromErr(fmt.Errorf("activation context terminated: %w", err))
+		}
+		act, err := client.GetActivations(ctx, getActivationRequest)
 
-		case <-ctx.Done():
-			return diag.FromErr(fmt.Errorf("activation context terminated: %w", ctx.Err()))
+		if err != nil {
+			return diag.FromErr(err)
 		}
+		activation = act
 	}
 
 	return resourceActivationsRead(ctx, d, m)
@@ -340,19 +348,20 @@ func resourceActivationsDelete(ctx context.Context, d *schema.ResourceData, m in
 	if err != nil {
 		return diag.FromErr(err)
 	}
+	poller, err := akamai.NewActivationPoller(d, ActivationPollInterval, logger, fmt.Sprintf("security configuration %d deactivation on %s", configID, network))
+	if err != nil {
+		return diag.FromErr(err)
+	}
 	for activation.Status != appsec.StatusDeactivated {
-		select {
-		case <-time.After(tools.MaxDuration(ActivationPollInterval, ActivationPollMinimum)):
-			act, err := client.GetActivations(ctx, getActivationRequest)
-
-			if err != nil {
-				return diag.FromErr(err)
-			}
-			activation = act
+		if err := poller.Wait(ctx, string(activation.Status)); err != nil {
+			return diag.FromErr(fmt.Errorf("activation context terminated: %w", err))
+		}
+		act, err := client.GetActivations(ctx, getActivationRequest)
 
-		case <-ctx.Done():
-			return diag.FromErr(fmt.Errorf("activation context terminated: %w", ctx.Err()))
+		if err != nil {
+			return diag.FromErr(err)
 		}
+		activation = act
 	}
 
 	if err := d.Set("status", activation.Status); err != nil {
@@ -168,7 +168,7 @@ func getWAFMode(ctx context.Context, m interface{}, configID int, version int, p
 
 	cacheKey := fmt.Sprintf("%s:%d:%d:%s", "getWAFMode", configID, version, policyID)
 	getWAFModeResponse := &appsec.GetWAFModeResponse{}
-	if err := meta.CacheGet(inst, cacheKey, getWAFModeResponse); err == nil {
+	if err := meta.RunCacheGet(inst, cacheKey, getWAFModeResponse); err == nil {
 		logger.Debugf("returning wafMode %s for config/version/policy %d/%d/%s",
 			getWAFModeResponse.Mode, configID, version, policyID)
 		return getWAFModeResponse.Mode, nil
@@ -181,7 +181,7 @@ func getWAFMode(ctx context.Context, m interface{}, configID int, version int, p
 		getWAFModeMutex.Unlock()
 	}()
 
-	err := meta.CacheGet(inst, cacheKey, getWAFModeResponse)
+	err := meta.RunCacheGet(inst, cacheKey, getWAFModeResponse)
 	if err == nil {
 		logger.Debugf("returning wafMode %s for config/version/policy %d/%d/%s",
 			getWAFModeResponse.Mode, configID, version, policyID)
@@ -203,7 +203,7 @@ func getWAFMode(ctx context.Context, m interface{}, configID int, version int, p
 		logger.Errorf("calling 'GetWAFMode': %s", err.Error())
 		return "", err
 	}
-	if err := meta.CacheSet(inst, cacheKey, wafMode); err != nil {
+	if err := meta.RunCacheSet(inst, cacheKey, wafMode); err != nil {
 		if !errors.Is(err, akamai.ErrCacheDisabled) {
 			logger.Errorf("error caching WAFMode: %s", err.Error())
 		}
@@ -78,7 +78,7 @@ func (p *provider) Client(meta akamai.OperationMeta) cloudlets.Cloudlets {
 	if p.client != nil {
 		return p.client
 	}
-	return cloudlets.Client(meta.Session())
+	return cloudlets.Client(meta.SubproviderSession(p))
 }
 
 func (p *provider) Name() string {
@@ -55,14 +55,13 @@ func resourceCloudletsApplicationLoadBalancerActivationSchema() map[string]*sche
 			Computed:    true,
 			Description: "Activation status for this application load balancer",
 		},
+		akamai.PollIntervalField: akamai.PollIntervalSchema(),
 	}
 }
 
 var (
-	// ALBActivationPollMinimum is the minimum polling interval for activation creation
-	ALBActivationPollMinimum = time.Second * 15
-	// ALBActivationPollInterval is the interval for polling an activation status on creation
-	ALBActivationPollInterval = ALBActivationPollMinimum
+	// ALBActivationPollInterval is the first interval for polling an activation status when poll_interval is not set
+	ALBActivationPollInterval = akamai.DefaultActivationPollInterval
 
 	// ApplicationLoadBalancerActivationResourceTimeout is the default timeout for the resource operations
 	ApplicationLoadBalancerActivationResourceTimeout = time.Minute * 20
@@ -166,7 +165,11 @@ func resourceApplicationLoadBalancerActivationChange(ctx context.Context, rd *sc
 	}
 
 	// wait until application load balancer activation is done
-	activation, err = waitForLoadBalancerActivation(ctx, client, originID, version, activationNetwork)
+	poller, err := akamai.NewActivationPoller(rd, ALBActivationPollInterval, logger, fmt.Sprintf("application load balancer %s version %d activation on %s", originID, version, activationNetwork))
+	if err != nil {
+		return nil, err
+	}
+	activation, err = waitForLoadBalancerActivation(ctx, poller, client, originID, version, activationNetwork)
 	if err != nil {
 		return nil, err
 	}
@@ -255,7 +258,7 @@ func getApplicationLoadBalancerActivation(ctx context.Context, client cloudlets.
 }
 
 // waitForLoadBalancerActivation polls server until the activation has active status or until context is closed (because of timeout, cancellation or context termination)
-func waitForLoadBalancerActivation(ctx context.Context, client cloudlets.Cloudlets, originID string, version int64, network cloudlets.LoadBalancerActivationNetwork) (*cloudlets.LoadBalancerActivation, error) {
+func waitForLoadBalancerActivation(ctx context.Context, poller *akamai.ActivationPoller, client cloudlets.Cloudlets, originID string, version int64, network cloudlets.LoadBalancerActivationNetwork) (*cloudlets.LoadBalancerActivation, error) {
 	activation, err := getApplicationLoadBalancerActivation(ctx, client, originID, version, network)
 	if err != nil {
 		return nil, err
@@ -264,21 +267,18 @@ func waitForLoadBalancerActivation(ctx context.Context, client cloudlets.Cloudle
 		if activation.Status != cloudlets.LoadBalancerActivationStatusPending {
 			return nil, fmt.Errorf("%v: originID: %s, status: %s", ErrApplicationLoadBalancerActivation, activation.OriginID, activation.Status)
 		}
-		select {
-		case <-time.After(tools.MaxDuration(ALBActivationPollInterval, ALBActivationPollMinimum)):
-			activation, err = getApplicationLoadBalancerActivation(ctx, client, originID, version, network)
-			if err != nil {
-				return nil, err
-			}
-
-		case <-ctx.Done():
-			if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+		if err := poller.Wait(ctx, string(activation.Status)); err != nil {
+			if errors.Is(err, context.DeadlineExceeded) {
 				return nil, ErrApplicationLoadBalancerActivationTimeout
 			}
-			if errors.Is(ctx.Err(), context.Canceled) {
+			if errors.Is(err, context.Canceled) {
 				return nil, ErrApplicationLoadBalancerActivationCanceled
 			}
-			return nil, fmt.Errorf("%v: %w", ErrApplicationLoadBalancerActivationContextTerminated, ctx.Err())
+			return nil, fmt.Errorf("%v: %w", ErrApplicationLoadBalancerActivationContextTerminated, err)
+		}
+		activation, err = getApplicationLoadBalancerActivation(ctx, client, originID, version, network)
+		if err != nil {
+			return nil, err
 		}
 	}
 	if activation.Status == cloudlets.LoadBalancerActivationStatusActive {
@@ -405,7 +405,6 @@ func TestResourceCloudletsApplicationLoadBalancerActivation(t *testing.T) {
 	}
 
 	// redefining times to run the tests faster
-	ALBActivationPollMinimum = time.Millisecond * 1
 	ALBActivationPollInterval = time.Millisecond * 1
 
 	for name, test := range tests {
@@ -63,9 +63,10 @@ func resourceCloudletsPolicy() *schema.Resource {
 			},
 			"group_id": {
 				Type:             schema.TypeString,
-				Required:         true,
+				Optional:         true,
+				Computed:         true,
 				DiffSuppressFunc: diffSuppressGroupID,
-				Description:      "Defines the group association for the policy. You must have edit privileges for the group",
+				Description:      "Defines the group association for the policy, the provider default_group_id is used if not set. You must have edit privileges for the group",
 			},
 			"match_rule_format": {
 				Type:             schema.TypeString,
@@ -144,11 +145,11 @@ func resourcePolicyCreate(ctx context.Context, d *schema.ResourceData, m interfa
 	if err != nil {
 		return diag.FromErr(err)
 	}
-	groupID, err := tools.GetStringValue("group_id", d)
+	groupID, err := akamai.GetGroupID(d, m, "group_id")
 	if err != nil {
 		return diag.FromErr(err)
 	}
-	groupIDNum, err := tools.GetIntID(groupID, "grp_")
+	groupIDNum, err := tools.NormalizeIntID(groupID, tools.GroupPrefix)
 	if err != nil {
 		return diag.Errorf("invalid group_id provided: %s", err)
 	}
@@ -276,11 +277,11 @@ func resourcePolicyUpdate(ctx context.Context, d *schema.ResourceData, m interfa
 		if err != nil {
 			return diag.FromErr(err)
 		}
-		groupID, err := tools.GetStringValue("group_id", d)
+		groupID, err := akamai.GetGroupID(d, m, "group_id")
 		if err != nil {
 			return diag.FromErr(err)
 		}
-		groupIDNum, err := tools.GetIntID(groupID, "grp_")
+		groupIDNum, err := tools.NormalizeIntID(groupID, tools.GroupPrefix)
 		if err != nil {
 			return diag.FromErr(err)
 		}
@@ -64,15 +64,13 @@ func resourceCloudletsPolicyActivationSchema() map[string]*schema.Schema {
 			MinItems:    1,
 			Description: "Set of property IDs to link to this Cloudlets policy",
 		},
+		akamai.PollIntervalField: akamai.PollIntervalSchema(),
 	}
 }
 
 var (
-	// ActivationPollMinimum is the minimum polling interval for activation creation
-	ActivationPollMinimum = time.Minute
-
-	// ActivationPollInterval is the interval for polling an activation status on creation
-	ActivationPollInterval = ActivationPollMinimum
+	// ActivationPollInterval is the first interval for polling an activation status when poll_interval is not set
+	ActivationPollInterval = akamai.DefaultActivationPollInterval
 
 	// PolicyActivationResourceTimeout is the default timeout for the resource operations
 	PolicyActivationResourceTimeout = time.Minute * 90
@@ -111,6 +109,10 @@ func resourcePolicyActivationDelete(ctx context.Context, rd *schema.ResourceData
 		return diag.FromErr(err)
 	}
 
+	poller, err := akamai.NewActivationPoller(rd, ActivationPollInterval, logger, fmt.Sprintf("policy %d deactivation on %s", policyID, network))
+	if err != nil {
+		return diag.FromErr(err)
+	}
 	logger.Debugf("Removing all policy (ID=%d) properties", policyID)
 	for propertyName, policyProperty := range policyProperties {
 		// filter out property by network
@@ -125,7 +127,7 @@ func resourcePolicyActivationDelete(ctx context.Context, rd *schema.ResourceData
 			continue
 		}
 		// wait for removal until there aren't any pending activations
-		if err = waitForNotPendingPolicyActivation(ctx, logger, client, policyID, network); err != nil {
+		if err = waitForNotPendingPolicyActivation(ctx, poller, logger, client, policyID, network); err != nil {
 			return diag.FromErr(err)
 		}
 
@@ -240,14 +242,19 @@ func resourcePolicyActivationUpdate(ctx context.Context, rd *schema.ResourceData
 		return diag.Errorf("%v update: %s", ErrPolicyActivation, err.Error())
 	}
 
+	poller, err := akamai.NewActivationPoller(rd, ActivationPollInterval, logger, fmt.Sprintf("policy %d version %d activation on %s", policyID, version, activationNetwork))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
 	// 6. remove from the server all unnecessary policy associated_properties
-	removedProperties, err := syncToServerRemovedProperties(ctx, logger, client, int64(policyID), activationNetwork, activeProps, newPolicyProperties)
+	removedProperties, err := syncToServerRemovedProperties(ctx, poller, logger, client, int64(policyID), activationNetwork, activeProps, newPolicyProperties)
 	if err != nil {
 		return diag.FromErr(err)
 	}
 
 	// 7. poll until active
-	_, err = waitForPolicyActivation(ctx, client, int64(policyID), version, activationNetwork, newPolicyProperties, removedProperties)
+	_, err = waitForPolicyActivation(ctx, poller, client, int64(policyID), version, activationNetwork, newPolicyProperties, removedProperties)
 	if err != nil {
 		return diag.Errorf("%v update: %s", ErrPolicyActivation, err.Error())
 	}
@@ -335,7 +342,11 @@ func resourcePolicyActivationCreate(ctx context.Context, rd *schema.ResourceData
 	}
 
 	// wait until policy activation is done
-	act, err := waitForPolicyActivation(ctx, client, int64(policyID), version, versionActivationNetwork, associatedProperties, nil)
+	poller, err := akamai.NewActivationPoller(rd, ActivationPollInterval, logger, fmt.Sprintf("policy %d version %d activation on %s", policyID, version, versionActivationNetwork))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	act, err := waitForPolicyActivation(ctx, poller, client, int64(policyID), version, versionActivationNetwork, associatedProperties, nil)
 	if err != nil {
 		return diag.Errorf("%v create: %s", ErrPolicyActivation, err.Error())
 	}
@@ -411,7 +422,7 @@ func getActiveProperties(policyActivations []cloudlets.PolicyActivation) []strin
 }
 
 // waitForPolicyActivation polls server until the activation has active status or until context is closed (because of timeout, cancellation or context termination)
-func waitForPolicyActivation(ctx context.Context, client cloudlets.Cloudlets, policyID, version int64, network cloudlets.PolicyActivationNetwork, additionalProps, removedProperties []string) ([]cloudlets.PolicyActivation, error) {
+func waitForPolicyActivation(ctx context.Context, poller *akamai.ActivationPoller, client cloudlets.Cloudlets, policyID, version int64, network cloudlets.PolicyActivationNetwork, additionalProps, removedProperties []string) ([]cloudlets.PolicyActivation, error) {
 	activations, err := client.ListPolicyActivations(ctx, cloudlets.ListPolicyActivationsRequest{
 		PolicyID: policyID,
 		Network:  network,
@@ -444,26 +455,23 @@ func waitForPolicyActivation(ctx context.Context, client cloudlets.Cloudlets, po
 		if allActive && allRemoved {
 			return activations, nil
 		}
-		select {
-		case <-time.After(tools.MaxDuration(ActivationPollInterval, ActivationPollMinimum)):
-			activations, err = client.ListPolicyActivations(ctx, cloudlets.ListPolicyActivationsRequest{
-				PolicyID: policyID,
-				Network:  network,
-			})
-			if err != nil {
-				return nil, err
-			}
-			activations = filterActivations(activations, version, additionalProps)
-
-		case <-ctx.Done():
-			if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+		if err := poller.Wait(ctx, string(cloudlets.PolicyActivationStatusPending)); err != nil {
+			if errors.Is(err, context.DeadlineExceeded) {
 				return nil, ErrPolicyActivationTimeout
 			}
-			if errors.Is(ctx.Err(), context.Canceled) {
+			if errors.Is(err, context.Canceled) {
 				return nil, ErrPolicyActivationCanceled
 			}
-			return nil, fmt.Errorf("%v: %w", ErrPolicyActivationContextTerminated, ctx.Err())
+			return nil, fmt.Errorf("%v: %w", ErrPolicyActivationContextTerminated, err)
+		}
+		activations, err = client.ListPolicyActivations(ctx, cloudlets.ListPolicyActivationsRequest{
+			PolicyID: policyID,
+			Network:  network,
+		})
+		if err != nil {
+			return nil, err
 		}
+		activations = filterActivations(activations, version, additionalProps)
 	}
 
 	if len(activations) == 0 {
@@ -547,7 +555,7 @@ func statePolicyActivationNetwork(i interface{}) string {
 	return net
 }
 
-func syncToServerRemovedProperties(ctx context.Context, logger log.Interface, client cloudlets.Cloudlets, policyID int64, network cloudlets.PolicyActivationNetwork, activeProps, newPolicyProperties []string) ([]string, error) {
+func syncToServerRemovedProperties(ctx context.Context, poller *akamai.ActivationPoller, logger log.Interface, client cloudlets.Cloudlets, policyID int64, network cloudlets.PolicyActivationNetwork, activeProps, newPolicyProperties []string) ([]string, error) {
 	policyProperties, err := client.GetPolicyProperties(ctx, cloudlets.GetPolicyPropertiesRequest{PolicyID: policyID})
 	if err != nil {
 		return nil, fmt.Errorf("%w: cannot find policy %d properties: %s", ErrPolicyActivation, policyID, err.Error())
@@ -569,7 +577,7 @@ activePropertiesLoop:
 		propertyID := associateProperty.ID
 
 		// wait for removal until there aren't any pending activations
-		if err = waitForNotPendingPolicyActivation(ctx, logger, client, policyID, network); err != nil {
+		if err = waitForNotPendingPolicyActivation(ctx, poller, logger, client, policyID, network); err != nil {
 			return nil, err
 		}
 
@@ -582,7 +590,7 @@ activePropertiesLoop:
 	}
 
 	// wait for removal until there aren't any pending activations
-	if err = waitForNotPendingPolicyActivation(ctx, logger, client, policyID, network); err != nil {
+	if err = waitForNotPendingPolicyActivation(ctx, poller, logger, client, policyID, network); err != nil {
 		return nil, err
 	}
 
@@ -590,7 +598,7 @@ activePropertiesLoop:
 	return removedProperties, nil
 }
 
-func waitForNotPendingPolicyActivation(ctx context.Context, logger log.Interface, client cloudlets.Cloudlets, policyID int64, network cloudlets.PolicyActivationNetwork) error {
+func waitForNotPendingPolicyActivation(ctx context.Context, poller *akamai.ActivationPoller, logger log.Interface, client cloudlets.Cloudlets, policyID int64, network cloudlets.PolicyActivationNetwork) error {
 	logger.Debugf("waiting until there none of the policy (ID=%d) activations are in pending state", policyID)
 	activations, err := client.ListPolicyActivations(ctx, cloudlets.ListPolicyActivationsRequest{PolicyID: policyID})
 	if err != nil {
@@ -610,24 +618,21 @@ func waitForNotPendingPolicyActivation(ctx context.Context, logger log.Interface
 		if !pending {
 			break
 		}
-		select {
-		case <-time.After(tools.MaxDuration(ActivationPollInterval, ActivationPollMinimum)):
-			activations, err = client.ListPolicyActivations(ctx, cloudlets.ListPolicyActivationsRequest{
-				PolicyID: policyID,
-				Network:  network,
-			})
-			if err != nil {
-				return fmt.Errorf("%w: failed to list policy activations for policy %d: %s", ErrPolicyActivation, policyID, err.Error())
-			}
-
-		case <-ctx.Done():
-			if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+		if err := poller.Wait(ctx, string(cloudlets.PolicyActivationStatusPending)); err != nil {
+			if errors.Is(err, context.DeadlineExceeded) {
 				return ErrPolicyActivationTimeout
 			}
-			if errors.Is(ctx.Err(), context.Canceled) {
+			if errors.Is(err, context.Canceled) {
 				return ErrPolicyActivationCanceled
 			}
-			return fmt.Errorf("%v: %w", ErrPolicyActivationContextTerminated, ctx.Err())
+			return fmt.Errorf("%v: %w", ErrPolicyActivationContextTerminated, err)
+		}
+		activations, err = client.ListPolicyActivations(ctx, cloudlets.ListPolicyActivationsRequest{
+			PolicyID: policyID,
+			Network:  network,
+		})
+		if err != nil {
+			return fmt.Errorf("%w: failed to list policy activations for policy %d: %s", ErrPolicyActivation, policyID, err.Error())
 		}
 	}
 
@@ -641,7 +641,7 @@ func TestResourceCloudletsPolicyActivation(t *testing.T) {
 	}
 
 	// redefining times to accelerate tests
-	ActivationPollMinimum, ActivationPollInterval = time.Millisecond, time.Millisecond
+	ActivationPollInterval = time.Millisecond
 
 	for name, test := range tests {
 		t.Run(name, func(t *testing.T) {
@@ -66,7 +66,7 @@ func (p *provider) Client(meta akamai.OperationMeta) cps.CPS {
 	if p.client != nil {
 		return p.client
 	}
-	return cps.Client(meta.Session())
+	return cps.Client(meta.SubproviderSession(p))
 }
 
 func (p *provider) Name() string {
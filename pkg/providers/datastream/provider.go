@@ -67,7 +67,7 @@ func (p *provider) Client(meta akamai.OperationMeta) datastream.DS {
 	if p.client != nil {
 		return p.client
 	}
-	return datastream.Client(meta.Session())
+	return datastream.Client(meta.SubproviderSession(p))
 }
 
 func (p *provider) Name() string {
@@ -80,9 +80,10 @@ var datastreamResourceSchema = map[string]*schema.Schema{
 	},
 	"contract_id": {
 		Type:             schema.TypeString,
-		Required:         true,
+		Optional:         true,
+		Computed:         true,
 		DiffSuppressFunc: tools.FieldPrefixSuppress("ctr_"),
-		Description:      "Identifies the contract that has access to the product",
+		Description:      "Identifies the contract that has access to the product, the provider default_contract_id is used if not set",
 	},
 	"created_by": {
 		Type:        schema.TypeString,
@@ -113,9 +114,10 @@ var datastreamResourceSchema = map[string]*schema.Schema{
 	},
 	"group_id": {
 		Type:             schema.TypeString,
-		Required:         true,
+		Optional:         true,
+		Computed:         true,
 		DiffSuppressFunc: tools.FieldPrefixSuppress("grp_"),
-		Description:      "Identifies the group that has access to the product and for which the stream configuration was created",
+		Description:      "Identifies the group that has access to the product and for which the stream configuration was created, the provider default_group_id is used if not set",
 	},
 	"group_name": {
 		Type:        schema.TypeString,
@@ -631,11 +633,11 @@ func resourceDatastreamCreate(ctx context.Context, d *schema.ResourceData, m int
 		return diag.FromErr(err)
 	}
 
-	contractID, err := tools.GetStringValue("contract_id", d)
+	contractID, err := akamai.GetContractID(d, m, "contract_id")
 	if err != nil {
 		return diag.FromErr(err)
 	}
-	contractID = strings.TrimPrefix(contractID, "ctr_")
+	contractID = tools.NormalizeID(contractID, tools.ContractPrefix, false)
 
 	datasetFieldsIDsList, err := tools.GetListValue("dataset_fields_ids", d)
 	if err != nil {
@@ -651,11 +653,11 @@ func resourceDatastreamCreate(ctx context.Context, d *schema.ResourceData, m int
 	}
 	emailIDs := strings.Join(InterfaceSliceToStringSlice(emailIDsList), ",")
 
-	groupIDStr, err := tools.GetStringValue("group_id", d)
+	groupIDStr, err := akamai.GetGroupID(d, m, "group_id")
 	if err != nil {
 		return diag.FromErr(err)
 	}
-	groupID, err := strconv.Atoi(strings.TrimPrefix(groupIDStr, "grp_"))
+	groupID, err := tools.NormalizeIntID(groupIDStr, tools.GroupPrefix)
 	if err != nil {
 		return diag.FromErr(err)
 	}
@@ -83,7 +83,7 @@ func (p *provider) Client(meta akamai.OperationMeta) dns.DNS {
 	if p.client != nil {
 		return p.client
 	}
-	return dns.Client(meta.Session())
+	return dns.Client(meta.SubproviderSession(p))
 }
 
 func getConfigDNSV2Service(d *schema.ResourceData) error {
@@ -33,8 +33,10 @@ func resourceDNSv2Zone() *schema.Resource {
 		Schema: map[string]*schema.Schema{
 			"contract": {
 				Type:             schema.TypeString,
-				Required:         true,
+				Optional:         true,
+				Computed:         true,
 				DiffSuppressFunc: tools.FieldPrefixSuppress("ctr_"),
+				Description:      "The contract ID of the zone, the provider default_contract_id is used if not set",
 			},
 			"zone": {
 				Type:     schema.TypeString,
@@ -64,6 +66,7 @@ func resourceDNSv2Zone() *schema.Resource {
 				Type:             schema.TypeString,
 				Optional:         true,
 				DiffSuppressFunc: tools.FieldPrefixSuppress("grp_"),
+				Description:      "The group ID of the zone, the provider default_group_id is used if not set",
 			},
 			"sign_and_serve": {
 				Type:     schema.TypeBool,
@@ -148,17 +151,10 @@ func resourceDNSv2ZoneCreate(ctx context.Context, d *schema.ResourceData, m inte
 	if strings.ToUpper(zoneType) == "SECONDARY" && len(masterlist) == 0 {
 		return diag.Errorf("DNS Secondary zone requires masters for zone %v", hostname)
 	}
-	contractStr, err := tools.GetStringValue("contract", d)
-	if err != nil {
-		return diag.FromErr(err)
-	}
-	groupStr, err := tools.GetStringValue("group", d)
+	zoneQueryString, err := getZoneQueryString(d, m)
 	if err != nil {
 		return diag.FromErr(err)
 	}
-	contract := strings.TrimPrefix(contractStr, "ctr_")
-	group := strings.TrimPrefix(groupStr, "grp_")
-	zoneQueryString := dns.ZoneQueryString{Contract: contract, Group: group}
 	zoneCreate := &dns.ZoneCreate{Zone: hostname, Type: zoneType}
 	if err := populateDNSv2ZoneObject(d, zoneCreate, logger); err != nil {
 		return diag.FromErr(err)
@@ -339,19 +335,14 @@ func resourceDNSv2ZoneUpdate(ctx context.Context, d *schema.ResourceData, m inte
 	if err != nil {
 		return diag.FromErr(err)
 	}
-	contract, err := tools.GetStringValue("contract", d)
-	if err != nil {
-		return diag.FromErr(err)
-	}
-	group, err := tools.GetStringValue("group", d)
+	zoneType, err := tools.GetStringValue("type", d)
 	if err != nil {
 		return diag.FromErr(err)
 	}
-	zoneType, err := tools.GetStringValue("type", d)
+	zoneQueryString, err := getZoneQueryString(d, m)
 	if err != nil {
 		return diag.FromErr(err)
 	}
-	zoneQueryString := dns.ZoneQueryString{Contract: contract, Group: group}
 
 	logger.Debugf("Searching for zone [%s]", hostname)
 	zone, e := inst.Client(meta).GetZone(ctx, hostname)
@@ -695,3 +686,20 @@ func createNSRecord(zone string, nameservers []string, _ log.Interface) dns.Reco
 
 	return rec
 }
+
+// getZoneQueryString returns the contract and group of the zone, or the provider defaults if they are not set.
+// The group is optional, so the query has no group when neither the zone nor the provider sets one.
+func getZoneQueryString(d *schema.ResourceData, m interface{}) (dns.ZoneQueryString, error) {
+	contractID, err := akamai.GetContractID(d, m, "contract")
+	if err != nil {
+		return dns.ZoneQueryString{}, err
+	}
+	groupID, err := akamai.GetGroupID(d, m, "group")
+	if err != nil && !errors.Is(err, akamai.ErrNoGroupID) {
+		return dns.ZoneQueryString{}, err
+	}
+	return dns.ZoneQueryString{
+		Contract: tools.NormalizeID(contractID, tools.ContractPrefix, false),
+		Group:    tools.NormalizeID(groupID, tools.GroupPrefix, false),
+	}, nil
+}
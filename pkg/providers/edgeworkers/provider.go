@@ -69,7 +69,7 @@ func (p *provider) Client(meta akamai.OperationMeta) edgeworkers.Edgeworkers {
 	if p.client != nil {
 		return p.client
 	}
-	return edgeworkers.Client(meta.Session())
+	return edgeworkers.Client(meta.SubproviderSession(p))
 }
 
 func (p *provider) Name() string {
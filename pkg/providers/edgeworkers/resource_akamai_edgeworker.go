@@ -55,8 +55,9 @@ func resourceEdgeWorker() *schema.Resource {
 			},
 			"group_id": {
 				Type:        schema.TypeInt,
-				Required:    true,
-				Description: "Defines the group association for the EdgeWorker",
+				Optional:    true,
+				Computed:    true,
+				Description: "Defines the group association for the EdgeWorker, the provider default_group_id is used if not set",
 			},
 			"resource_tier_id": {
 				Type:        schema.TypeInt,
@@ -109,10 +110,14 @@ func resourceEdgeWorkerCreate(ctx context.Context, d *schema.ResourceData, m int
 	if err != nil {
 		return diag.FromErr(err)
 	}
-	groupID, err := tools.GetIntValue("group_id", d)
+	group, err := akamai.GetGroupID(d, m, "group_id")
 	if err != nil {
 		return diag.FromErr(err)
 	}
+	groupID, err := tools.NormalizeIntID(group, tools.GroupPrefix)
+	if err != nil {
+		return diag.Errorf("invalid group_id provided: %s", err)
+	}
 	createEdgeWorkerIDReq := edgeworkers.CreateEdgeWorkerIDRequest{
 		Name:           name,
 		GroupID:        groupID,
@@ -277,10 +282,14 @@ func resourceEdgeWorkerUpdate(ctx context.Context, d *schema.ResourceData, m int
 			return diag.FromErr(err)
 		}
 	}
-	groupID, err := tools.GetIntValue("group_id", d)
+	group, err := akamai.GetGroupID(d, m, "group_id")
 	if err != nil {
 		return diag.FromErr(err)
 	}
+	groupID, err := tools.NormalizeIntID(group, tools.GroupPrefix)
+	if err != nil {
+		return diag.Errorf("invalid group_id provided: %s", err)
+	}
 	name, err := tools.GetStringValue("name", d)
 	if err != nil {
 		return diag.FromErr(err)
@@ -7,6 +7,8 @@ import (
 	"sort"
 	"time"
 
+	"github.com/apex/log"
+
 	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v2/pkg/edgeworkers"
 	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v2/pkg/session"
 	"github.com/akamai/terraform-provider-akamai/v2/pkg/akamai"
@@ -58,6 +60,7 @@ func resourceEdgeworkersActivationSchema() map[string]*schema.Schema {
 			Computed:    true,
 			Description: "A unique identifier of the activation",
 		},
+		akamai.PollIntervalField: akamai.PollIntervalSchema(),
 	}
 }
 
@@ -68,8 +71,7 @@ var (
 	activationStatusInProgress                  = "IN_PROGRESS"
 	errorCodeVersionIsBeingDeactivated          = "EW1031"
 	errorCodeVersionAlreadyDeactivated          = "EW1032"
-	activationPollMinimum                       = time.Minute
-	activationPollInterval                      = activationPollMinimum
+	activationPollInterval                      = akamai.DefaultActivationPollInterval
 	edgeworkersActivationResourceDefaultTimeout = time.Minute * 30
 	edgeworkersActivationResourceDeleteTimeout  = time.Minute * 60
 )
@@ -82,7 +84,7 @@ func resourceEdgeworkersActivationCreate(ctx context.Context, rd *schema.Resourc
 
 	logger.Debug("Activating edgeworker")
 
-	return upsertActivation(ctx, rd, m, client)
+	return upsertActivation(ctx, rd, m, logger, client)
 }
 
 func resourceEdgeworkersActivationRead(ctx context.Context, rd *schema.ResourceData, m interface{}) diag.Diagnostics {
@@ -103,7 +105,11 @@ func resourceEdgeworkersActivationRead(ctx context.Context, rd *schema.ResourceD
 		return diag.FromErr(err)
 	}
 
-	activation, err := getCurrentActivation(ctx, client, edgeworkerID, network, false)
+	poller, err := akamai.NewActivationPoller(rd, activationPollInterval, logger, fmt.Sprintf("edgeworker %d activation on %s", edgeworkerID, network))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	activation, err := getCurrentActivation(ctx, poller, client, edgeworkerID, network, false)
 	if err != nil {
 		return diag.Errorf("%s read: %s", ErrEdgeworkerActivation, err)
 	}
@@ -130,7 +136,7 @@ func resourceEdgeworkersActivationUpdate(ctx context.Context, rd *schema.Resourc
 
 	logger.Debug("Updating edgeworker activation")
 
-	return upsertActivation(ctx, rd, m, client)
+	return upsertActivation(ctx, rd, m, logger, client)
 }
 
 func resourceEdgeworkersActivationDelete(ctx context.Context, rd *schema.ResourceData, m interface{}) diag.Diagnostics {
@@ -156,6 +162,11 @@ func resourceEdgeworkersActivationDelete(ctx context.Context, rd *schema.Resourc
 		return diag.FromErr(err)
 	}
 
+	poller, err := akamai.NewActivationPoller(rd, activationPollInterval, logger, fmt.Sprintf("edgeworker %d version %s deactivation on %s", edgeworkerID, version, network))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
 	deactivation, err := client.DeactivateVersion(ctx, edgeworkers.DeactivateVersionRequest{
 		EdgeWorkerID: edgeworkerID,
 		DeactivateVersion: edgeworkers.DeactivateVersion{
@@ -186,7 +197,7 @@ func resourceEdgeworkersActivationDelete(ctx context.Context, rd *schema.Resourc
 		}
 	}
 
-	if _, err := waitForEdgeworkerDeactivation(ctx, client, edgeworkerID, deactivation.DeactivationID); err != nil {
+	if _, err := waitForEdgeworkerDeactivation(ctx, poller, client, edgeworkerID, deactivation.DeactivationID); err != nil {
 		if errors.Is(err, ErrEdgeworkerDeactivationTimeout) {
 			rd.SetId("")
 			return append(tools.DiagWarningf("%s: %s", ErrEdgeworkerDeactivation, err), tools.DiagWarningf("Resource has been removed from the state, but deactivation is still ongoing on the server")...)
@@ -198,7 +209,7 @@ func resourceEdgeworkersActivationDelete(ctx context.Context, rd *schema.Resourc
 	return nil
 }
 
-func upsertActivation(ctx context.Context, rd *schema.ResourceData, m interface{}, client edgeworkers.Edgeworkers) diag.Diagnostics {
+func upsertActivation(ctx context.Context, rd *schema.ResourceData, m interface{}, logger log.Interface, client edgeworkers.Edgeworkers) diag.Diagnostics {
 	edgeworkerID, err := tools.GetIntValue("edgeworker_id", rd)
 	if err != nil {
 		return diag.FromErr(err)
@@ -224,7 +235,11 @@ func upsertActivation(ctx context.Context, rd *schema.ResourceData, m interface{
 		return diag.Errorf(`%s: version '%s' is not valid for edgeworker with id=%d`, ErrEdgeworkerActivation, version, edgeworkerID)
 	}
 
-	currentActivation, err := getCurrentActivation(ctx, client, edgeworkerID, network, true)
+	poller, err := akamai.NewActivationPoller(rd, activationPollInterval, logger, fmt.Sprintf("edgeworker %d version %s activation on %s", edgeworkerID, version, network))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	currentActivation, err := getCurrentActivation(ctx, poller, client, edgeworkerID, network, true)
 	if err != nil {
 		return diag.Errorf("%s: %s", ErrEdgeworkerActivation, err.Error())
 	}
@@ -246,7 +261,7 @@ func upsertActivation(ctx context.Context, rd *schema.ResourceData, m interface{
 		return diag.Errorf("%s: %s", ErrEdgeworkerActivation, err.Error())
 	}
 
-	if _, err := waitForEdgeworkerActivation(ctx, client, edgeworkerID, activation.ActivationID); err != nil {
+	if _, err := waitForEdgeworkerActivation(ctx, poller, client, edgeworkerID, activation.ActivationID); err != nil {
 		return diag.Errorf("%s: %s", ErrEdgeworkerActivation, err.Error())
 	}
 
@@ -254,7 +269,7 @@ func upsertActivation(ctx context.Context, rd *schema.ResourceData, m interface{
 	return resourceEdgeworkersActivationRead(ctx, rd, m)
 }
 
-func getCurrentActivation(ctx context.Context, client edgeworkers.Edgeworkers, edgeworkerID int, network string, waitForDeactivation bool) (*edgeworkers.Activation, error) {
+func getCurrentActivation(ctx context.Context, poller *akamai.ActivationPoller, client edgeworkers.Edgeworkers, edgeworkerID int, network string, waitForDeactivation bool) (*edgeworkers.Activation, error) {
 	activationsResp, err := client.ListActivations(ctx, edgeworkers.ListActivationsRequest{
 		EdgeWorkerID: edgeworkerID,
 	})
@@ -272,7 +287,7 @@ func getCurrentActivation(ctx context.Context, client edgeworkers.Edgeworkers, e
 	case activationStatusComplete:
 		// do nothing
 	case activationStatusPresubmit, activationStatusPending, activationStatusInProgress:
-		latestActivation, err = waitForEdgeworkerActivation(ctx, client, edgeworkerID, latestActivation.ActivationID)
+		latestActivation, err = waitForEdgeworkerActivation(ctx, poller, client, edgeworkerID, latestActivation.ActivationID)
 		if err != nil {
 			return nil, err
 		}
@@ -281,7 +296,7 @@ func getCurrentActivation(ctx context.Context, client edgeworkers.Edgeworkers, e
 		return nil, nil
 	}
 
-	latestDeactivation, err := getLatestCompletedDeactivation(ctx, client, edgeworkerID, latestActivation.Version, network, waitForDeactivation)
+	latestDeactivation, err := getLatestCompletedDeactivation(ctx, poller, client, edgeworkerID, latestActivation.Version, network, waitForDeactivation)
 	if err != nil {
 		return nil, err
 	}
@@ -318,7 +333,7 @@ func getDeactivationsByVersionAndNetwork(ctx context.Context, client edgeworkers
 	return sortDeactivationsByDate(filterDeactivationsByNetwork(deactivationsResp.Deactivations, network)), nil
 }
 
-func getLatestCompletedDeactivation(ctx context.Context, client edgeworkers.Edgeworkers, edgeworkerID int, version, network string, wait bool) (*edgeworkers.Deactivation, error) {
+func getLatestCompletedDeactivation(ctx context.Context, poller *akamai.ActivationPoller, client edgeworkers.Edgeworkers, edgeworkerID int, version, network string, wait bool) (*edgeworkers.Deactivation, error) {
 	deactivations, err := getDeactivationsByVersionAndNetwork(ctx, client, edgeworkerID, version, network)
 	if err != nil {
 		return nil, err
@@ -330,7 +345,7 @@ func getLatestCompletedDeactivation(ctx context.Context, client edgeworkers.Edge
 	for i := range deactivations {
 		d := &deactivations[i]
 		if wait && (d.Status == activationStatusPresubmit || d.Status == activationStatusPending || d.Status == activationStatusInProgress) {
-			d, err = waitForEdgeworkerDeactivation(ctx, client, edgeworkerID, d.DeactivationID)
+			d, err = waitForEdgeworkerDeactivation(ctx, poller, client, edgeworkerID, d.DeactivationID)
 			if err != nil {
 				return nil, err
 			}
@@ -351,7 +366,7 @@ func versionExists(version string, versions []edgeworkers.EdgeWorkerVersion) boo
 	return false
 }
 
-func waitForEdgeworkerActivation(ctx context.Context, client edgeworkers.Edgeworkers, edgeworkerID, activationID int) (*edgeworkers.Activation, error) {
+func waitForEdgeworkerActivation(ctx context.Context, poller *akamai.ActivationPoller, client edgeworkers.Edgeworkers, edgeworkerID, activationID int) (*edgeworkers.Activation, error) {
 	activation, err := client.GetActivation(ctx, edgeworkers.GetActivationRequest{
 		EdgeWorkerID: edgeworkerID,
 		ActivationID: activationID,
@@ -363,29 +378,27 @@ func waitForEdgeworkerActivation(ctx context.Context, client edgeworkers.Edgewor
 		if activation.Status != activationStatusPresubmit && activation.Status != activationStatusPending && activation.Status != activationStatusInProgress {
 			return nil, ErrEdgeworkerActivationFailure
 		}
-		select {
-		case <-time.After(tools.MaxDuration(activationPollInterval, activationPollMinimum)):
-			activation, err = client.GetActivation(ctx, edgeworkers.GetActivationRequest{
-				EdgeWorkerID: edgeworkerID,
-				ActivationID: activationID,
-			})
-			if err != nil {
-				return nil, err
-			}
-		case <-ctx.Done():
-			if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+		if err := poller.Wait(ctx, activation.Status); err != nil {
+			if errors.Is(err, context.DeadlineExceeded) {
 				return nil, ErrEdgeworkerActivationTimeout
 			}
-			if errors.Is(ctx.Err(), context.Canceled) {
+			if errors.Is(err, context.Canceled) {
 				return nil, ErrEdgeworkerActivationCancelled
 			}
-			return nil, fmt.Errorf("%v: %w", ErrEdgeworkerActivationContextTerminated, ctx.Err())
+			return nil, fmt.Errorf("%v: %w", ErrEdgeworkerActivationContextTerminated, err)
+		}
+		activation, err = client.GetActivation(ctx, edgeworkers.GetActivationRequest{
+			EdgeWorkerID: edgeworkerID,
+			ActivationID: activationID,
+		})
+		if err != nil {
+			return nil, err
 		}
 	}
 	return activation, nil
 }
 
-func waitForEdgeworkerDeactivation(ctx context.Context, client edgeworkers.Edgeworkers, edgeworkerID, deactivationID int) (*edgeworkers.Deactivation, error) {
+func waitForEdgeworkerDeactivation(ctx context.Context, poller *akamai.ActivationPoller, client edgeworkers.Edgeworkers, edgeworkerID, deactivationID int) (*edgeworkers.Deactivation, error) {
 	deactivation, err := client.GetDeactivation(ctx, edgeworkers.GetDeactivationRequest{
 		EdgeWorkerID:   edgeworkerID,
 		DeactivationID: deactivationID,
@@ -397,23 +410,21 @@ func waitForEdgeworkerDeactivation(ctx context.Context, client edgeworkers.Edgew
 		if deactivation.Status != activationStatusPresubmit && deactivation.Status != activationStatusPending && deactivation.Status != activationStatusInProgress {
 			return nil, ErrEdgeworkerDeactivationFailure
 		}
-		select {
-		case <-time.After(tools.MaxDuration(activationPollInterval, activationPollMinimum)):
-			deactivation, err = client.GetDeactivation(ctx, edgeworkers.GetDeactivationRequest{
-				EdgeWorkerID:   edgeworkerID,
-				DeactivationID: deactivationID,
-			})
-			if err != nil {
-				return nil, err
-			}
-		case <-ctx.Done():
-			if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+		if err := poller.Wait(ctx, deactivation.Status); err != nil {
+			if errors.Is(err, context.DeadlineExceeded) {
 				return nil, ErrEdgeworkerDeactivationTimeout
 			}
-			if errors.Is(ctx.Err(), context.Canceled) {
+			if errors.Is(err, context.Canceled) {
 				return nil, ErrEdgeworkerDeactivationCancelled
 			}
-			return nil, fmt.Errorf("%v: %w", ErrEdgeworkerDeactivationContextTerminated, ctx.Err())
+			return nil, fmt.Errorf("%v: %w", ErrEdgeworkerDeactivationContextTerminated, err)
+		}
+		deactivation, err = client.GetDeactivation(ctx, edgeworkers.GetDeactivationRequest{
+			EdgeWorkerID:   edgeworkerID,
+			DeactivationID: deactivationID,
+		})
+		if err != nil {
+			return nil, err
 		}
 	}
 	return deactivation, nil
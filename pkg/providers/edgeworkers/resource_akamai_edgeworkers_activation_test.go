@@ -1025,8 +1025,7 @@ func TestResourceEdgeworkersActivation(t *testing.T) {
 	}
 
 	// redefining times to accelerate tests
-	activationPollMinimum = time.Millisecond * 1
-	activationPollInterval = activationPollMinimum
+	activationPollInterval = time.Millisecond * 1
 
 	for name, test := range tests {
 		t.Run(name, func(t *testing.T) {
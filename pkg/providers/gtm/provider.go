@@ -87,7 +87,7 @@ func (p *provider) Client(meta akamai.OperationMeta) gtm.GTM {
 	if p.client != nil {
 		return p.client
 	}
-	return gtm.Client(meta.Session())
+	return gtm.Client(meta.SubproviderSession(p))
 }
 
 func getConfigGTMV1Service(d *schema.ResourceData) error {
@@ -38,12 +38,14 @@ func resourceGTMv1Domain() *schema.Resource {
 				Optional:         true,
 				Default:          "",
 				DiffSuppressFunc: tools.FieldPrefixSuppress("ctr_"),
+				Description:      "The contract ID of the domain, the provider default_contract_id is used if not set",
 			},
 			"group": {
 				Type:             schema.TypeString,
 				Optional:         true,
 				Default:          "",
 				DiffSuppressFunc: tools.FieldPrefixSuppress("grp_"),
+				Description:      "The group ID of the domain, the provider default_group_id is used if not set",
 			},
 			"wait_on_complete": {
 				Type:     schema.TypeBool,
@@ -184,24 +186,23 @@ func resourceGTMv1Domain() *schema.Resource {
 }
 
 // GetQueryArgs retrieves optional query args. contractId, groupId [and accountSwitchKey] supported.
-func GetQueryArgs(d *schema.ResourceData) (map[string]string, error) {
+// The provider default_contract_id and default_group_id are used if the domain does not set them.
+func GetQueryArgs(d *schema.ResourceData, m interface{}) (map[string]string, error) {
 
 	qArgs := make(map[string]string)
-	contractName, err := tools.GetStringValue("contract", d)
-	if err != nil {
+	contract, err := akamai.GetContractID(d, m, "contract")
+	if err != nil && !errors.Is(err, akamai.ErrNoContractID) {
 		return nil, fmt.Errorf("contract not present in resource data: %v", err.Error())
 	}
-	contract := strings.TrimPrefix(contractName, "ctr_")
-	if contract != "" && len(contract) > 0 {
-		qArgs["contractId"] = contract
+	if contract != "" {
+		qArgs["contractId"] = tools.NormalizeID(contract, tools.ContractPrefix, false)
 	}
-	groupName, err := tools.GetStringValue("group", d)
-	if err != nil {
+	groupID, err := akamai.GetGroupID(d, m, "group")
+	if err != nil && !errors.Is(err, akamai.ErrNoGroupID) {
 		return nil, fmt.Errorf("group not present in resource data: %v", err.Error())
 	}
-	groupID := strings.TrimPrefix(groupName, "grp_")
-	if groupID != "" && len(groupID) > 0 {
-		qArgs["gid"] = groupID
+	if groupID != "" {
+		qArgs["gid"] = tools.NormalizeID(groupID, tools.GroupPrefix, false)
 	}
 
 	return qArgs, nil
@@ -229,7 +230,7 @@ func resourceGTMv1DomainCreate(ctx context.Context, d *schema.ResourceData, m in
 	}
 	logger.Debugf("Domain: [%v]", newDom)
 	var diags diag.Diagnostics
-	queryArgs, err := GetQueryArgs(d)
+	queryArgs, err := GetQueryArgs(d, m)
 	if err != nil {
 		logger.Errorf("Domain Create failed: %s", err.Error())
 		return append(diags, diag.Diagnostic{
@@ -365,7 +366,7 @@ func resourceGTMv1DomainUpdate(ctx context.Context, d *schema.ResourceData, m in
 	}
 	logger.Debugf("Updating Domain PROPOSED: %v", existDom)
 	//existDom := populateNewDomainObject(d)
-	args, err := GetQueryArgs(d)
+	args, err := GetQueryArgs(d, m)
 	if err != nil {
 		logger.Errorf("Domain Update failed: %s", err.Error())
 		return append(diags, diag.Diagnostic{
@@ -56,7 +56,7 @@ func (p *provider) handleMeta(ctx context.Context, m interface{}, opName string)
 	logger := meta.Log("IAM", opName)
 	logger = logger.WithFields(log.Fields{"operation_id": meta.OperationID()})
 
-	p.SetIAM(iam.Client(meta.Session()))
+	p.SetIAM(iam.Client(meta.SubproviderSession(p)))
 	p.SetCache(metaCache{p, meta})
 
 	return log.NewContext(ctx, logger)
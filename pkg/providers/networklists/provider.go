@@ -83,7 +83,7 @@ func (p *provider) Client(meta akamai.OperationMeta) networklists.NTWRKLISTS {
 	if p.client != nil {
 		return p.client
 	}
-	return networklists.Client(meta.Session())
+	return networklists.Client(meta.SubproviderSession(p))
 }
 
 func getNetworkListV1Service(d *schema.ResourceData) error {
@@ -3,6 +3,7 @@ package networklists
 import (
 	"context"
 	"errors"
+	"fmt"
 	"strconv"
 	"sync"
 	"time"
@@ -59,18 +60,20 @@ func resourceActivations() *schema.Resource {
 				Type:     schema.TypeString,
 				Computed: true,
 			},
+			akamai.PollIntervalField: akamai.PollIntervalSchema(),
+		},
+		Timeouts: &schema.ResourceTimeout{
+			Default: &ActivationTimeout,
 		},
 	}
 }
 
-const (
-	// ActivationPollMinimum is the minumum polling interval for activation creation
-	ActivationPollMinimum = time.Minute
-)
-
 var (
-	// ActivationPollInterval is the interval for polling an activation status on creation
-	ActivationPollInterval = ActivationPollMinimum
+	// ActivationPollInterval is the first interval for polling an activation status when poll_interval is not set
+	ActivationPollInterval = akamai.DefaultActivationPollInterval
+
+	// ActivationTimeout is the default timeout for waiting on an activation
+	ActivationTimeout = 90 * time.Minute
 )
 
 func resourceActivationsCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
@@ -120,19 +123,20 @@ func resourceActivationsCreate(ctx context.Context, d *schema.ResourceData, m in
 	}
 	logger.Debugf("calling 'createActivations': GET STATUS ID %v", lookupResponse)
 
+	poller, err := akamai.NewActivationPoller(d, ActivationPollInterval, logger, fmt.Sprintf("network list %s activation on %s", networkListID, network))
+	if err != nil {
+		return diag.FromErr(err)
+	}
 	for lookupResponse.ActivationStatus != "ACTIVATED" {
-		select {
-		case <-time.After(tools.MaxDuration(ActivationPollInterval, ActivationPollMinimum)):
-			act, err := client.GetActivation(ctx, lookupRequest)
-
-			if err != nil {
-				return diag.FromErr(err)
-			}
-			lookupResponse = act
+		if err := poller.Wait(ctx, string(lookupResponse.ActivationStatus)); err != nil {
+			return diag.Errorf("activation context terminated: %s", err)
+		}
+		act, err := client.GetActivation(ctx, lookupRequest)
 
-		case <-ctx.Done():
-			return diag.Errorf("activation context terminated: %s", ctx.Err())
+		if err != nil {
+			return diag.FromErr(err)
 		}
+		lookupResponse = act
 	}
 
 	return resourceActivationsRead(ctx, d, m)
@@ -177,6 +181,10 @@ func resourceActivationsUpdate(ctx context.Context, d *schema.ResourceData, m in
 	client := inst.Client(meta)
 	logger := meta.Log("NETWORKLIST", "resourceActivationsUpdate")
 
+	if !d.HasChangeExcept(akamai.PollIntervalField) {
+		return resourceActivationsRead(ctx, d, m)
+	}
+
 	networkListID, err := tools.GetStringValue("network_list_id", d)
 	if err != nil && !errors.Is(err, tools.ErrNotFound) {
 		return diag.FromErr(err)
@@ -219,19 +227,20 @@ func resourceActivationsUpdate(ctx context.Context, d *schema.ResourceData, m in
 	}
 	logger.Debugf("calling 'createActivations': GET STATUS ID %v", lookupResponse)
 
+	poller, err := akamai.NewActivationPoller(d, ActivationPollInterval, logger, fmt.Sprintf("network list %s activation on %s", networkListID, network))
+	if err != nil {
+		return diag.FromErr(err)
+	}
 	for lookupResponse.ActivationStatus != "ACTIVATED" {
-		select {
-		case <-time.After(tools.MaxDuration(ActivationPollInterval, ActivationPollMinimum)):
-			act, err := client.GetActivation(ctx, lookupRequest)
-
-			if err != nil {
-				return diag.FromErr(err)
-			}
-			lookupResponse = act
+		if err := poller.Wait(ctx, string(lookupResponse.ActivationStatus)); err != nil {
+			return diag.Errorf("activation context terminated: %s", err)
+		}
+		act, err := client.GetActivation(ctx, lookupRequest)
 
-		case <-ctx.Done():
-			return diag.Errorf("activation context terminated: %s", ctx.Err())
+		if err != nil {
+			return diag.FromErr(err)
 		}
+		lookupResponse = act
 	}
 	return resourceActivationsRead(ctx, d, m)
 }
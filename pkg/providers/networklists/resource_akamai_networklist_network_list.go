@@ -27,6 +27,7 @@ func resourceNetworkList() *schema.Resource {
 		DeleteContext: resourceNetworkListDelete,
 		CustomizeDiff: customdiff.All(
 			VerifyContractGroupUnchanged,
+			verifyContractGroupSet,
 		),
 		Importer: &schema.ResourceImporter{
 			StateContext: schema.ImportStatePassthroughContext,
@@ -84,14 +85,17 @@ func resourceNetworkList() *schema.Resource {
 				Description: "sync point",
 			},
 			"contract_id": {
-				Type:        schema.TypeString,
-				Optional:    true,
-				Description: "contract ID",
+				Type:             schema.TypeString,
+				Optional:         true,
+				Computed:         true,
+				DiffSuppressFunc: tools.FieldPrefixSuppress(tools.ContractPrefix),
+				Description:      "contract ID, the provider default_contract_id is used if not set",
 			},
 			"group_id": {
 				Type:        schema.TypeInt,
 				Optional:    true,
-				Description: "group ID",
+				Computed:    true,
+				Description: "group ID, the provider default_group_id is used if not set",
 			},
 		},
 	}
@@ -122,24 +126,13 @@ func resourceNetworkListCreate(ctx context.Context, d *schema.ResourceData, m in
 	}
 	createNetworkList.Description = description
 
-	contractID, err := tools.GetStringValue("contract_id", d)
-	if err != nil && !errors.Is(err, tools.ErrNotFound) {
+	contractID, groupID, err := getContractAndGroup(d, m)
+	if err != nil {
 		return diag.FromErr(err)
 	}
 	createNetworkList.ContractID = contractID
-
-	groupID, err := tools.GetIntValue("group_id", d)
-	if err != nil && !errors.Is(err, tools.ErrNotFound) {
-		return diag.FromErr(err)
-	}
 	createNetworkList.GroupID = groupID
 
-	if len(contractID) > 0 || groupID > 0 {
-		if len(contractID) == 0 || groupID == 0 {
-			return diag.Errorf("If either a contract_id or group_id is provided, both must be provided")
-		}
-	}
-
 	mode, err := tools.GetStringValue("mode", d)
 	if err != nil && !errors.Is(err, tools.ErrNotFound) {
 		return diag.FromErr(err)
@@ -260,11 +253,12 @@ func resourceNetworkListUpdate(ctx context.Context, d *schema.ResourceData, m in
 	}
 	updateNetworkList.Description = description
 
+	// the contract and group can't be changed, the provider defaults only apply when the list is created
 	contractID, err := tools.GetStringValue("contract_id", d)
 	if err != nil && !errors.Is(err, tools.ErrNotFound) {
 		return diag.FromErr(err)
 	}
-	updateNetworkList.ContractID = contractID
+	updateNetworkList.ContractID = tools.NormalizeID(contractID, tools.ContractPrefix, false)
 
 	groupID, err := tools.GetIntValue("group_id", d)
 	if err != nil && !errors.Is(err, tools.ErrNotFound) {
@@ -272,12 +266,6 @@ func resourceNetworkListUpdate(ctx context.Context, d *schema.ResourceData, m in
 	}
 	updateNetworkList.GroupID = groupID
 
-	if len(contractID) > 0 || groupID > 0 {
-		if len(contractID) == 0 || groupID == 0 {
-			return diag.Errorf("If either a contract_id or group_id is provided, both must be provided")
-		}
-	}
-
 	mode, err := tools.GetStringValue("mode", d)
 	if err != nil && !errors.Is(err, tools.ErrNotFound) {
 		return diag.FromErr(err)
@@ -341,14 +329,6 @@ func resourceNetworkListUpdate(ctx context.Context, d *schema.ResourceData, m in
 		return diag.FromErr(err)
 	}
 
-	if err := d.Set("contract_id", contractID); err != nil {
-		return diag.Errorf("%s: %s", tools.ErrValueSet, err.Error())
-	}
-
-	if err := d.Set("group_id", groupID); err != nil {
-		return diag.Errorf("%s: %s", tools.ErrValueSet, err.Error())
-	}
-
 	return resourceNetworkListRead(ctx, d, m)
 }
 
@@ -505,6 +485,31 @@ func RemoveIndex(hl []string, index int) []string {
 	return append(hl[:index], hl[index+1:]...)
 }
 
+// getContractAndGroup returns the contract and group of the network list, or the provider defaults if they are not set.
+// Network lists can be created without a contract and group, but not with only one of them.
+func getContractAndGroup(d tools.ResourceDataFetcher, m interface{}) (string, int, error) {
+	contractID, err := akamai.GetContractID(d, m, "contract_id")
+	if err != nil && !errors.Is(err, akamai.ErrNoContractID) {
+		return "", 0, err
+	}
+	groupID, err := akamai.GetGroupID(d, m, "group_id")
+	if err != nil && !errors.Is(err, akamai.ErrNoGroupID) {
+		return "", 0, err
+	}
+
+	if contractID == "" && groupID == "" {
+		return "", 0, nil
+	}
+	if contractID == "" || groupID == "" {
+		return "", 0, fmt.Errorf("If either a contract_id or group_id is provided, both must be provided")
+	}
+	group, err := tools.NormalizeIntID(groupID, tools.GroupPrefix)
+	if err != nil {
+		return "", 0, fmt.Errorf("%w: group_id %q is not a number", tools.ErrInvalidType, groupID)
+	}
+	return tools.NormalizeID(contractID, tools.ContractPrefix, false), group, nil
+}
+
 // VerifyContractGroupUnchanged compares the configuration's value for the contract_id and group_id with the resource's
 // value specified in the resources's ID, to ensure that the user has not inadvertently modified the configuration's
 // value; any such modifications indicate an incorrect understanding of the Update operation.
@@ -535,6 +540,16 @@ func VerifyContractGroupUnchanged(_ context.Context, d *schema.ResourceDiff, m i
 	return nil
 }
 
+// verifyContractGroupSet fails the plan of a new network list when only one of the contract and group
+// is set, on the resource or with the provider defaults
+func verifyContractGroupSet(_ context.Context, d *schema.ResourceDiff, m interface{}) error {
+	if d.Id() != "" || !d.NewValueKnown("contract_id") || !d.NewValueKnown("group_id") {
+		return nil
+	}
+	_, _, err := getContractAndGroup(d, m)
+	return err
+}
+
 // Append Replace Remove mode flags
 const (
 	Append  = "APPEND"
@@ -2,6 +2,7 @@ package networklists
 
 import (
 	"encoding/json"
+	"regexp"
 	"testing"
 
 	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v2/pkg/networklists"
@@ -85,4 +86,22 @@ func TestAccAkamaiNetworkList_res_basic(t *testing.T) {
 		client.AssertExpectations(t)
 	})
 
+	t.Run("contract without group", func(t *testing.T) {
+		client := &mocknetworklists{}
+
+		useClient(client, func() {
+			resource.Test(t, resource.TestCase{
+				IsUnitTest: true,
+				Providers:  testAccProviders,
+				Steps: []resource.TestStep{
+					{
+						Config:      loadFixtureString("testdata/TestResNetworkList/contract_without_group.tf"),
+						ExpectError: regexp.MustCompile("both must be provided"),
+					},
+				},
+			})
+		})
+
+		client.AssertExpectations(t)
+	})
 }
@@ -0,0 +1,261 @@
+package property
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v2/pkg/papi"
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v2/pkg/session"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+
+	"github.com/akamai/terraform-provider-akamai/v2/pkg/tools"
+)
+
+type (
+	// complianceRecord is the audit record PAPI accepts with production activations and deactivations
+	complianceRecord struct {
+		NoncomplianceReason      string `json:"noncomplianceReason"`
+		TicketID                 string `json:"ticketId,omitempty"`
+		OtherNoncomplianceReason string `json:"otherNoncomplianceReason,omitempty"`
+		CustomerEmail            string `json:"customerEmail,omitempty"`
+		PeerReviewedBy           string `json:"peerReviewedBy,omitempty"`
+		UnitTested               *bool  `json:"unitTested,omitempty"`
+	}
+
+	// activationWithComplianceRecord is the body of an activation request with a compliance record
+	activationWithComplianceRecord struct {
+		papi.Activation
+		ComplianceRecord *complianceRecord `json:"complianceRecord"`
+	}
+
+	// complianceRecordSession adds the compliance record from the request context to activation requests,
+	// as papi.Activation has no field for it
+	complianceRecordSession struct {
+		session.Session
+	}
+
+	complianceRecordContextKey struct{}
+)
+
+const (
+	noncomplianceReasonNone                = "NONE"
+	noncomplianceReasonOther               = "OTHER"
+	noncomplianceReasonNoProductionTraffic = "NO_PRODUCTION_TRAFFIC"
+	noncomplianceReasonEmergency           = "EMERGENCY"
+)
+
+var (
+	// complianceRecordReasons maps the compliance_record blocks to the noncompliance reasons of PAPI
+	complianceRecordReasons = map[string]string{
+		"noncompliance_reason_none":                  noncomplianceReasonNone,
+		"noncompliance_reason_other":                 noncomplianceReasonOther,
+		"noncompliance_reason_no_production_traffic": noncomplianceReasonNoProductionTraffic,
+		"noncompliance_reason_emergency":             noncomplianceReasonEmergency,
+	}
+
+	// ErrComplianceRecordNetwork is returned when compliance_record is set for a staging activation
+	ErrComplianceRecordNetwork = errors.New("compliance_record can only be set for activations on the PRODUCTION network")
+)
+
+func complianceRecordSchema() *schema.Schema {
+	ticketID := &schema.Schema{
+		Type:        schema.TypeString,
+		Optional:    true,
+		Description: "Identifies the ticket that describes the need for the activation",
+	}
+	var reasons []string
+	for _, block := range complianceRecordBlocks() {
+		reasons = append(reasons, "compliance_record.0."+block)
+	}
+
+	return &schema.Schema{
+		Type:        schema.TypeList,
+		Optional:    true,
+		MaxItems:    1,
+		Description: "Provides an audit record when activating on the PRODUCTION network",
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"noncompliance_reason_none": {
+					Type:         schema.TypeList,
+					Optional:     true,
+					MaxItems:     1,
+					ExactlyOneOf: reasons,
+					Description:  "Provides the record of a compliant activation",
+					Elem: &schema.Resource{
+						Schema: map[string]*schema.Schema{
+							"customer_email": {
+								Type:             schema.TypeString,
+								Required:         true,
+								ValidateDiagFunc: tools.ValidateEmail,
+								Description:      "The email address of the customer who requested the activation",
+							},
+							"peer_reviewed_by": {
+								Type:        schema.TypeString,
+								Required:    true,
+								Description: "The email address of the peer who reviewed the change",
+							},
+							"unit_tested": {
+								Type:        schema.TypeBool,
+								Optional:    true,
+								Default:     false,
+								Description: "Whether the change was unit tested",
+							},
+							"ticket_id": ticketID,
+						},
+					},
+				},
+				"noncompliance_reason_other": {
+					Type:         schema.TypeList,
+					Optional:     true,
+					MaxItems:     1,
+					ExactlyOneOf: reasons,
+					Description:  "Provides the record of an activation that does not comply for another reason",
+					Elem: &schema.Resource{
+						Schema: map[string]*schema.Schema{
+							"other_noncompliance_reason": {
+								Type:             schema.TypeString,
+								Required:         true,
+								ValidateDiagFunc: tools.IsNotBlank,
+								Description:      "Describes why the activation does not comply",
+							},
+							"ticket_id": ticketID,
+						},
+					},
+				},
+				"noncompliance_reason_no_production_traffic": {
+					Type:         schema.TypeList,
+					Optional:     true,
+					MaxItems:     1,
+					ExactlyOneOf: reasons,
+					Description:  "Provides the record of an activation of a property that does not serve production traffic",
+					Elem: &schema.Resource{
+						Schema: map[string]*schema.Schema{
+							"ticket_id": ticketID,
+						},
+					},
+				},
+				"noncompliance_reason_emergency": {
+					Type:         schema.TypeList,
+					Optional:     true,
+					MaxItems:     1,
+					ExactlyOneOf: reasons,
+					Description:  "Provides the record of an emergency activation",
+					Elem: &schema.Resource{
+						Schema: map[string]*schema.Schema{
+							"ticket_id": ticketID,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+// getComplianceRecord returns the compliance record of the activation, or nil if compliance_record is not set
+func getComplianceRecord(d tools.ResourceDataFetcher) (*complianceRecord, error) {
+	blocks, err := tools.GetListValue("compliance_record", d)
+	if err != nil {
+		if errors.Is(err, tools.ErrNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	if len(blocks) == 0 || blocks[0] == nil {
+		return nil, nil
+	}
+	block, ok := blocks[0].(map[string]interface{})
+	if !ok {
+		return nil, fmt.Errorf("%w: %s, %q", tools.ErrInvalidType, "compliance_record", "map[string]interface{}")
+	}
+
+	for _, name := range complianceRecordBlocks() {
+		reason := complianceRecordReasons[name]
+		values, ok := block[name].([]interface{})
+		if !ok || len(values) == 0 {
+			continue
+		}
+		record := &complianceRecord{NoncomplianceReason: reason}
+		fields, ok := values[0].(map[string]interface{})
+		if !ok {
+			// blocks without arguments set are read as nil
+			return record, nil
+		}
+		record.TicketID, _ = fields["ticket_id"].(string)
+		switch reason {
+		case noncomplianceReasonNone:
+			record.CustomerEmail, _ = fields["customer_email"].(string)
+			record.PeerReviewedBy, _ = fields["peer_reviewed_by"].(string)
+			unitTested, _ := fields["unit_tested"].(bool)
+			record.UnitTested = &unitTested
+		case noncomplianceReasonOther:
+			record.OtherNoncomplianceReason, _ = fields["other_noncompliance_reason"].(string)
+		}
+		return record, nil
+	}
+	return nil, fmt.Errorf("compliance_record requires one of %s", strings.Join(complianceRecordBlocks(), ", "))
+}
+
+// validateComplianceRecord verifies at plan time that compliance_record is only set for production activations
+func validateComplianceRecord(_ context.Context, diff *schema.ResourceDiff, _ interface{}) error {
+	if !diff.NewValueKnown("network") || !diff.NewValueKnown("compliance_record") {
+		return nil
+	}
+	return checkComplianceRecordNetwork(diff)
+}
+
+func checkComplianceRecordNetwork(d tools.ResourceDataFetcher) error {
+	record, err := getComplianceRecord(d)
+	if err != nil || record == nil {
+		return err
+	}
+	network, err := tools.GetStringValue("network", d)
+	if err != nil && !errors.Is(err, tools.ErrNotFound) {
+		return err
+	}
+	if network == "" {
+		network = string(papi.ActivationNetworkStaging)
+	}
+	alias, err := NetworkAlias(network)
+	if err != nil {
+		return fmt.Errorf("network %q: %w", network, err)
+	}
+	if papi.ActivationNetwork(alias) != papi.ActivationNetworkProduction {
+		return ErrComplianceRecordNetwork
+	}
+	return nil
+}
+
+func complianceRecordBlocks() []string {
+	return []string{
+		"noncompliance_reason_none",
+		"noncompliance_reason_other",
+		"noncompliance_reason_no_production_traffic",
+		"noncompliance_reason_emergency",
+	}
+}
+
+// contextWithComplianceRecord returns a context which makes activation requests send the compliance record
+func contextWithComplianceRecord(ctx context.Context, record *complianceRecord) context.Context {
+	if record == nil {
+		return ctx
+	}
+	return context.WithValue(ctx, complianceRecordContextKey{}, record)
+}
+
+// Exec adds the compliance record from the request context to the body of activation requests
+func (s complianceRecordSession) Exec(r *http.Request, out interface{}, in ...interface{}) (*http.Response, error) {
+	record, ok := r.Context().Value(complianceRecordContextKey{}).(*complianceRecord)
+	if !ok || r.Method != http.MethodPost || !strings.HasSuffix(r.URL.Path, "/activations") || len(in) == 0 {
+		return s.Session.Exec(r, out, in...)
+	}
+	activation, ok := in[0].(papi.Activation)
+	if !ok {
+		return s.Session.Exec(r, out, in...)
+	}
+
+	body := append([]interface{}{activationWithComplianceRecord{Activation: activation, ComplianceRecord: record}}, in[1:]...)
+	return s.Session.Exec(r, out, body...)
+}
@@ -0,0 +1,174 @@
+package property
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v2/pkg/papi"
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v2/pkg/session"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/stretchr/testify/require"
+	"github.com/tj/assert"
+)
+
+func TestGetComplianceRecord(t *testing.T) {
+	unitTested := true
+	tests := map[string]struct {
+		givenData map[string]interface{}
+		expected  *complianceRecord
+	}{
+		"not set": {
+			givenData: map[string]interface{}{},
+		},
+		"none": {
+			givenData: map[string]interface{}{
+				"compliance_record": []interface{}{map[string]interface{}{
+					"noncompliance_reason_none": []interface{}{map[string]interface{}{
+						"customer_email":   "customer@example.com",
+						"peer_reviewed_by": "reviewer@example.com",
+						"unit_tested":      true,
+						"ticket_id":        "JIRA-1",
+					}},
+				}},
+			},
+			expected: &complianceRecord{
+				NoncomplianceReason: noncomplianceReasonNone,
+				TicketID:            "JIRA-1",
+				CustomerEmail:       "customer@example.com",
+				PeerReviewedBy:      "reviewer@example.com",
+				UnitTested:          &unitTested,
+			},
+		},
+		"other": {
+			givenData: map[string]interface{}{
+				"compliance_record": []interface{}{map[string]interface{}{
+					"noncompliance_reason_other": []interface{}{map[string]interface{}{
+						"other_noncompliance_reason": "tested in the lab",
+					}},
+				}},
+			},
+			expected: &complianceRecord{
+				NoncomplianceReason:      noncomplianceReasonOther,
+				OtherNoncomplianceReason: "tested in the lab",
+			},
+		},
+		"no production traffic": {
+			givenData: map[string]interface{}{
+				"compliance_record": []interface{}{map[string]interface{}{
+					"noncompliance_reason_no_production_traffic": []interface{}{map[string]interface{}{
+						"ticket_id": "JIRA-2",
+					}},
+				}},
+			},
+			expected: &complianceRecord{
+				NoncomplianceReason: noncomplianceReasonNoProductionTraffic,
+				TicketID:            "JIRA-2",
+			},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			d := schema.TestResourceDataRaw(t, akamaiPropertyActivationSchema, test.givenData)
+			record, err := getComplianceRecord(d)
+			require.NoError(t, err)
+			assert.Equal(t, test.expected, record)
+		})
+	}
+}
+
+func TestCheckComplianceRecordNetwork(t *testing.T) {
+	record := []interface{}{map[string]interface{}{
+		"noncompliance_reason_emergency": []interface{}{map[string]interface{}{
+			"ticket_id": "JIRA-3",
+		}},
+	}}
+
+	tests := map[string]struct {
+		givenData map[string]interface{}
+		withError error
+	}{
+		"production": {
+			givenData: map[string]interface{}{"network": "PROD", "compliance_record": record},
+		},
+		"staging": {
+			givenData: map[string]interface{}{"network": "STAGING", "compliance_record": record},
+			withError: ErrComplianceRecordNetwork,
+		},
+		"default network": {
+			givenData: map[string]interface{}{"compliance_record": record},
+			withError: ErrComplianceRecordNetwork,
+		},
+		"staging without record": {
+			givenData: map[string]interface{}{"network": "STAGING"},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			d := schema.TestResourceDataRaw(t, akamaiPropertyActivationSchema, test.givenData)
+			err := checkComplianceRecordNetwork(d)
+			if test.withError != nil {
+				assert.True(t, errors.Is(err, test.withError), "want: %s; got: %s", test.withError, err)
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func TestComplianceRecordSession(t *testing.T) {
+	record := &complianceRecord{NoncomplianceReason: noncomplianceReasonEmergency, TicketID: "JIRA-4"}
+	activation := papi.Activation{Network: papi.ActivationNetworkProduction, PropertyVersion: 2}
+
+	tests := map[string]struct {
+		ctx      context.Context
+		method   string
+		path     string
+		expected interface{}
+	}{
+		"activation with record": {
+			ctx:      contextWithComplianceRecord(context.Background(), record),
+			method:   http.MethodPost,
+			path:     "/papi/v1/properties/prp_1/activations",
+			expected: activationWithComplianceRecord{Activation: activation, ComplianceRecord: record},
+		},
+		"activation without record": {
+			ctx:      context.Background(),
+			method:   http.MethodPost,
+			path:     "/papi/v1/properties/prp_1/activations",
+			expected: activation,
+		},
+		"other request": {
+			ctx:      contextWithComplianceRecord(context.Background(), record),
+			method:   http.MethodPut,
+			path:     "/papi/v1/properties/prp_1/versions/2/rules",
+			expected: activation,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			next := &capturingSession{}
+			req, err := http.NewRequestWithContext(test.ctx, test.method, test.path, nil)
+			require.NoError(t, err)
+
+			_, err = complianceRecordSession{next}.Exec(req, nil, activation)
+			require.NoError(t, err)
+			require.Len(t, next.in, 1)
+			assert.Equal(t, test.expected, next.in[0])
+		})
+	}
+}
+
+type capturingSession struct {
+	session.Session
+	in []interface{}
+}
+
+func (s *capturingSession) Exec(_ *http.Request, _ interface{}, in ...interface{}) (*http.Response, error) {
+	s.in = in
+	return &http.Response{StatusCode: http.StatusCreated}, nil
+}
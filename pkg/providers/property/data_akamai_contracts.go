@@ -2,6 +2,7 @@ package property
 
 import (
 	"context"
+	"errors"
 	"fmt"
 
 	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v2/pkg/papi"
@@ -70,7 +71,7 @@ func dataSourceContractsRead(ctx context.Context, d *schema.ResourceData, m inte
 func getContracts(ctx context.Context, meta akamai.OperationMeta) (*papi.GetContractsResponse, error) {
 	contracts := &papi.GetContractsResponse{}
 	if err := meta.CacheGet(inst, "contracts", contracts); err != nil {
-		if !akamai.IsNotFoundError(err) {
+		if !akamai.IsNotFoundError(err) && !errors.Is(err, akamai.ErrCacheDisabled) {
 			return nil, err
 		}
 		contracts, err = inst.Client(meta).GetContracts(ctx)
@@ -78,7 +79,9 @@ func getContracts(ctx context.Context, meta akamai.OperationMeta) (*papi.GetCont
 			return nil, err
 		}
 		if err := meta.CacheSet(inst, "contracts", contracts); err != nil {
-			return nil, err
+			if !errors.Is(err, akamai.ErrCacheDisabled) {
+				return nil, err
+			}
 		}
 	}
 	return contracts, nil
@@ -0,0 +1,116 @@
+package property
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v2/pkg/hapi"
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v2/pkg/session"
+	"github.com/akamai/terraform-provider-akamai/v2/pkg/akamai"
+	"github.com/akamai/terraform-provider-akamai/v2/pkg/tools"
+)
+
+func dataSourceEdgeHostnames() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataEdgeHostnamesRead,
+		Schema: map[string]*schema.Schema{
+			"record_name_substring": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Lists only the edge hostnames whose record name contains the substring",
+			},
+			"dns_zone": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Lists only the edge hostnames of the DNS zone, such as edgesuite.net or edgekey.net",
+			},
+			"china_only": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Description: "Lists only the edge hostnames mapped to China CDN",
+			},
+			"edge_hostnames": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "List of edge hostnames with their certificate and IP settings",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"edge_hostname_id":    {Type: schema.TypeString, Computed: true},
+						"edge_hostname":       {Type: schema.TypeString, Computed: true},
+						"record_name":         {Type: schema.TypeString, Computed: true},
+						"dns_zone":            {Type: schema.TypeString, Computed: true},
+						"security_type":       {Type: schema.TypeString, Computed: true},
+						"slot_number":         {Type: schema.TypeInt, Computed: true},
+						"ip_version_behavior": {Type: schema.TypeString, Computed: true},
+						"ttl":                 {Type: schema.TypeInt, Computed: true},
+						"use_default_ttl":     {Type: schema.TypeBool, Computed: true},
+						"map":                 {Type: schema.TypeString, Computed: true},
+						"use_default_map":     {Type: schema.TypeBool, Computed: true},
+						"china_cdn":           {Type: schema.TypeBool, Computed: true},
+						"comments":            {Type: schema.TypeString, Computed: true},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataEdgeHostnamesRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	meta := akamai.Meta(m)
+	logger := meta.Log("HAPI", "dataEdgeHostnamesRead")
+	// create a context with logging for api calls
+	ctx = session.ContextWithOptions(
+		ctx,
+		session.WithContextLog(logger),
+	)
+	client := inst.HAPIClient(meta)
+
+	var request listEdgeHostnamesRequest
+	if v, err := tools.GetStringValue("record_name_substring", d); err == nil {
+		request.RecordNameSubstring = v
+	}
+	if v, err := tools.GetStringValue("dns_zone", d); err == nil {
+		request.DNSZone = v
+	}
+	if v, err := tools.GetBoolValue("china_only", d); err == nil {
+		request.ChinaOnly = v
+	}
+
+	logger.Debugf("Listing edge hostnames: %#v", request)
+	edgeHostnames, err := client.ListEdgeHostnames(ctx, request)
+	if err != nil {
+		return diag.Errorf("error listing edge hostnames: %s", err)
+	}
+
+	if err := d.Set("edge_hostnames", edgeHostnamesToList(edgeHostnames)); err != nil {
+		return diag.FromErr(fmt.Errorf("%w: %s", tools.ErrValueSet, err.Error()))
+	}
+	d.SetId(fmt.Sprintf("%s:%s:%s", request.RecordNameSubstring, request.DNSZone, strconv.FormatBool(request.ChinaOnly)))
+	return nil
+}
+
+func edgeHostnamesToList(edgeHostnames []hapi.EdgeHostname) []interface{} {
+	list := make([]interface{}, 0, len(edgeHostnames))
+	for _, h := range edgeHostnames {
+		list = append(list, map[string]interface{}{
+			"edge_hostname_id":    tools.AddPrefix(strconv.Itoa(h.EdgeHostnameID), "ehn_"),
+			"edge_hostname":       fmt.Sprintf("%s.%s", h.RecordName, h.DNSZone),
+			"record_name":         h.RecordName,
+			"dns_zone":            h.DNSZone,
+			"security_type":       h.SecurityType,
+			"slot_number":         h.SlotNumber,
+			"ip_version_behavior": h.IPVersionBehavior,
+			"ttl":                 h.TTL,
+			"use_default_ttl":     h.UseDefaultTTL,
+			"map":                 h.Map,
+			"use_default_map":     h.UseDefaultMap,
+			"china_cdn":           h.ChinaCDN.IsChinaCDN,
+			"comments":            h.Comments,
+		})
+	}
+	return list
+}
@@ -0,0 +1,54 @@
+package property
+
+import (
+	"testing"
+
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v2/pkg/hapi"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestDSEdgeHostnames(t *testing.T) {
+	client := &mockhapi{}
+	client.On("ListEdgeHostnames", AnyCTX, listEdgeHostnamesRequest{RecordNameSubstring: "example", DNSZone: "edgekey.net"}).
+		Return([]hapi.EdgeHostname{
+			{
+				EdgeHostnameID:    123,
+				RecordName:        "www.example.com",
+				DNSZone:           "edgekey.net",
+				SecurityType:      "ENHANCED-TLS",
+				TTL:               300,
+				Map:               "e1.akamaiedge.net",
+				SlotNumber:        456,
+				IPVersionBehavior: "IPV6_IPV4_DUALSTACK",
+			},
+			{
+				EdgeHostnameID:    124,
+				RecordName:        "api.example.com",
+				DNSZone:           "edgekey.net",
+				SecurityType:      "ENHANCED-TLS",
+				UseDefaultTTL:     true,
+				TTL:               21600,
+				SlotNumber:        457,
+				IPVersionBehavior: "IPV4",
+			},
+		}, nil)
+
+	useHAPIClient(client, func() {
+		resource.UnitTest(t, resource.TestCase{
+			Providers: testAccProviders,
+			Steps: []resource.TestStep{{
+				Config: loadFixtureString("testdata/TestDSEdgeHostnames/edge_hostnames.tf"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.akamai_edge_hostnames.test", "id", "example:edgekey.net:false"),
+					resource.TestCheckResourceAttr("data.akamai_edge_hostnames.test", "edge_hostnames.#", "2"),
+					resource.TestCheckResourceAttr("data.akamai_edge_hostnames.test", "edge_hostnames.0.edge_hostname_id", "ehn_123"),
+					resource.TestCheckResourceAttr("data.akamai_edge_hostnames.test", "edge_hostnames.0.edge_hostname", "www.example.com.edgekey.net"),
+					resource.TestCheckResourceAttr("data.akamai_edge_hostnames.test", "edge_hostnames.0.slot_number", "456"),
+					resource.TestCheckResourceAttr("data.akamai_edge_hostnames.test", "edge_hostnames.0.ip_version_behavior", "IPV6_IPV4_DUALSTACK"),
+					resource.TestCheckResourceAttr("data.akamai_edge_hostnames.test", "edge_hostnames.1.use_default_ttl", "true"),
+				),
+			}},
+		})
+	})
+	client.AssertExpectations(t)
+}
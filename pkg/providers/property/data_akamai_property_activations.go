@@ -0,0 +1,146 @@
+package property
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v2/pkg/papi"
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v2/pkg/session"
+	"github.com/akamai/terraform-provider-akamai/v2/pkg/akamai"
+	"github.com/akamai/terraform-provider-akamai/v2/pkg/tools"
+)
+
+func dataSourcePropertyActivations() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataPropertyActivationsRead,
+		Schema: map[string]*schema.Schema{
+			"property_id": {
+				Type:             schema.TypeString,
+				Required:         true,
+				StateFunc:        addPrefixToState("prp_"),
+				ValidateDiagFunc: tools.IsNotBlank,
+			},
+			"network": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Lists only the activations on this network, STAGING or PRODUCTION",
+			},
+			"activations": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "The activations of the property, the most recent first",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"activation_id":   {Type: schema.TypeString, Computed: true},
+						"activation_type": {Type: schema.TypeString, Computed: true},
+						"version":         {Type: schema.TypeInt, Computed: true},
+						"network":         {Type: schema.TypeString, Computed: true},
+						"status":          {Type: schema.TypeString, Computed: true},
+						"note":            {Type: schema.TypeString, Computed: true},
+						"submit_date":     {Type: schema.TypeString, Computed: true},
+						"update_date":     {Type: schema.TypeString, Computed: true},
+						"notify_emails": {
+							Type:     schema.TypeList,
+							Computed: true,
+							Elem:     &schema.Schema{Type: schema.TypeString},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataPropertyActivationsRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	meta := akamai.Meta(m)
+	client := inst.Client(meta)
+	logger := meta.Log("PAPI", "dataPropertyActivationsRead")
+	// create a context with logging for api calls
+	ctx = session.ContextWithOptions(
+		ctx,
+		session.WithContextLog(logger),
+	)
+	logger.Debug("Listing property activations")
+
+	propertyID, err := tools.GetStringValue("property_id", d)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	propertyID = tools.AddPrefix(propertyID, "prp_")
+
+	var network papi.ActivationNetwork
+	networkValue, err := tools.GetStringValue("network", d)
+	if err != nil && !errors.Is(err, tools.ErrNotFound) {
+		return diag.FromErr(err)
+	}
+	if networkValue != "" {
+		alias, err := NetworkAlias(networkValue)
+		if err != nil {
+			return diag.Errorf("%s: %s", networkValue, err)
+		}
+		network = papi.ActivationNetwork(alias)
+	}
+
+	resp, err := client.GetActivations(ctx, papi.GetActivationsRequest{
+		PropertyID: propertyID,
+	})
+	if err != nil {
+		return diag.FromErr(fmt.Errorf("failed to get activations for property: %w", err))
+	}
+
+	activations, err := flattenActivations(resp.Activations.Items, network)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("activations", activations); err != nil {
+		return diag.FromErr(fmt.Errorf("%w: %s", tools.ErrValueSet, err.Error()))
+	}
+
+	d.SetId(propertyID + ":" + string(network))
+	return nil
+}
+
+// flattenActivations returns the activations on the network, or on all networks if network is empty,
+// ordered from the most recent submit date
+func flattenActivations(items []*papi.Activation, network papi.ActivationNetwork) ([]interface{}, error) {
+	type submittedActivation struct {
+		*papi.Activation
+		submitDate int64
+	}
+
+	var activations []submittedActivation
+	for _, item := range items {
+		if network != "" && item.Network != network {
+			continue
+		}
+		submitDate, err := tools.ParseDate(tools.DateTimeFormat, item.SubmitDate)
+		if err != nil {
+			return nil, err
+		}
+		activations = append(activations, submittedActivation{Activation: item, submitDate: submitDate.UnixNano()})
+	}
+	sort.SliceStable(activations, func(i, j int) bool {
+		return activations[i].submitDate > activations[j].submitDate
+	})
+
+	result := make([]interface{}, 0, len(activations))
+	for _, activation := range activations {
+		result = append(result, map[string]interface{}{
+			"activation_id":   activation.ActivationID,
+			"activation_type": string(activation.ActivationType),
+			"version":         activation.PropertyVersion,
+			"network":         string(activation.Network),
+			"status":          string(activation.Status),
+			"note":            activation.Note,
+			"submit_date":     activation.SubmitDate,
+			"update_date":     activation.UpdateDate,
+			"notify_emails":   activation.NotifyEmails,
+		})
+	}
+	return result, nil
+}
@@ -0,0 +1,100 @@
+package property
+
+import (
+	"testing"
+
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v2/pkg/papi"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/stretchr/testify/require"
+	"github.com/tj/assert"
+)
+
+func TestDataPropertyActivations(t *testing.T) {
+	client := &mockpapi{}
+	expectGetActivations(client, "prp_test", activationsResponseHistory, nil)
+
+	useClient(client, func() {
+		resource.UnitTest(t, resource.TestCase{
+			Providers: testAccProviders,
+			Steps: []resource.TestStep{{
+				Config: loadFixtureString("testdata/TestDataPropertyActivations/property_activations.tf"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.akamai_property_activations.test", "id", "prp_test:PRODUCTION"),
+					resource.TestCheckResourceAttr("data.akamai_property_activations.test", "activations.#", "1"),
+					resource.TestCheckResourceAttr("data.akamai_property_activations.test", "activations.0.activation_id", "atv_prod"),
+					resource.TestCheckResourceAttr("data.akamai_property_activations.test", "activations.0.version", "1"),
+					resource.TestCheckResourceAttr("data.akamai_property_activations.test", "activations.0.note", "production release"),
+					resource.TestCheckResourceAttr("data.akamai_property_activations.test", "activations.0.notify_emails.0", "user@example.com"),
+				),
+			}},
+		})
+	})
+
+	client.AssertExpectations(t)
+}
+
+func TestFlattenActivations(t *testing.T) {
+	tests := map[string]struct {
+		network  papi.ActivationNetwork
+		expected []string
+	}{
+		"all networks": {
+			expected: []string{"atv_staging2", "atv_prod", "atv_staging1"},
+		},
+		"staging": {
+			network:  papi.ActivationNetworkStaging,
+			expected: []string{"atv_staging2", "atv_staging1"},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			activations, err := flattenActivations(activationsResponseHistory.Activations.Items, test.network)
+			require.NoError(t, err)
+			var ids []string
+			for _, activation := range activations {
+				ids = append(ids, activation.(map[string]interface{})["activation_id"].(string))
+			}
+			assert.Equal(t, test.expected, ids)
+		})
+	}
+
+	t.Run("invalid submit date", func(t *testing.T) {
+		_, err := flattenActivations([]*papi.Activation{{ActivationID: "atv_1", SubmitDate: "yesterday"}}, "")
+		assert.Error(t, err)
+	})
+}
+
+var activationsResponseHistory = papi.GetActivationsResponse{
+	Activations: papi.ActivationsItems{Items: []*papi.Activation{
+		{
+			ActivationID:    "atv_staging1",
+			ActivationType:  papi.ActivationTypeActivate,
+			PropertyID:      "prp_test",
+			PropertyVersion: 1,
+			Network:         papi.ActivationNetworkStaging,
+			Status:          papi.ActivationStatusActive,
+			SubmitDate:      "2020-10-28T15:04:05Z",
+		},
+		{
+			ActivationID:    "atv_staging2",
+			ActivationType:  papi.ActivationTypeActivate,
+			PropertyID:      "prp_test",
+			PropertyVersion: 2,
+			Network:         papi.ActivationNetworkStaging,
+			Status:          papi.ActivationStatusPending,
+			SubmitDate:      "2020-11-02T10:00:00Z",
+		},
+		{
+			ActivationID:    "atv_prod",
+			ActivationType:  papi.ActivationTypeActivate,
+			PropertyID:      "prp_test",
+			PropertyVersion: 1,
+			Network:         papi.ActivationNetworkProduction,
+			Status:          papi.ActivationStatusActive,
+			SubmitDate:      "2020-10-29T09:30:00Z",
+			Note:            "production release",
+			NotifyEmails:    []string{"user@example.com"},
+		},
+	}},
+}
@@ -0,0 +1,117 @@
+package property
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v2/pkg/session"
+	"github.com/akamai/terraform-provider-akamai/v2/pkg/akamai"
+	"github.com/akamai/terraform-provider-akamai/v2/pkg/tools"
+)
+
+func dataSourcePropertyIncludeParents() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataPropertyIncludeParentsRead,
+		Schema: map[string]*schema.Schema{
+			"include_id": {
+				Type:             schema.TypeString,
+				Required:         true,
+				StateFunc:        addPrefixToState("inc_"),
+				ValidateDiagFunc: tools.IsNotBlank,
+			},
+			"contract_id": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Computed:    true,
+				StateFunc:   addPrefixToState("ctr_"),
+				Description: "Contract ID of the include, the provider default_contract_id is used if not set",
+			},
+			"group_id": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Computed:    true,
+				StateFunc:   addPrefixToState("grp_"),
+				Description: "Group ID of the include, the provider default_group_id is used if not set",
+			},
+			"parents": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "The properties which use the include",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"property_id":                   {Type: schema.TypeString, Computed: true},
+						"property_name":                 {Type: schema.TypeString, Computed: true},
+						"contract_id":                   {Type: schema.TypeString, Computed: true},
+						"group_id":                      {Type: schema.TypeString, Computed: true},
+						"staging_version":               {Type: schema.TypeInt, Computed: true},
+						"production_version":            {Type: schema.TypeInt, Computed: true},
+						"is_include_used_in_staging":    {Type: schema.TypeBool, Computed: true},
+						"is_include_used_in_production": {Type: schema.TypeBool, Computed: true},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataPropertyIncludeParentsRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	meta := akamai.Meta(m)
+	client := inst.IncludesClient(meta)
+	logger := meta.Log("PAPI", "dataPropertyIncludeParentsRead")
+	// create a context with logging for api calls
+	ctx = session.ContextWithOptions(
+		ctx,
+		session.WithContextLog(logger),
+	)
+	logger.Debug("Listing include parents")
+
+	request, err := getIncludeDataRequest(d, m)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	parents, err := client.ListIncludeParents(ctx, request)
+	if err != nil {
+		return diag.FromErr(fmt.Errorf("failed to get parents of include: %w", err))
+	}
+
+	attrs := map[string]interface{}{
+		"include_id":  request.IncludeID,
+		"contract_id": request.ContractID,
+		"group_id":    request.GroupID,
+		"parents":     flattenIncludeParents(parents),
+	}
+	if err := tools.SetAttrs(d, attrs); err != nil {
+		return diag.FromErr(fmt.Errorf("%w: %s", tools.ErrValueSet, err.Error()))
+	}
+
+	d.SetId(request.IncludeID)
+	return nil
+}
+
+func flattenIncludeParents(parents []includeParent) []interface{} {
+	result := make([]interface{}, 0, len(parents))
+	for _, parent := range parents {
+		var stagingVersion, productionVersion int
+		if parent.StagingVersion != nil {
+			stagingVersion = *parent.StagingVersion
+		}
+		if parent.ProductionVersion != nil {
+			productionVersion = *parent.ProductionVersion
+		}
+		result = append(result, map[string]interface{}{
+			"property_id":                   parent.PropertyID,
+			"property_name":                 parent.PropertyName,
+			"contract_id":                   parent.ContractID,
+			"group_id":                      parent.GroupID,
+			"staging_version":               stagingVersion,
+			"production_version":            productionVersion,
+			"is_include_used_in_staging":    parent.IsIncludeUsedInStagingVersion,
+			"is_include_used_in_production": parent.IsIncludeUsedInProductionVersion,
+		})
+	}
+	return result
+}
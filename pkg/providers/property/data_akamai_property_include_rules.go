@@ -0,0 +1,155 @@
+package property
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v2/pkg/papi"
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v2/pkg/session"
+	"github.com/akamai/terraform-provider-akamai/v2/pkg/akamai"
+	"github.com/akamai/terraform-provider-akamai/v2/pkg/tools"
+)
+
+func dataSourcePropertyIncludeRules() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataPropertyIncludeRulesRead,
+		Schema: map[string]*schema.Schema{
+			"include_id": {
+				Type:             schema.TypeString,
+				Required:         true,
+				StateFunc:        addPrefixToState("inc_"),
+				ValidateDiagFunc: tools.IsNotBlank,
+			},
+			"contract_id": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Computed:    true,
+				StateFunc:   addPrefixToState("ctr_"),
+				Description: "Contract ID of the include, the provider default_contract_id is used if not set",
+			},
+			"group_id": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Computed:    true,
+				StateFunc:   addPrefixToState("grp_"),
+				Description: "Group ID of the include, the provider default_group_id is used if not set",
+			},
+			"version": {
+				Type:        schema.TypeInt,
+				Optional:    true,
+				Computed:    true,
+				Description: "The include version to read, the latest version is read if not set",
+			},
+			"name": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"type": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"rule_format": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"rules": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "JSON Rule representation",
+			},
+			"rule_errors": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem:     papiError(),
+			},
+			"rule_warnings": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem:     papiError(),
+			},
+		},
+	}
+}
+
+func dataPropertyIncludeRulesRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	meta := akamai.Meta(m)
+	client := inst.IncludesClient(meta)
+	logger := meta.Log("PAPI", "dataPropertyIncludeRulesRead")
+	// create a context with logging for api calls
+	ctx = session.ContextWithOptions(
+		ctx,
+		session.WithContextLog(logger),
+	)
+
+	request, err := getIncludeDataRequest(d, m)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	version, err := tools.GetIntValue("version", d)
+	if err != nil && !errors.Is(err, tools.ErrNotFound) {
+		return diag.FromErr(err)
+	}
+	if version == 0 {
+		inc, err := client.GetInclude(ctx, request)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+		version = inc.LatestVersion
+	}
+
+	rules, err := client.GetIncludeRuleTree(ctx, includeVersionRequest{includeRequest: request, Version: version})
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	rulesJSON, err := json.MarshalIndent(papi.RulesUpdate{Rules: rules.Rules, Comments: rules.Comments}, "", "  ")
+	if err != nil {
+		logger.Debugf("Reading include rule tree resulted in invalid JSON: %s", err)
+		return diag.Errorf("invalid JSON result: %s", err)
+	}
+
+	attrs := map[string]interface{}{
+		"include_id":    request.IncludeID,
+		"contract_id":   request.ContractID,
+		"group_id":      request.GroupID,
+		"version":       rules.IncludeVersion,
+		"name":          rules.IncludeName,
+		"type":          rules.IncludeType,
+		"rule_format":   rules.RuleFormat,
+		"rules":         string(rulesJSON),
+		"rule_errors":   papiErrorsToList(rules.Errors),
+		"rule_warnings": papiErrorsToList(rules.Warnings),
+	}
+	if err := tools.SetAttrs(d, attrs); err != nil {
+		return diag.FromErr(fmt.Errorf("%w: %s", tools.ErrValueSet, err.Error()))
+	}
+
+	d.SetId(fmt.Sprintf("%s:%d", request.IncludeID, rules.IncludeVersion))
+	return nil
+}
+
+// getIncludeDataRequest returns the request identifying the include of a data source
+func getIncludeDataRequest(d *schema.ResourceData, m interface{}) (includeRequest, error) {
+	includeID, err := tools.GetStringValue("include_id", d)
+	if err != nil {
+		return includeRequest{}, err
+	}
+	contractID, err := akamai.GetContractID(d, m, "contract_id")
+	if err != nil {
+		return includeRequest{}, err
+	}
+	groupID, err := akamai.GetGroupID(d, m, "group_id")
+	if err != nil {
+		return includeRequest{}, err
+	}
+	return includeRequest{
+		IncludeID:  tools.AddPrefix(includeID, "inc_"),
+		ContractID: contractID,
+		GroupID:    groupID,
+	}, nil
+}
@@ -0,0 +1,100 @@
+package property
+
+import (
+	"testing"
+
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v2/pkg/papi"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/stretchr/testify/mock"
+	"github.com/tj/assert"
+
+	"github.com/akamai/terraform-provider-akamai/v2/pkg/tools"
+)
+
+func TestDataPropertyIncludeRules(t *testing.T) {
+	request := includeRequest{IncludeID: "inc_1", ContractID: "ctr_1", GroupID: "grp_1"}
+
+	client := &mockincludes{}
+	client.On("GetInclude", mock.Anything, request).Return(&include{IncludeID: "inc_1", LatestVersion: 2}, nil)
+	client.On("GetIncludeRuleTree", mock.Anything, includeVersionRequest{includeRequest: request, Version: 2}).Return(&includeRuleTree{
+		IncludeID:      "inc_1",
+		IncludeName:    "shared",
+		IncludeType:    IncludeTypeCommonSettings,
+		IncludeVersion: 2,
+		RuleFormat:     "v2021-05-05",
+		Rules:          papi.Rules{Name: "default"},
+		Errors:         []*papi.Error{{Type: "error", Title: "Missing origin", ErrorLocation: "#/rules"}},
+	}, nil)
+
+	useIncludesClient(client, func() {
+		resource.UnitTest(t, resource.TestCase{
+			Providers: testAccProviders,
+			Steps: []resource.TestStep{{
+				Config: loadFixtureString("testdata/TestDataPropertyInclude/property_include_rules.tf"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.akamai_property_include_rules.test", "id", "inc_1:2"),
+					resource.TestCheckResourceAttr("data.akamai_property_include_rules.test", "version", "2"),
+					resource.TestCheckResourceAttr("data.akamai_property_include_rules.test", "name", "shared"),
+					resource.TestCheckResourceAttr("data.akamai_property_include_rules.test", "type", "COMMON_SETTINGS"),
+					resource.TestCheckResourceAttr("data.akamai_property_include_rules.test", "rule_format", "v2021-05-05"),
+					resource.TestCheckResourceAttr("data.akamai_property_include_rules.test", "rule_errors.#", "1"),
+					resource.TestCheckResourceAttr("data.akamai_property_include_rules.test", "rule_errors.0.error_location", "#/rules"),
+					resource.TestCheckResourceAttrSet("data.akamai_property_include_rules.test", "rules"),
+				),
+			}},
+		})
+	})
+
+	client.AssertExpectations(t)
+}
+
+func TestDataPropertyIncludeParents(t *testing.T) {
+	request := includeRequest{IncludeID: "inc_1", ContractID: "ctr_1", GroupID: "grp_1"}
+
+	client := &mockincludes{}
+	client.On("ListIncludeParents", mock.Anything, request).Return([]includeParent{{
+		PropertyID:                    "prp_1",
+		PropertyName:                  "www.example.com",
+		ContractID:                    "ctr_1",
+		GroupID:                       "grp_1",
+		StagingVersion:                tools.IntPtr(3),
+		IsIncludeUsedInStagingVersion: true,
+	}}, nil)
+
+	useIncludesClient(client, func() {
+		resource.UnitTest(t, resource.TestCase{
+			Providers: testAccProviders,
+			Steps: []resource.TestStep{{
+				Config: loadFixtureString("testdata/TestDataPropertyInclude/property_include_parents.tf"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.akamai_property_include_parents.test", "id", "inc_1"),
+					resource.TestCheckResourceAttr("data.akamai_property_include_parents.test", "parents.#", "1"),
+					resource.TestCheckResourceAttr("data.akamai_property_include_parents.test", "parents.0.property_id", "prp_1"),
+					resource.TestCheckResourceAttr("data.akamai_property_include_parents.test", "parents.0.staging_version", "3"),
+					resource.TestCheckResourceAttr("data.akamai_property_include_parents.test", "parents.0.production_version", "0"),
+					resource.TestCheckResourceAttr("data.akamai_property_include_parents.test", "parents.0.is_include_used_in_staging", "true"),
+				),
+			}},
+		})
+	})
+
+	client.AssertExpectations(t)
+}
+
+func TestFlattenIncludeParents(t *testing.T) {
+	parents := flattenIncludeParents([]includeParent{{
+		PropertyID:                       "prp_1",
+		ProductionVersion:                tools.IntPtr(2),
+		IsIncludeUsedInProductionVersion: true,
+	}})
+	assert.Equal(t, []interface{}{map[string]interface{}{
+		"property_id":                   "prp_1",
+		"property_name":                 "",
+		"contract_id":                   "",
+		"group_id":                      "",
+		"staging_version":               0,
+		"production_version":            2,
+		"is_include_used_in_staging":    false,
+		"is_include_used_in_production": true,
+	}}, parents)
+}
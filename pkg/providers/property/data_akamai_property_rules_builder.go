@@ -0,0 +1,353 @@
+package property
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"regexp"
+
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v2/pkg/papi"
+	"github.com/akamai/terraform-provider-akamai/v2/pkg/akamai"
+	"github.com/akamai/terraform-provider-akamai/v2/pkg/tools"
+)
+
+func dataSourcePropertyRulesBuilder() *schema.Resource {
+	ruleBehavior := func(description string) *schema.Schema {
+		return &schema.Schema{
+			Type:        schema.TypeList,
+			Optional:    true,
+			Description: description,
+			Elem: &schema.Resource{
+				Schema: map[string]*schema.Schema{
+					"name": {
+						Type:             schema.TypeString,
+						Required:         true,
+						ValidateDiagFunc: validateRuleBehaviorName,
+					},
+					"options_json": {
+						Type:             schema.TypeString,
+						Optional:         true,
+						ValidateDiagFunc: validateRuleOptions,
+						Description:      "The options of the behavior or criterion as a JSON object, which keeps the types of the values",
+					},
+					"locked": {
+						Type:     schema.TypeBool,
+						Optional: true,
+					},
+					"uuid": {
+						Type:     schema.TypeString,
+						Optional: true,
+					},
+				},
+			},
+		}
+	}
+
+	return &schema.Resource{
+		ReadContext: dataPropertyRulesBuilderRead,
+		Schema: map[string]*schema.Schema{
+			"rule_format": {
+				Type:             schema.TypeString,
+				Required:         true,
+				ValidateDiagFunc: validateRuleFormat,
+				Description:      "The rule format the rules are built for, whose schema for the product_id validates the rules",
+			},
+			"product_id": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "The product whose schema for the rule format validates the names and options of the behaviors and criteria",
+			},
+			"rules": {
+				Type:     schema.TypeList,
+				Required: true,
+				MaxItems: 1,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"name": {
+							Type:             schema.TypeString,
+							Required:         true,
+							ValidateDiagFunc: tools.IsNotBlank,
+						},
+						"comments": {
+							Type:     schema.TypeString,
+							Optional: true,
+						},
+						"is_secure": {
+							Type:     schema.TypeBool,
+							Optional: true,
+						},
+						"criteria_must_satisfy": {
+							Type:             schema.TypeString,
+							Optional:         true,
+							ValidateDiagFunc: tools.ValidateStringInSlice([]string{string(papi.RuleCriteriaMustSatisfyAll), string(papi.RuleCriteriaMustSatisfyAny)}),
+						},
+						"uuid": {
+							Type:     schema.TypeString,
+							Optional: true,
+						},
+						"behavior":  ruleBehavior("The behaviors of the rule, in the order they are applied"),
+						"criterion": ruleBehavior("The criteria which select the requests the rule applies to"),
+						"variable": {
+							Type:        schema.TypeList,
+							Optional:    true,
+							Description: "The variables of the rule, which can only be declared in the default rule",
+							Elem: &schema.Resource{
+								Schema: map[string]*schema.Schema{
+									"name": {
+										Type:             schema.TypeString,
+										Required:         true,
+										ValidateDiagFunc: validateRuleVariableName,
+									},
+									"value": {
+										Type:     schema.TypeString,
+										Optional: true,
+									},
+									"description": {
+										Type:     schema.TypeString,
+										Optional: true,
+									},
+									"hidden": {
+										Type:     schema.TypeBool,
+										Optional: true,
+									},
+									"sensitive": {
+										Type:     schema.TypeBool,
+										Optional: true,
+									},
+								},
+							},
+						},
+						"children": {
+							Type:        schema.TypeList,
+							Optional:    true,
+							Description: "The child rules, as the JSON rendered by other akamai_property_rules_builder data sources",
+							Elem: &schema.Schema{
+								Type:             schema.TypeString,
+								ValidateDiagFunc: tools.ValidateJSON,
+							},
+						},
+					},
+				},
+			},
+			"json": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The rules as JSON, in the format of the rules of akamai_property",
+			},
+		},
+	}
+}
+
+var (
+	ruleBehaviorNameRegexp = regexp.MustCompile(`^[a-z][a-zA-Z0-9]*$`)
+	ruleOptionKeyRegexp    = regexp.MustCompile(`^[a-zA-Z][a-zA-Z0-9]*$`)
+	ruleVariableNameRegexp = regexp.MustCompile(`^PMUSER_[A-Z0-9_]+$`)
+)
+
+// validateRuleBehaviorName validates if a behavior or criterion name is in the camel case used by PAPI
+func validateRuleBehaviorName(v interface{}, _ cty.Path) diag.Diagnostics {
+	name := v.(string)
+	if !ruleBehaviorNameRegexp.MatchString(name) {
+		return diag.Errorf("%q is not a valid behavior or criterion name: names are in camel case, for example \"caching\" or \"originCharacteristics\"", name)
+	}
+	return nil
+}
+
+// validateRuleOptions validates if the options are a JSON object whose keys are in the camel case used by PAPI
+func validateRuleOptions(v interface{}, _ cty.Path) diag.Diagnostics {
+	options, err := parseRuleOptions(v.(string))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	var diags diag.Diagnostics
+	for key := range options {
+		if !ruleOptionKeyRegexp.MatchString(key) {
+			diags = append(diags, diag.Errorf("%q is not a valid option name: names are in camel case, for example \"mustRevalidate\"", key)...)
+		}
+	}
+	return diags
+}
+
+// validateRuleVariableName validates if a variable name has the PMUSER_ prefix required by PAPI
+func validateRuleVariableName(v interface{}, _ cty.Path) diag.Diagnostics {
+	name := v.(string)
+	if !ruleVariableNameRegexp.MatchString(name) {
+		return diag.Errorf("%q is not a valid variable name: names start with PMUSER_ and contain only uppercase letters, numbers and underscores", name)
+	}
+	return nil
+}
+
+func dataPropertyRulesBuilderRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	meta := akamai.Meta(m)
+	logger := meta.Log("PAPI", "dataPropertyRulesBuilderRead")
+
+	ruleFormat, err := tools.GetStringValue("rule_format", d)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	blocks, err := tools.GetListValue("rules", d)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	block, ok := blocks[0].(map[string]interface{})
+	if !ok {
+		return diag.Errorf("%s: %s, %q", tools.ErrInvalidType, "rules", "map[string]interface{}")
+	}
+
+	rules, err := buildRule(block)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	logger.Debugf("Built rule %q for rule format %s", rules.Name, ruleFormat)
+
+	var diags diag.Diagnostics
+	productID, err := tools.GetStringValue("product_id", d)
+	if err != nil && !errors.Is(err, tools.ErrNotFound) {
+		return diag.FromErr(err)
+	}
+	if productID != "" {
+		rs, err := getRulesSchema(ctx, meta, productID, ruleFormat)
+		if err != nil {
+			logger.Warnf("Rules are not validated, the schema of %s for rule format %s is not available: %s", productID, ruleFormat, err)
+			diags = append(diags, diag.Diagnostic{
+				Severity: diag.Warning,
+				Summary:  "rules are not validated",
+				Detail:   fmt.Sprintf("the schema of %s for rule format %s is not available: %s", productID, ruleFormat, err),
+			})
+		} else if diags := validateBuiltRules(rs, ruleFormat, rules); diags.HasError() {
+			return diags
+		}
+	}
+
+	rulesJSON, err := json.MarshalIndent(papi.RulesUpdate{Rules: rules}, "", "  ")
+	if err != nil {
+		return diag.Errorf("invalid JSON result: %s", err)
+	}
+	if err := d.Set("json", string(rulesJSON)); err != nil {
+		return diag.FromErr(fmt.Errorf("%w: %s", tools.ErrValueSet, err.Error()))
+	}
+
+	d.SetId(tools.GetSHAString(ruleFormat + string(rulesJSON)))
+	return diags
+}
+
+// validateBuiltRules validates the rule and its children against the schema, as the rules of a property,
+// with a diagnostic for each value which does not match it
+func validateBuiltRules(rs *rulesSchema, ruleFormat string, rules papi.Rules) diag.Diagnostics {
+	rulesJSON, err := json.Marshal(papi.RulesUpdate{Rules: rules})
+	if err != nil {
+		return diag.Errorf("invalid JSON result: %s", err)
+	}
+	var value interface{}
+	if err := json.Unmarshal(rulesJSON, &value); err != nil {
+		return diag.Errorf("invalid JSON result: %s", err)
+	}
+	return rulesSchemaDiags(ruleFormat, rs.validate(value))
+}
+
+// buildRule returns the rule of a rules block of the builder
+func buildRule(block map[string]interface{}) (papi.Rules, error) {
+	rule := papi.Rules{}
+	rule.Name, _ = block["name"].(string)
+	rule.Comments, _ = block["comments"].(string)
+	rule.UUID, _ = block["uuid"].(string)
+	rule.Options.IsSecure, _ = block["is_secure"].(bool)
+	criteriaMustSatisfy, _ := block["criteria_must_satisfy"].(string)
+	rule.CriteriaMustSatisfy = papi.RuleCriteriaMustSatisfy(criteriaMustSatisfy)
+
+	var err error
+	if rule.Behaviors, err = buildRuleBehaviors(block["behavior"]); err != nil {
+		return rule, fmt.Errorf("rule %q: behavior: %w", rule.Name, err)
+	}
+	if rule.Criteria, err = buildRuleBehaviors(block["criterion"]); err != nil {
+		return rule, fmt.Errorf("rule %q: criterion: %w", rule.Name, err)
+	}
+
+	variables, _ := block["variable"].([]interface{})
+	for _, v := range variables {
+		variable, ok := v.(map[string]interface{})
+		if !ok {
+			return rule, fmt.Errorf("%w: %s, %q", tools.ErrInvalidType, "variable", "map[string]interface{}")
+		}
+		ruleVariable := papi.RuleVariable{}
+		ruleVariable.Name, _ = variable["name"].(string)
+		ruleVariable.Value, _ = variable["value"].(string)
+		ruleVariable.Description, _ = variable["description"].(string)
+		ruleVariable.Hidden, _ = variable["hidden"].(bool)
+		ruleVariable.Sensitive, _ = variable["sensitive"].(bool)
+		rule.Variables = append(rule.Variables, ruleVariable)
+	}
+
+	children, _ := block["children"].([]interface{})
+	for i, c := range children {
+		childJSON, _ := c.(string)
+		child, err := parseChildRule(childJSON)
+		if err != nil {
+			return rule, fmt.Errorf("rule %q: child %d: %w", rule.Name, i, err)
+		}
+		rule.Children = append(rule.Children, child)
+	}
+	return rule, nil
+}
+
+func buildRuleBehaviors(value interface{}) ([]papi.RuleBehavior, error) {
+	blocks, _ := value.([]interface{})
+	var behaviors []papi.RuleBehavior
+	for _, b := range blocks {
+		block, ok := b.(map[string]interface{})
+		if !ok {
+			return nil, fmt.Errorf("%w: %q", tools.ErrInvalidType, "map[string]interface{}")
+		}
+		behavior := papi.RuleBehavior{Options: papi.RuleOptionsMap{}}
+		behavior.Name, _ = block["name"].(string)
+		behavior.Locked, _ = block["locked"].(bool)
+		behavior.UUID, _ = block["uuid"].(string)
+		optionsJSON, _ := block["options_json"].(string)
+		if optionsJSON != "" {
+			options, err := parseRuleOptions(optionsJSON)
+			if err != nil {
+				return nil, fmt.Errorf("%s: %w", behavior.Name, err)
+			}
+			behavior.Options = options
+		}
+		behaviors = append(behaviors, behavior)
+	}
+	return behaviors, nil
+}
+
+// parseRuleOptions returns the options of a behavior or criterion from their JSON object
+func parseRuleOptions(optionsJSON string) (papi.RuleOptionsMap, error) {
+	options := papi.RuleOptionsMap{}
+	if err := json.Unmarshal([]byte(optionsJSON), &options); err != nil {
+		return nil, fmt.Errorf("options are not a JSON object: %s", err)
+	}
+	if options == nil {
+		return nil, fmt.Errorf("options are not a JSON object: null")
+	}
+	return options, nil
+}
+
+// parseChildRule returns the rule from the JSON of a builder, or from the JSON of a single rule
+func parseChildRule(childJSON string) (papi.Rules, error) {
+	var rules papi.RulesUpdate
+	if err := json.Unmarshal([]byte(childJSON), &rules); err != nil {
+		return papi.Rules{}, fmt.Errorf("rules are not valid JSON: %s", err)
+	}
+	if rules.Rules.Name != "" {
+		return rules.Rules, nil
+	}
+
+	var rule papi.Rules
+	if err := json.Unmarshal([]byte(childJSON), &rule); err != nil {
+		return papi.Rules{}, fmt.Errorf("rules are not valid JSON: %s", err)
+	}
+	if rule.Name == "" {
+		return papi.Rules{}, fmt.Errorf("rule has no name")
+	}
+	return rule, nil
+}
@@ -0,0 +1,197 @@
+package property
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v2/pkg/papi"
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/stretchr/testify/require"
+	"github.com/tj/assert"
+)
+
+func TestDataPropertyRulesBuilder(t *testing.T) {
+	resource.UnitTest(t, resource.TestCase{
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{{
+			Config: loadFixtureString("testdata/TestDataPropertyRulesBuilder/rules_builder.tf"),
+			Check: resource.ComposeAggregateTestCheckFunc(
+				func(s *terraform.State) error {
+					rs := s.RootModule().Resources["data.akamai_property_rules_builder.default"]
+					assert.JSONEq(t, loadFixtureString("testdata/TestDataPropertyRulesBuilder/rules.json"), rs.Primary.Attributes["json"])
+					return nil
+				},
+			),
+		}},
+	})
+}
+
+func TestBuildRule(t *testing.T) {
+	child := map[string]interface{}{
+		"name":                  "static",
+		"criteria_must_satisfy": "any",
+		"criterion": []interface{}{map[string]interface{}{
+			"name":         "fileExtension",
+			"options_json": `{"matchOperator": "IS_ONE_OF", "values": ["css", "js"]}`,
+		}},
+		"behavior": []interface{}{map[string]interface{}{
+			"name":         "caching",
+			"options_json": `{"behavior": "MAX_AGE", "mustRevalidate": false, "ttl": "1d"}`,
+		}},
+	}
+	childRule, err := buildRule(child)
+	require.NoError(t, err)
+	childJSON, err := json.Marshal(papi.RulesUpdate{Rules: childRule})
+	require.NoError(t, err)
+
+	rule, err := buildRule(map[string]interface{}{
+		"name":      "default",
+		"is_secure": true,
+		"behavior": []interface{}{map[string]interface{}{
+			"name":         "origin",
+			"options_json": `{"hostname": "origin.example.com", "httpPort": 80, "originType": "CUSTOMER"}`,
+		}},
+		"variable": []interface{}{map[string]interface{}{
+			"name":  "PMUSER_ORIGIN",
+			"value": "origin.example.com",
+		}},
+		"children": []interface{}{string(childJSON)},
+	})
+	require.NoError(t, err)
+
+	rulesJSON, err := json.Marshal(papi.RulesUpdate{Rules: rule})
+	require.NoError(t, err)
+	assert.JSONEq(t, loadFixtureString("testdata/TestDataPropertyRulesBuilder/rules.json"), string(rulesJSON))
+
+	t.Run("invalid options", func(t *testing.T) {
+		_, err := buildRule(map[string]interface{}{
+			"name":     "default",
+			"behavior": []interface{}{map[string]interface{}{"name": "caching", "options_json": `["MAX_AGE"]`}},
+		})
+		assert.Error(t, err)
+	})
+
+	t.Run("invalid child", func(t *testing.T) {
+		_, err := buildRule(map[string]interface{}{
+			"name":     "default",
+			"children": []interface{}{`{"rules": {}}`},
+		})
+		assert.Error(t, err)
+	})
+}
+
+func TestParseRuleOptions(t *testing.T) {
+	tests := map[string]struct {
+		given     string
+		expected  papi.RuleOptionsMap
+		withError bool
+	}{
+		"typed values": {
+			given:    `{"behavior": "MAX_AGE", "httpPort": 80, "mustRevalidate": false, "id": "80", "values": ["css"]}`,
+			expected: papi.RuleOptionsMap{"behavior": "MAX_AGE", "httpPort": float64(80), "mustRevalidate": false, "id": "80", "values": []interface{}{"css"}},
+		},
+		"empty object": {
+			given:    `{}`,
+			expected: papi.RuleOptionsMap{},
+		},
+		"null": {
+			given:     `null`,
+			withError: true,
+		},
+		"not an object": {
+			given:     `["css"]`,
+			withError: true,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			options, err := parseRuleOptions(test.given)
+			if test.withError {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, test.expected, options)
+		})
+	}
+}
+
+func TestValidateBuiltRules(t *testing.T) {
+	rs := loadTestRulesSchema(t)
+
+	t.Run("valid rules", func(t *testing.T) {
+		diags := validateBuiltRules(rs, "v2021-05-05", papi.Rules{Name: "default", Behaviors: []papi.RuleBehavior{
+			{Name: "origin", Options: papi.RuleOptionsMap{"hostname": "origin.example.com", "httpPort": float64(80)}},
+		}})
+		assert.False(t, diags.HasError())
+	})
+
+	t.Run("unknown behavior and option", func(t *testing.T) {
+		diags := validateBuiltRules(rs, "v2021-05-05", papi.Rules{Name: "default", Behaviors: []papi.RuleBehavior{
+			{Name: "caching", Options: papi.RuleOptionsMap{"behavior": "MAX_AGE", "maxAge": "1d"}},
+		}})
+		require.Len(t, diags, 1)
+		assert.Equal(t, "rules do not match the rule format schema v2021-05-05 at #/rules/behaviors/0/options/maxAge", diags[0].Summary)
+		assert.Equal(t, `property "maxAge" is not allowed`, diags[0].Detail)
+	})
+}
+
+func TestParseChildRule(t *testing.T) {
+	tests := map[string]struct {
+		given     string
+		expected  string
+		withError bool
+	}{
+		"builder JSON": {
+			given:    `{"rules": {"name": "static"}}`,
+			expected: "static",
+		},
+		"rule JSON": {
+			given:    `{"name": "static", "behaviors": []}`,
+			expected: "static",
+		},
+		"rule without name": {
+			given:     `{"behaviors": []}`,
+			withError: true,
+		},
+		"invalid JSON": {
+			given:     `{"rules":`,
+			withError: true,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			rule, err := parseChildRule(test.given)
+			if test.withError {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, test.expected, rule.Name)
+		})
+	}
+}
+
+func TestRulesBuilderValidation(t *testing.T) {
+	t.Run("behavior name", func(t *testing.T) {
+		assert.False(t, validateRuleBehaviorName("originCharacteristics", cty.Path{}).HasError())
+		assert.True(t, validateRuleBehaviorName("origin_characteristics", cty.Path{}).HasError())
+		assert.True(t, validateRuleBehaviorName("Caching", cty.Path{}).HasError())
+	})
+
+	t.Run("option keys", func(t *testing.T) {
+		assert.False(t, validateRuleOptions(`{"mustRevalidate": true}`, cty.Path{}).HasError())
+		assert.True(t, validateRuleOptions(`{"must_revalidate": true}`, cty.Path{}).HasError())
+		assert.True(t, validateRuleOptions(`"MAX_AGE"`, cty.Path{}).HasError())
+	})
+
+	t.Run("variable name", func(t *testing.T) {
+		assert.False(t, validateRuleVariableName("PMUSER_ORIGIN_2", cty.Path{}).HasError())
+		assert.True(t, validateRuleVariableName("ORIGIN", cty.Path{}).HasError())
+		assert.True(t, validateRuleVariableName("PMUSER_origin", cty.Path{}).HasError())
+	})
+}
@@ -0,0 +1,136 @@
+package property
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/apex/log"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v2/pkg/papi"
+	"github.com/akamai/terraform-provider-akamai/v2/pkg/akamai"
+	"github.com/akamai/terraform-provider-akamai/v2/pkg/tools"
+)
+
+func dataSourcePropertyRulesUpgrade() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataPropertyRulesUpgradeRead,
+		Schema: map[string]*schema.Schema{
+			"property_id": {
+				Type:             schema.TypeString,
+				Required:         true,
+				StateFunc:        addPrefixToState("prp_"),
+				ValidateDiagFunc: tools.IsNotBlank,
+			},
+			"contract_id": {
+				Type:      schema.TypeString,
+				Optional:  true,
+				Computed:  true,
+				StateFunc: addPrefixToState("ctr_"),
+			},
+			"group_id": {
+				Type:      schema.TypeString,
+				Optional:  true,
+				Computed:  true,
+				StateFunc: addPrefixToState("grp_"),
+			},
+			"version": {
+				Type:        schema.TypeInt,
+				Optional:    true,
+				Computed:    true,
+				Description: "The property version to convert, the latest version is converted if not set",
+			},
+			"rule_format": {
+				Type:             schema.TypeString,
+				Required:         true,
+				ValidateDiagFunc: validateRuleFormat,
+				Description:      "The rule format to convert the rules to",
+			},
+			"rules": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "JSON Rule representation converted to the rule format",
+			},
+			"rule_errors": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem:     papiError(),
+			},
+			"rule_warnings": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem:     papiError(),
+			},
+		},
+	}
+}
+
+func dataPropertyRulesUpgradeRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	meta := akamai.Meta(m)
+	client := inst.Client(meta)
+	ctx = log.NewContext(ctx, meta.Log("PAPI", "dataPropertyRulesUpgradeRead"))
+
+	propertyID, err := tools.GetStringValue("property_id", d)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	ruleFormat, err := tools.GetStringValue("rule_format", d)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	// since contract_id and group_id are optional, we should not return an error.
+	contractID, _ := tools.GetStringValue("contract_id", d)
+	groupID, _ := tools.GetStringValue("group_id", d)
+
+	Property := papi.Property{
+		PropertyID: tools.AddPrefix(propertyID, "prp_"),
+	}
+	if contractID != "" {
+		Property.ContractID = tools.AddPrefix(contractID, "ctr_")
+	}
+	if groupID != "" {
+		Property.GroupID = tools.AddPrefix(groupID, "grp_")
+	}
+
+	version, err := tools.GetIntValue("version", d)
+	if err != nil && !errors.Is(err, tools.ErrNotFound) {
+		return diag.FromErr(err)
+	}
+	if version == 0 {
+		latest, err := fetchLatestProperty(ctx, client, Property.PropertyID, Property.GroupID, Property.ContractID)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+		Property.ContractID = latest.ContractID
+		Property.GroupID = latest.GroupID
+		version = latest.LatestVersion
+	}
+
+	Rules, _, Errors, Warnings, err := fetchConvertedPropertyVersionRules(ctx, client, Property, version, ruleFormat)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	rulesJSON, err := json.MarshalIndent(Rules, "", "  ")
+	if err != nil {
+		return diag.Errorf("invalid JSON result: %s", err)
+	}
+
+	attrs := map[string]interface{}{
+		"property_id":   Property.PropertyID,
+		"contract_id":   Property.ContractID,
+		"group_id":      Property.GroupID,
+		"version":       version,
+		"rules":         string(rulesJSON),
+		"rule_errors":   papiErrorsToList(Errors),
+		"rule_warnings": papiErrorsToList(Warnings),
+	}
+	if err := tools.SetAttrs(d, attrs); err != nil {
+		return diag.FromErr(fmt.Errorf("%w: %s", tools.ErrValueSet, err.Error()))
+	}
+
+	d.SetId(fmt.Sprintf("%s:%d:%s", Property.PropertyID, version, ruleFormat))
+	return papiWarningsToDiags(fmt.Sprintf("rule format conversion to %s", ruleFormat), Warnings)
+}
@@ -0,0 +1,63 @@
+package property
+
+import (
+	"testing"
+
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v2/pkg/papi"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestDSPropertyRulesUpgrade(t *testing.T) {
+	client := &mockpapi{}
+	client.On("GetProperty", mock.Anything, papi.GetPropertyRequest{PropertyID: "prp_2"}).
+		Return(&papi.GetPropertyResponse{Property: &papi.Property{
+			PropertyID:    "prp_2",
+			ContractID:    "ctr_2",
+			GroupID:       "grp_2",
+			LatestVersion: 3,
+		}}, nil)
+	client.On("GetRuleTree", mock.Anything, papi.GetRuleTreeRequest{
+		PropertyID:      "prp_2",
+		ContractID:      "ctr_2",
+		GroupID:         "grp_2",
+		PropertyVersion: 3,
+		ValidateRules:   true,
+		ValidateMode:    papi.RuleValidateModeFull,
+		RuleFormat:      "v2021-05-05",
+	}).Return(&papi.GetRuleTreeResponse{
+		RuleFormat: "v2021-05-05",
+		Rules: papi.Rules{
+			Name:      "default",
+			Behaviors: []papi.RuleBehavior{{Name: "origin", Options: papi.RuleOptionsMap{"hostname": "origin.example.com"}}},
+		},
+		Response: papi.Response{
+			Warnings: []*papi.Error{{
+				Type:          "https://problems.luna.akamaiapis.net/papi/v0/validation/behavior_option_removed",
+				Title:         "Behavior option removed",
+				Detail:        "The `mobileOriginAccess` option was removed in the new rule format.",
+				ErrorLocation: "#/rules/behaviors/0/options",
+			}},
+		},
+	}, nil)
+
+	useClient(client, func() {
+		resource.UnitTest(t, resource.TestCase{
+			Providers: testAccProviders,
+			Steps: []resource.TestStep{{
+				Config: loadFixtureString("testdata/TestDSPropertyRulesUpgrade/rules_upgrade.tf"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.akamai_property_rules_upgrade.upgrade", "id", "prp_2:3:v2021-05-05"),
+					resource.TestCheckResourceAttr("data.akamai_property_rules_upgrade.upgrade", "contract_id", "ctr_2"),
+					resource.TestCheckResourceAttr("data.akamai_property_rules_upgrade.upgrade", "group_id", "grp_2"),
+					resource.TestCheckResourceAttr("data.akamai_property_rules_upgrade.upgrade", "version", "3"),
+					resource.TestCheckResourceAttrSet("data.akamai_property_rules_upgrade.upgrade", "rules"),
+					resource.TestCheckResourceAttr("data.akamai_property_rules_upgrade.upgrade", "rule_errors.#", "0"),
+					resource.TestCheckResourceAttr("data.akamai_property_rules_upgrade.upgrade", "rule_warnings.#", "1"),
+					resource.TestCheckResourceAttr("data.akamai_property_rules_upgrade.upgrade", "rule_warnings.0.title", "Behavior option removed"),
+				),
+			}},
+		})
+	})
+	client.AssertExpectations(t)
+}
@@ -0,0 +1,635 @@
+package property
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/apex/log"
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v2/pkg/papi"
+	"github.com/akamai/terraform-provider-akamai/v2/pkg/akamai"
+	"github.com/akamai/terraform-provider-akamai/v2/pkg/tools"
+)
+
+const (
+	changeAdded     = "added"
+	changeRemoved   = "removed"
+	changeModified  = "modified"
+	changeReordered = "reordered"
+)
+
+type (
+	// ruleChange is a change of a rule, or of a behavior or criterion of a rule, between two rule trees
+	ruleChange struct {
+		// Path is the path of the rule, as rule names separated by "/" starting with the default rule
+		Path     string
+		Type     string
+		Name     string
+		Action   string
+		OldValue string
+		NewValue string
+	}
+
+	// variableChange is a change of a property variable between two rule trees
+	variableChange struct {
+		Name     string
+		Action   string
+		OldValue string
+		NewValue string
+	}
+
+	// hostnameChange is a change of a property hostname between two property versions
+	hostnameChange struct {
+		CnameFrom               string
+		Action                  string
+		OldCnameTo              string
+		NewCnameTo              string
+		OldCertProvisioningType string
+		NewCertProvisioningType string
+	}
+)
+
+func dataSourcePropertyVersionDiff() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataPropertyVersionDiffRead,
+		Schema: map[string]*schema.Schema{
+			"property_id": {
+				Type:             schema.TypeString,
+				Required:         true,
+				StateFunc:        addPrefixToState("prp_"),
+				ValidateDiagFunc: tools.IsNotBlank,
+			},
+			"contract_id": {
+				Type:      schema.TypeString,
+				Optional:  true,
+				Computed:  true,
+				StateFunc: addPrefixToState("ctr_"),
+			},
+			"group_id": {
+				Type:      schema.TypeString,
+				Optional:  true,
+				Computed:  true,
+				StateFunc: addPrefixToState("grp_"),
+			},
+			"old_version": {
+				Type:             schema.TypeString,
+				Required:         true,
+				ValidateDiagFunc: validateDiffVersion,
+				Description:      "The version to compare from, either a version number or one of latest, staging and production",
+			},
+			"new_version": {
+				Type:             schema.TypeString,
+				Required:         true,
+				ValidateDiagFunc: validateDiffVersion,
+				Description:      "The version to compare to, either a version number or one of latest, staging and production",
+			},
+			"old_version_number": {
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+			"new_version_number": {
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+			"has_changes": {
+				Type:     schema.TypeBool,
+				Computed: true,
+			},
+			"rule_changes": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"path":      {Type: schema.TypeString, Computed: true},
+						"type":      {Type: schema.TypeString, Computed: true},
+						"name":      {Type: schema.TypeString, Computed: true},
+						"action":    {Type: schema.TypeString, Computed: true},
+						"old_value": {Type: schema.TypeString, Computed: true},
+						"new_value": {Type: schema.TypeString, Computed: true},
+					},
+				},
+			},
+			"variable_changes": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"name":      {Type: schema.TypeString, Computed: true},
+						"action":    {Type: schema.TypeString, Computed: true},
+						"old_value": {Type: schema.TypeString, Computed: true},
+						"new_value": {Type: schema.TypeString, Computed: true},
+					},
+				},
+			},
+			"hostname_changes": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"cname_from":                 {Type: schema.TypeString, Computed: true},
+						"action":                     {Type: schema.TypeString, Computed: true},
+						"old_cname_to":               {Type: schema.TypeString, Computed: true},
+						"new_cname_to":               {Type: schema.TypeString, Computed: true},
+						"old_cert_provisioning_type": {Type: schema.TypeString, Computed: true},
+						"new_cert_provisioning_type": {Type: schema.TypeString, Computed: true},
+					},
+				},
+			},
+		},
+	}
+}
+
+// validateDiffVersion validates if the version is a version number or one of "latest", "staging" and "production"
+func validateDiffVersion(v interface{}, _ cty.Path) diag.Diagnostics {
+	version := v.(string)
+	switch strings.ToLower(version) {
+	case "latest", "staging", "production":
+		return nil
+	}
+	if _, err := parseVersionNumber(version); err != nil {
+		return diag.Errorf(`version must be a version number or one of "latest", "staging" and "production", got %q`, version)
+	}
+	return nil
+}
+
+func dataPropertyVersionDiffRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	meta := akamai.Meta(m)
+	client := inst.Client(meta)
+	ctx = log.NewContext(ctx, meta.Log("PAPI", "dataPropertyVersionDiffRead"))
+
+	propertyID, err := tools.GetStringValue("property_id", d)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	oldVersion, err := tools.GetStringValue("old_version", d)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	newVersion, err := tools.GetStringValue("new_version", d)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	// since contract_id and group_id are optional, we should not return an error.
+	contractID, _ := tools.GetStringValue("contract_id", d)
+	groupID, _ := tools.GetStringValue("group_id", d)
+	if contractID != "" {
+		contractID = tools.AddPrefix(contractID, "ctr_")
+	}
+	if groupID != "" {
+		groupID = tools.AddPrefix(groupID, "grp_")
+	}
+
+	Property, err := fetchLatestProperty(ctx, client, tools.AddPrefix(propertyID, "prp_"), groupID, contractID)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	oldVersionNumber, err := resolvePropertyVersion(Property, oldVersion)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	newVersionNumber, err := resolvePropertyVersion(Property, newVersion)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	newRules, newFormat, _, _, err := fetchPropertyVersionRules(ctx, client, *Property, newVersionNumber)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	oldRules, oldFormat, _, _, err := fetchPropertyVersionRules(ctx, client, *Property, oldVersionNumber)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	if oldFormat != newFormat {
+		// the old rules are compared converted to the new rule format, so that renamed behaviors and options are not reported
+		oldRules, _, _, _, err = fetchConvertedPropertyVersionRules(ctx, client, *Property, oldVersionNumber, newFormat)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+	}
+	oldHostnames, err := fetchPropertyVersionHostnames(ctx, client, *Property, oldVersionNumber)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	newHostnames, err := fetchPropertyVersionHostnames(ctx, client, *Property, newVersionNumber)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	ruleChanges, err := diffRuleTrees(oldRules, newRules)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	variableChanges, err := diffVariables(oldRules.Rules.Variables, newRules.Rules.Variables)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	hostnameChanges := diffHostnames(oldHostnames, newHostnames)
+
+	attrs := map[string]interface{}{
+		"property_id":        Property.PropertyID,
+		"contract_id":        Property.ContractID,
+		"group_id":           Property.GroupID,
+		"old_version_number": oldVersionNumber,
+		"new_version_number": newVersionNumber,
+		"has_changes":        len(ruleChanges)+len(variableChanges)+len(hostnameChanges) > 0,
+		"rule_changes":       ruleChangesToList(ruleChanges),
+		"variable_changes":   variableChangesToList(variableChanges),
+		"hostname_changes":   hostnameChangesToList(hostnameChanges),
+	}
+	if err := tools.SetAttrs(d, attrs); err != nil {
+		return diag.FromErr(fmt.Errorf("%w: %s", tools.ErrValueSet, err.Error()))
+	}
+
+	d.SetId(fmt.Sprintf("%s:%d:%d", Property.PropertyID, oldVersionNumber, newVersionNumber))
+	return nil
+}
+
+// resolvePropertyVersion returns the number of the given version of the property, which is either a version number or
+// one of "latest", "staging" and "production"
+func resolvePropertyVersion(Property *papi.Property, version string) (int, error) {
+	var network string
+	var active *int
+	switch strings.ToLower(version) {
+	case "latest":
+		return Property.LatestVersion, nil
+	case "staging":
+		network, active = "staging", Property.StagingVersion
+	case "production":
+		network, active = "production", Property.ProductionVersion
+	default:
+		return parseVersionNumber(version)
+	}
+	if active == nil || *active == 0 {
+		return 0, fmt.Errorf("%w: no version of property %s is active on %s", ErrPropertyVersionNotFound, Property.PropertyID, network)
+	}
+	return *active, nil
+}
+
+// diffRuleTrees returns the changes between the old and new rule trees, the rule variables are left out
+func diffRuleTrees(old, new papi.RulesUpdate) ([]ruleChange, error) {
+	var changes []ruleChange
+	if old.Comments != new.Comments {
+		changes = append(changes, ruleChange{Type: "rule_tree", Name: "comments", Action: changeModified, OldValue: old.Comments, NewValue: new.Comments})
+	}
+	ruleChanges, err := diffRules(old.Rules.Name, old.Rules, new.Rules)
+	if err != nil {
+		return nil, err
+	}
+	return append(changes, ruleChanges...), nil
+}
+
+// diffRules returns the changes between the old and new rule at the given path and between their child rules
+// child rules are matched by name, and behaviors and criteria are compared discarding their order as in compareRules
+func diffRules(path string, old, new papi.Rules) ([]ruleChange, error) {
+	var changes []ruleChange
+
+	oldAttrs, newAttrs := ruleAttributes(old), ruleAttributes(new)
+	if !reflect.DeepEqual(oldAttrs, newAttrs) {
+		change, err := newRuleChange(path, "rule", new.Name, changeModified, oldAttrs, newAttrs)
+		if err != nil {
+			return nil, err
+		}
+		changes = append(changes, change)
+	}
+
+	for _, items := range []struct {
+		typ      string
+		old, new []papi.RuleBehavior
+	}{
+		{typ: "behavior", old: old.Behaviors, new: new.Behaviors},
+		{typ: "criterion", old: old.Criteria, new: new.Criteria},
+	} {
+		behaviorChanges, err := diffBehaviors(path, items.typ, items.old, items.new)
+		if err != nil {
+			return nil, err
+		}
+		changes = append(changes, behaviorChanges...)
+	}
+
+	oldKeys, newKeys := childRuleKeys(old.Children), childRuleKeys(new.Children)
+	oldChildren := make(map[string]papi.Rules, len(old.Children))
+	for i, child := range old.Children {
+		oldChildren[oldKeys[i]] = child
+	}
+	newChildren := make(map[string]papi.Rules, len(new.Children))
+	for i, child := range new.Children {
+		newChildren[newKeys[i]] = child
+	}
+
+	var oldOrder, newOrder []string
+	for _, key := range oldKeys {
+		if _, ok := newChildren[key]; ok {
+			oldOrder = append(oldOrder, key)
+		}
+	}
+	for _, key := range newKeys {
+		if _, ok := oldChildren[key]; ok {
+			newOrder = append(newOrder, key)
+		}
+	}
+	if !reflect.DeepEqual(oldOrder, newOrder) {
+		change, err := newRuleChange(path, "rule", new.Name, changeReordered, oldOrder, newOrder)
+		if err != nil {
+			return nil, err
+		}
+		changes = append(changes, change)
+	}
+
+	for i, child := range old.Children {
+		childPath := path + "/" + child.Name
+		newChild, ok := newChildren[oldKeys[i]]
+		if !ok {
+			change, err := newRuleChange(childPath, "rule", child.Name, changeRemoved, child, nil)
+			if err != nil {
+				return nil, err
+			}
+			changes = append(changes, change)
+			continue
+		}
+		childChanges, err := diffRules(childPath, child, newChild)
+		if err != nil {
+			return nil, err
+		}
+		changes = append(changes, childChanges...)
+	}
+	for i, child := range new.Children {
+		if _, ok := oldChildren[newKeys[i]]; ok {
+			continue
+		}
+		change, err := newRuleChange(path+"/"+child.Name, "rule", child.Name, changeAdded, nil, child)
+		if err != nil {
+			return nil, err
+		}
+		changes = append(changes, change)
+	}
+
+	return changes, nil
+}
+
+// ruleAttributes returns the rule without its behaviors, criteria, variables and child rules
+func ruleAttributes(rule papi.Rules) papi.Rules {
+	rule.Behaviors, rule.Criteria, rule.Variables, rule.Children = nil, nil, nil, nil
+	// the API does not return the default value of criteriaMustSatisfy, see compareRules
+	if rule.CriteriaMustSatisfy == papi.RuleCriteriaMustSatisfyAll {
+		rule.CriteriaMustSatisfy = ""
+	}
+	return rule
+}
+
+// childRuleKeys returns the keys matching child rules by name, child rules with the same name are told apart by their order
+func childRuleKeys(children []papi.Rules) []string {
+	keys := make([]string, 0, len(children))
+	seen := make(map[string]int, len(children))
+	for _, child := range children {
+		seen[child.Name]++
+		key := child.Name
+		if n := seen[child.Name]; n > 1 {
+			key = fmt.Sprintf("%s#%d", child.Name, n)
+		}
+		keys = append(keys, key)
+	}
+	return keys
+}
+
+// diffBehaviors returns the changes between the old and new behaviors or criteria of the rule at the given path
+// the behaviors are compared by name after orderBehaviors, unchanged behaviors with the same name are matched first
+func diffBehaviors(path, typ string, old, new []papi.RuleBehavior) ([]ruleChange, error) {
+	// orderBehaviors sorts in place, so the behaviors are ordered on copies
+	old = orderBehaviors(append([]papi.RuleBehavior(nil), old...))
+	new = orderBehaviors(append([]papi.RuleBehavior(nil), new...))
+
+	byName := func(behaviors []papi.RuleBehavior) (map[string][]papi.RuleBehavior, []string) {
+		grouped := make(map[string][]papi.RuleBehavior)
+		var names []string
+		for _, b := range behaviors {
+			if _, ok := grouped[b.Name]; !ok {
+				names = append(names, b.Name)
+			}
+			grouped[b.Name] = append(grouped[b.Name], b)
+		}
+		return grouped, names
+	}
+	oldByName, oldNames := byName(old)
+	newByName, newNames := byName(new)
+	names := oldNames
+	for _, name := range newNames {
+		if _, ok := oldByName[name]; !ok {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+
+	var changes []ruleChange
+	for _, name := range names {
+		olds, news := unmatchedBehaviors(oldByName[name], newByName[name])
+		for i := 0; i < len(olds) || i < len(news); i++ {
+			var change ruleChange
+			var err error
+			switch {
+			case i >= len(news):
+				change, err = newRuleChange(path, typ, name, changeRemoved, olds[i], nil)
+			case i >= len(olds):
+				change, err = newRuleChange(path, typ, name, changeAdded, nil, news[i])
+			default:
+				change, err = newRuleChange(path, typ, name, changeModified, olds[i], news[i])
+			}
+			if err != nil {
+				return nil, err
+			}
+			changes = append(changes, change)
+		}
+	}
+	return changes, nil
+}
+
+// unmatchedBehaviors returns the old and new behaviors left after removing the ones equal in both
+func unmatchedBehaviors(old, new []papi.RuleBehavior) ([]papi.RuleBehavior, []papi.RuleBehavior) {
+	matched := make([]bool, len(new))
+	var olds []papi.RuleBehavior
+	for _, o := range old {
+		found := false
+		for i, n := range new {
+			if !matched[i] && reflect.DeepEqual(o, n) {
+				matched[i], found = true, true
+				break
+			}
+		}
+		if !found {
+			olds = append(olds, o)
+		}
+	}
+	var news []papi.RuleBehavior
+	for i, n := range new {
+		if !matched[i] {
+			news = append(news, n)
+		}
+	}
+	return olds, news
+}
+
+// diffVariables returns the changes between the old and new property variables
+func diffVariables(old, new []papi.RuleVariable) ([]variableChange, error) {
+	oldByName := make(map[string]papi.RuleVariable, len(old))
+	for _, v := range old {
+		oldByName[v.Name] = v
+	}
+	newByName := make(map[string]papi.RuleVariable, len(new))
+	for _, v := range new {
+		newByName[v.Name] = v
+	}
+	// orderVariables sorts in place, so the variables are ordered on copies
+	variables := orderVariables(append(append([]papi.RuleVariable(nil), old...), new...))
+
+	var changes []variableChange
+	for i, v := range variables {
+		if i > 0 && variables[i-1].Name == v.Name {
+			continue
+		}
+		o, inOld := oldByName[v.Name]
+		n, inNew := newByName[v.Name]
+		var action string
+		var oldValue, newValue interface{}
+		switch {
+		case !inNew:
+			action, oldValue = changeRemoved, o
+		case !inOld:
+			action, newValue = changeAdded, n
+		case !reflect.DeepEqual(o, n):
+			action, oldValue, newValue = changeModified, o, n
+		default:
+			continue
+		}
+		oldJSON, err := changeValueJSON(oldValue)
+		if err != nil {
+			return nil, err
+		}
+		newJSON, err := changeValueJSON(newValue)
+		if err != nil {
+			return nil, err
+		}
+		changes = append(changes, variableChange{Name: v.Name, Action: action, OldValue: oldJSON, NewValue: newJSON})
+	}
+	return changes, nil
+}
+
+// diffHostnames returns the changes between the old and new property hostnames, matched by cname_from
+func diffHostnames(old, new []papi.Hostname) []hostnameChange {
+	oldByName := make(map[string]papi.Hostname, len(old))
+	for _, h := range old {
+		oldByName[h.CnameFrom] = h
+	}
+	newByName := make(map[string]papi.Hostname, len(new))
+	for _, h := range new {
+		newByName[h.CnameFrom] = h
+	}
+	names := make([]string, 0, len(oldByName)+len(newByName))
+	for name := range oldByName {
+		names = append(names, name)
+	}
+	for name := range newByName {
+		if _, ok := oldByName[name]; !ok {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+
+	var changes []hostnameChange
+	for _, name := range names {
+		o, inOld := oldByName[name]
+		n, inNew := newByName[name]
+		change := hostnameChange{
+			CnameFrom:               name,
+			OldCnameTo:              o.CnameTo,
+			NewCnameTo:              n.CnameTo,
+			OldCertProvisioningType: o.CertProvisioningType,
+			NewCertProvisioningType: n.CertProvisioningType,
+		}
+		switch {
+		case !inNew:
+			change.Action = changeRemoved
+		case !inOld:
+			change.Action = changeAdded
+		case o.CnameTo != n.CnameTo || o.CnameType != n.CnameType || o.CertProvisioningType != n.CertProvisioningType:
+			change.Action = changeModified
+		default:
+			continue
+		}
+		changes = append(changes, change)
+	}
+	return changes
+}
+
+func newRuleChange(path, typ, name, action string, oldValue, newValue interface{}) (ruleChange, error) {
+	oldJSON, err := changeValueJSON(oldValue)
+	if err != nil {
+		return ruleChange{}, err
+	}
+	newJSON, err := changeValueJSON(newValue)
+	if err != nil {
+		return ruleChange{}, err
+	}
+	return ruleChange{Path: path, Type: typ, Name: name, Action: action, OldValue: oldJSON, NewValue: newJSON}, nil
+}
+
+// changeValueJSON returns the value of a change as JSON, or an empty string for no value
+func changeValueJSON(value interface{}) (string, error) {
+	if value == nil {
+		return "", nil
+	}
+	b, err := json.Marshal(value)
+	if err != nil {
+		return "", fmt.Errorf("cannot encode changed value as JSON: %s", err)
+	}
+	return string(b), nil
+}
+
+func ruleChangesToList(changes []ruleChange) []interface{} {
+	res := make([]interface{}, 0, len(changes))
+	for _, c := range changes {
+		res = append(res, map[string]interface{}{
+			"path":      c.Path,
+			"type":      c.Type,
+			"name":      c.Name,
+			"action":    c.Action,
+			"old_value": c.OldValue,
+			"new_value": c.NewValue,
+		})
+	}
+	return res
+}
+
+func variableChangesToList(changes []variableChange) []interface{} {
+	res := make([]interface{}, 0, len(changes))
+	for _, c := range changes {
+		res = append(res, map[string]interface{}{
+			"name":      c.Name,
+			"action":    c.Action,
+			"old_value": c.OldValue,
+			"new_value": c.NewValue,
+		})
+	}
+	return res
+}
+
+func hostnameChangesToList(changes []hostnameChange) []interface{} {
+	res := make([]interface{}, 0, len(changes))
+	for _, c := range changes {
+		res = append(res, map[string]interface{}{
+			"cname_from":                 c.CnameFrom,
+			"action":                     c.Action,
+			"old_cname_to":               c.OldCnameTo,
+			"new_cname_to":               c.NewCnameTo,
+			"old_cert_provisioning_type": c.OldCertProvisioningType,
+			"new_cert_provisioning_type": c.NewCertProvisioningType,
+		})
+	}
+	return res
+}
@@ -0,0 +1,209 @@
+package property
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v2/pkg/papi"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/tj/assert"
+)
+
+func TestDiffRuleTrees(t *testing.T) {
+	origin := papi.RuleBehavior{Name: "origin", Options: papi.RuleOptionsMap{"hostname": "origin.example.com"}}
+	cpCode := papi.RuleBehavior{Name: "cpCode", Options: papi.RuleOptionsMap{"value": map[string]interface{}{"id": 1}}}
+	caching := papi.RuleBehavior{Name: "caching", Options: papi.RuleOptionsMap{"behavior": "MAX_AGE", "ttl": "1d"}}
+	extension := papi.RuleBehavior{Name: "fileExtension", Options: papi.RuleOptionsMap{"values": []interface{}{"css"}}}
+
+	tests := map[string]struct {
+		old, new papi.RulesUpdate
+		expected []ruleChange
+	}{
+		"reordered behaviors are not reported": {
+			old: papi.RulesUpdate{Rules: papi.Rules{Name: "default", Behaviors: []papi.RuleBehavior{origin, cpCode}}},
+			new: papi.RulesUpdate{Rules: papi.Rules{Name: "default", Behaviors: []papi.RuleBehavior{cpCode, origin}, CriteriaMustSatisfy: papi.RuleCriteriaMustSatisfyAll}},
+		},
+		"behavior changes": {
+			old: papi.RulesUpdate{Rules: papi.Rules{Name: "default", Behaviors: []papi.RuleBehavior{origin, cpCode}}},
+			new: papi.RulesUpdate{Rules: papi.Rules{Name: "default", Behaviors: []papi.RuleBehavior{
+				{Name: "origin", Options: papi.RuleOptionsMap{"hostname": "new.example.com"}}, caching,
+			}}},
+			expected: []ruleChange{
+				{Path: "default", Type: "behavior", Name: "caching", Action: changeAdded, NewValue: `{"name":"caching","options":{"behavior":"MAX_AGE","ttl":"1d"}}`},
+				{Path: "default", Type: "behavior", Name: "cpCode", Action: changeRemoved, OldValue: `{"name":"cpCode","options":{"value":{"id":1}}}`},
+				{Path: "default", Type: "behavior", Name: "origin", Action: changeModified,
+					OldValue: `{"name":"origin","options":{"hostname":"origin.example.com"}}`,
+					NewValue: `{"name":"origin","options":{"hostname":"new.example.com"}}`},
+			},
+		},
+		"child rule changes": {
+			old: papi.RulesUpdate{Rules: papi.Rules{Name: "default", Children: []papi.Rules{
+				{Name: "Static", Criteria: []papi.RuleBehavior{extension}},
+				{Name: "Performance"},
+				{Name: "Legacy"},
+			}}},
+			new: papi.RulesUpdate{Comments: "new version", Rules: papi.Rules{Name: "default", Children: []papi.Rules{
+				{Name: "Performance", Comments: "faster"},
+				{Name: "Static", Criteria: []papi.RuleBehavior{extension}, Behaviors: []papi.RuleBehavior{caching}},
+				{Name: "Images"},
+			}}},
+			expected: []ruleChange{
+				{Type: "rule_tree", Name: "comments", Action: changeModified, NewValue: "new version"},
+				{Path: "default", Type: "rule", Name: "default", Action: changeReordered, OldValue: `["Static","Performance"]`, NewValue: `["Performance","Static"]`},
+				{Path: "default/Static", Type: "behavior", Name: "caching", Action: changeAdded, NewValue: `{"name":"caching","options":{"behavior":"MAX_AGE","ttl":"1d"}}`},
+				{Path: "default/Performance", Type: "rule", Name: "Performance", Action: changeModified, OldValue: `{"name":"Performance","options":{}}`, NewValue: `{"comments":"faster","name":"Performance","options":{}}`},
+				{Path: "default/Legacy", Type: "rule", Name: "Legacy", Action: changeRemoved, OldValue: `{"name":"Legacy","options":{}}`},
+				{Path: "default/Images", Type: "rule", Name: "Images", Action: changeAdded, NewValue: `{"name":"Images","options":{}}`},
+			},
+		},
+		"duplicated criteria": {
+			old: papi.RulesUpdate{Rules: papi.Rules{Name: "default", Criteria: []papi.RuleBehavior{
+				{Name: "path", Options: papi.RuleOptionsMap{"values": []interface{}{"/a"}}},
+				{Name: "path", Options: papi.RuleOptionsMap{"values": []interface{}{"/b"}}},
+			}}},
+			new: papi.RulesUpdate{Rules: papi.Rules{Name: "default", Criteria: []papi.RuleBehavior{
+				{Name: "path", Options: papi.RuleOptionsMap{"values": []interface{}{"/b"}}},
+				{Name: "path", Options: papi.RuleOptionsMap{"values": []interface{}{"/c"}}},
+			}}},
+			expected: []ruleChange{
+				{Path: "default", Type: "criterion", Name: "path", Action: changeModified,
+					OldValue: `{"name":"path","options":{"values":["/a"]}}`,
+					NewValue: `{"name":"path","options":{"values":["/c"]}}`},
+			},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			changes, err := diffRuleTrees(test.old, test.new)
+			require.NoError(t, err)
+			assert.Equal(t, test.expected, changes)
+		})
+	}
+}
+
+func TestDiffVariables(t *testing.T) {
+	old := []papi.RuleVariable{
+		{Name: "PMUSER_ORIGIN", Value: "origin.example.com"},
+		{Name: "PMUSER_LEGACY", Value: "1"},
+		{Name: "PMUSER_SAME", Value: "same"},
+	}
+	new := []papi.RuleVariable{
+		{Name: "PMUSER_SAME", Value: "same"},
+		{Name: "PMUSER_ORIGIN", Value: "new.example.com"},
+		{Name: "PMUSER_TOKEN", Sensitive: true},
+	}
+
+	changes, err := diffVariables(old, new)
+	require.NoError(t, err)
+	assert.Equal(t, []variableChange{
+		{Name: "PMUSER_LEGACY", Action: changeRemoved, OldValue: `{"hidden":false,"name":"PMUSER_LEGACY","sensitive":false,"value":"1"}`},
+		{Name: "PMUSER_ORIGIN", Action: changeModified,
+			OldValue: `{"hidden":false,"name":"PMUSER_ORIGIN","sensitive":false,"value":"origin.example.com"}`,
+			NewValue: `{"hidden":false,"name":"PMUSER_ORIGIN","sensitive":false,"value":"new.example.com"}`},
+		{Name: "PMUSER_TOKEN", Action: changeAdded, NewValue: `{"hidden":false,"name":"PMUSER_TOKEN","sensitive":true}`},
+	}, changes)
+	assert.Equal(t, []papi.RuleVariable{{Name: "PMUSER_ORIGIN", Value: "origin.example.com"}}, old[:1], "variables are not reordered")
+}
+
+func TestDiffHostnames(t *testing.T) {
+	old := []papi.Hostname{
+		{CnameFrom: "www.example.com", CnameTo: "www.example.com.edgesuite.net", CertProvisioningType: "CPS_MANAGED"},
+		{CnameFrom: "old.example.com", CnameTo: "old.example.com.edgesuite.net", CertProvisioningType: "CPS_MANAGED"},
+		{CnameFrom: "api.example.com", CnameTo: "api.example.com.edgekey.net", CertProvisioningType: "DEFAULT"},
+	}
+	new := []papi.Hostname{
+		{CnameFrom: "api.example.com", CnameTo: "api.example.com.edgekey.net", CertProvisioningType: "DEFAULT",
+			CertStatus: papi.CertStatusItem{Staging: []papi.StatusItem{{Status: "DEPLOYED"}}}},
+		{CnameFrom: "www.example.com", CnameTo: "www.example.com.edgekey.net", CertProvisioningType: "DEFAULT"},
+		{CnameFrom: "new.example.com", CnameTo: "new.example.com.edgesuite.net", CertProvisioningType: "CPS_MANAGED"},
+	}
+
+	assert.Equal(t, []hostnameChange{
+		{CnameFrom: "new.example.com", Action: changeAdded, NewCnameTo: "new.example.com.edgesuite.net", NewCertProvisioningType: "CPS_MANAGED"},
+		{CnameFrom: "old.example.com", Action: changeRemoved, OldCnameTo: "old.example.com.edgesuite.net", OldCertProvisioningType: "CPS_MANAGED"},
+		{CnameFrom: "www.example.com", Action: changeModified,
+			OldCnameTo: "www.example.com.edgesuite.net", NewCnameTo: "www.example.com.edgekey.net",
+			OldCertProvisioningType: "CPS_MANAGED", NewCertProvisioningType: "DEFAULT"},
+	}, diffHostnames(old, new))
+}
+
+func TestResolvePropertyVersion(t *testing.T) {
+	production := 2
+	property := &papi.Property{PropertyID: "prp_1", LatestVersion: 3, ProductionVersion: &production}
+
+	tests := map[string]struct {
+		version   string
+		expected  int
+		withError error
+	}{
+		"latest":         {version: "latest", expected: 3},
+		"production":     {version: "PRODUCTION", expected: 2},
+		"version number": {version: "1", expected: 1},
+		"ver_ prefix":    {version: "ver_1", expected: 1},
+		"not on staging": {version: "staging", withError: ErrPropertyVersionNotFound},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			version, err := resolvePropertyVersion(property, test.version)
+			if test.withError != nil {
+				assert.True(t, errors.Is(err, test.withError), "want: %s; got: %s", test.withError, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, test.expected, version)
+		})
+	}
+}
+
+func TestDSPropertyVersionDiff(t *testing.T) {
+	production := 1
+	property := papi.Property{PropertyID: "prp_1", ContractID: "ctr_1", GroupID: "grp_1", LatestVersion: 2, ProductionVersion: &production}
+	hostnames := map[int][]papi.Hostname{
+		1: {{CnameFrom: "www.example.com", CnameTo: "www.example.com.edgesuite.net", CertProvisioningType: "CPS_MANAGED"}},
+		2: {{CnameFrom: "www.example.com", CnameTo: "www.example.com.edgesuite.net", CertProvisioningType: "CPS_MANAGED"}},
+	}
+	rules := map[int]papi.Rules{
+		1: {Name: "default", Behaviors: []papi.RuleBehavior{{Name: "origin", Options: papi.RuleOptionsMap{"hostname": "origin.example.com"}}}},
+		2: {Name: "default", Behaviors: []papi.RuleBehavior{{Name: "origin", Options: papi.RuleOptionsMap{"hostname": "new.example.com"}}}},
+	}
+
+	client := &mockpapi{}
+	ExpectGetProperty(client, "prp_1", "", "", &property)
+	for version := 1; version <= 2; version++ {
+		ExpectGetPropertyVersionHostnames(client, "prp_1", "grp_1", "ctr_1", version, &[]papi.Hostname{hostnames[version][0]})
+		client.On("GetRuleTree", mock.Anything, papi.GetRuleTreeRequest{
+			PropertyID:      "prp_1",
+			ContractID:      "ctr_1",
+			GroupID:         "grp_1",
+			PropertyVersion: version,
+			ValidateRules:   true,
+			ValidateMode:    papi.RuleValidateModeFull,
+		}).Return(&papi.GetRuleTreeResponse{RuleFormat: "v2021-05-05", Rules: rules[version]}, nil)
+	}
+
+	useClient(client, func() {
+		resource.UnitTest(t, resource.TestCase{
+			Providers: testAccProviders,
+			Steps: []resource.TestStep{{
+				Config: loadFixtureString("testdata/TestDSPropertyVersionDiff/version_diff.tf"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.akamai_property_version_diff.diff", "id", "prp_1:1:2"),
+					resource.TestCheckResourceAttr("data.akamai_property_version_diff.diff", "old_version_number", "1"),
+					resource.TestCheckResourceAttr("data.akamai_property_version_diff.diff", "new_version_number", "2"),
+					resource.TestCheckResourceAttr("data.akamai_property_version_diff.diff", "has_changes", "true"),
+					resource.TestCheckResourceAttr("data.akamai_property_version_diff.diff", "rule_changes.#", "1"),
+					resource.TestCheckResourceAttr("data.akamai_property_version_diff.diff", "rule_changes.0.path", "default"),
+					resource.TestCheckResourceAttr("data.akamai_property_version_diff.diff", "rule_changes.0.name", "origin"),
+					resource.TestCheckResourceAttr("data.akamai_property_version_diff.diff", "rule_changes.0.action", "modified"),
+					resource.TestCheckResourceAttr("data.akamai_property_version_diff.diff", "variable_changes.#", "0"),
+					resource.TestCheckResourceAttr("data.akamai_property_version_diff.diff", "hostname_changes.#", "0"),
+				),
+			}},
+		})
+	})
+	client.AssertExpectations(t)
+}
@@ -26,19 +26,24 @@ func compareRulesJSON(old, new string) bool {
 	return diff
 }
 
-func compareRuleTree(old, new *papi.RulesUpdate) bool {
+func compareRuleTree(old, new *papi.RulesUpdate, ignorePaths ...string) bool {
 	if old.Comments != new.Comments {
 		return false
 	}
-	diff := compareRules(&old.Rules, &new.Rules)
+	diff := compareRules(&old.Rules, &new.Rules, ignorePaths...)
 	return diff
 }
 
 // compareRules handles comparison between two papi.Rules objects
 // due to an issue in PAPI we need to compare collections of behaviors, criteria and variables discarding the order from JSON
+// the child rules at ignorePaths, as rule names separated by "/" below the compared rule, are left out of the comparison
 // true: deeply equals
 // false: not deeply equals
-func compareRules(old, new *papi.Rules) bool {
+func compareRules(old, new *papi.Rules, ignorePaths ...string) bool {
+	if len(ignorePaths) > 0 {
+		oldRules, newRules := withoutRulePaths(*old, ignorePaths), withoutRulePaths(*new, ignorePaths)
+		return compareRules(&oldRules, &newRules)
+	}
 	if len(old.Behaviors) != len(new.Behaviors) ||
 		len(old.Criteria) != len(new.Criteria) ||
 		len(old.Variables) != len(new.Variables) ||
@@ -81,6 +86,32 @@ func compareRules(old, new *papi.Rules) bool {
 	return reflect.DeepEqual(old, new)
 }
 
+// withoutRulePaths returns a copy of the rules without the child rules at the given paths, the rules are not modified
+func withoutRulePaths(rules papi.Rules, paths []string) papi.Rules {
+	for _, path := range paths {
+		rules = withoutRulePath(rules, splitRulePath(path))
+	}
+	return rules
+}
+
+func withoutRulePath(rules papi.Rules, path []string) papi.Rules {
+	if len(path) == 0 {
+		return rules
+	}
+	children := make([]papi.Rules, 0, len(rules.Children))
+	for _, child := range rules.Children {
+		if child.Name != path[0] {
+			children = append(children, child)
+			continue
+		}
+		if len(path) > 1 {
+			children = append(children, withoutRulePath(child, path[1:]))
+		}
+	}
+	rules.Children = children
+	return rules
+}
+
 func orderBehaviors(behaviors []papi.RuleBehavior) []papi.RuleBehavior {
 	if len(behaviors) == 0 {
 		return nil
@@ -606,3 +606,54 @@ func TestCompareRules(t *testing.T) {
 		})
 	}
 }
+
+func TestCompareRulesIgnorePaths(t *testing.T) {
+	rules := func(cachingTTL string, children ...papi.Rules) *papi.Rules {
+		return &papi.Rules{
+			Name: "default",
+			Children: append([]papi.Rules{{
+				Name: "Performance",
+				Children: []papi.Rules{{
+					Name:      "Caching",
+					Behaviors: []papi.RuleBehavior{{Name: "caching", Options: papi.RuleOptionsMap{"ttl": cachingTTL}}},
+				}},
+			}}, children...),
+		}
+	}
+
+	tests := map[string]struct {
+		old, new    *papi.Rules
+		ignorePaths []string
+		expected    bool
+	}{
+		"ignored rule differs": {
+			old:         rules("1d"),
+			new:         rules("7d"),
+			ignorePaths: []string{"Performance/Caching"},
+			expected:    true,
+		},
+		"ignored rule missing on one side": {
+			old:         rules("1d"),
+			new:         &papi.Rules{Name: "default", Children: []papi.Rules{{Name: "Performance"}}},
+			ignorePaths: []string{"Performance/Caching"},
+			expected:    true,
+		},
+		"other rule differs": {
+			old:         rules("1d"),
+			new:         rules("1d", papi.Rules{Name: "Security"}),
+			ignorePaths: []string{"Performance/Caching"},
+			expected:    false,
+		},
+		"not ignored": {
+			old:      rules("1d"),
+			new:      rules("7d"),
+			expected: false,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, test.expected, compareRules(test.old, test.new, test.ignorePaths...))
+		})
+	}
+}
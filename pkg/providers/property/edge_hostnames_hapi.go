@@ -0,0 +1,130 @@
+package property
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v2/pkg/hapi"
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v2/pkg/session"
+)
+
+type (
+	// edgeHostnamesHAPI is the client of the Edge Hostnames API (HAPI). The HAPI client in use only deletes edge
+	// hostnames, the other endpoints are implemented on its session.
+	// Changes of edge hostnames are asynchronous, they return a change request which is polled until it is done.
+	edgeHostnamesHAPI interface {
+		hapi.EdgeHostnames
+		ListEdgeHostnames(context.Context, listEdgeHostnamesRequest) ([]hapi.EdgeHostname, error)
+		PatchEdgeHostname(context.Context, patchEdgeHostnameRequest) (*edgeHostnameChange, error)
+		GetChangeRequest(context.Context, int) (*edgeHostnameChange, error)
+	}
+
+	edgeHostnamesHAPIClient struct {
+		hapi.HAPI
+		session.Session
+	}
+
+	listEdgeHostnamesRequest struct {
+		RecordNameSubstring string
+		DNSZone             string
+		ChinaOnly           bool
+	}
+
+	patchEdgeHostnameRequest struct {
+		DNSZone           string
+		RecordName        string
+		Patches           []edgeHostnamePatch
+		StatusUpdateEmail []string
+		Comments          string
+	}
+
+	// edgeHostnamePatch is a JSON patch operation on an edge hostname, only the ttl and ipVersionBehavior of an edge
+	// hostname can be replaced
+	edgeHostnamePatch struct {
+		Op    string `json:"op"`
+		Path  string `json:"path"`
+		Value string `json:"value"`
+	}
+
+	// edgeHostnameChange is a change request on edge hostnames, as returned when deleting an edge hostname
+	edgeHostnameChange = hapi.DeleteEdgeHostnameResponse
+)
+
+const (
+	// EdgeHostnameChangePending is the status of a change request in progress
+	EdgeHostnameChangePending = "PENDING"
+	// EdgeHostnameChangeSucceeded is the status of a change request applied
+	EdgeHostnameChangeSucceeded = "SUCCEEDED"
+	// EdgeHostnameChangeFailed is the status of a change request which was not applied
+	EdgeHostnameChangeFailed = "FAILED"
+)
+
+// newEdgeHostnamesHAPIClient returns the client of the Edge Hostnames API
+func newEdgeHostnamesHAPIClient(sess session.Session) edgeHostnamesHAPI {
+	return &edgeHostnamesHAPIClient{HAPI: hapi.Client(sess), Session: sess}
+}
+
+// ListEdgeHostnames returns the edge hostnames of the account, filtered by the request
+func (c *edgeHostnamesHAPIClient) ListEdgeHostnames(ctx context.Context, params listEdgeHostnamesRequest) ([]hapi.EdgeHostname, error) {
+	uri := url.URL{Path: "/hapi/v1/edge-hostnames"}
+	q := uri.Query()
+	if params.RecordNameSubstring != "" {
+		q.Add("recordNameSubstring", params.RecordNameSubstring)
+	}
+	if params.DNSZone != "" {
+		q.Add("dnsZone", params.DNSZone)
+	}
+	if params.ChinaOnly {
+		q.Add("chinaOnly", "true")
+	}
+	uri.RawQuery = q.Encode()
+
+	var resp struct {
+		EdgeHostnames []hapi.EdgeHostname `json:"edgeHostnames"`
+	}
+	if err := c.exec(ctx, http.MethodGet, uri.String(), http.StatusOK, &resp); err != nil {
+		return nil, fmt.Errorf("list edge hostnames: %w", err)
+	}
+	return resp.EdgeHostnames, nil
+}
+
+// PatchEdgeHostname replaces the settings of an edge hostname, and returns the change request which applies the patch
+func (c *edgeHostnamesHAPIClient) PatchEdgeHostname(ctx context.Context, params patchEdgeHostnameRequest) (*edgeHostnameChange, error) {
+	uri := url.URL{Path: fmt.Sprintf("/hapi/v1/dns-zones/%s/edge-hostnames/%s", params.DNSZone, params.RecordName)}
+	q := uri.Query()
+	if len(params.StatusUpdateEmail) > 0 {
+		q.Add("statusUpdateEmail", strings.Join(params.StatusUpdateEmail, ","))
+	}
+	if params.Comments != "" {
+		q.Add("comments", params.Comments)
+	}
+	uri.RawQuery = q.Encode()
+
+	var change edgeHostnameChange
+	if err := c.exec(ctx, http.MethodPatch, uri.String(), http.StatusAccepted, &change, params.Patches); err != nil {
+		return nil, fmt.Errorf("patch edge hostname: %w", err)
+	}
+	return &change, nil
+}
+
+// GetChangeRequest returns the change request with its status
+func (c *edgeHostnamesHAPIClient) GetChangeRequest(ctx context.Context, changeID int) (*edgeHostnameChange, error) {
+	uri := fmt.Sprintf("/hapi/v1/change-requests/%s", strconv.Itoa(changeID))
+	var change edgeHostnameChange
+	if err := c.exec(ctx, http.MethodGet, uri, http.StatusOK, &change); err != nil {
+		return nil, fmt.Errorf("get change request: %w", err)
+	}
+	return &change, nil
+}
+
+func (c *edgeHostnamesHAPIClient) exec(ctx context.Context, method, uri string, status int, out interface{}, in ...interface{}) error {
+	var header http.Header
+	if method == http.MethodPatch {
+		header = http.Header{"Content-Type": []string{"application/json-patch+json"}}
+	}
+	return execAPI(ctx, c.Session, method, uri, header, status, out, in...)
+}
@@ -0,0 +1,165 @@
+package property
+
+import (
+	"context"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v2/pkg/hapi"
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v2/pkg/papi"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/tj/assert"
+)
+
+func mockEdgeHostnamesHAPIClient(t *testing.T, mockServer *httptest.Server) edgeHostnamesHAPI {
+	return newEdgeHostnamesHAPIClient(mockIncludesClient(t, mockServer).(*includesClient).Session)
+}
+
+func TestListEdgeHostnames(t *testing.T) {
+	mockServer := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/hapi/v1/edge-hostnames?chinaOnly=true&dnsZone=edgekey.net&recordNameSubstring=example", r.URL.String())
+		assert.Equal(t, http.MethodGet, r.Method)
+		w.WriteHeader(http.StatusOK)
+		_, err := w.Write([]byte(`{"edgeHostnames": [{"edgeHostnameId": 123, "recordName": "www.example.com", "dnsZone": "edgekey.net",
+			"securityType": "ENHANCED-TLS", "useDefaultTtl": false, "useDefaultMap": true, "ttl": 300, "map": "e1.akamaiedge.net",
+			"slotNumber": 456, "ipVersionBehavior": "IPV6_IPV4_DUALSTACK", "chinaCdn": {"isChinaCdn": true}}]}`))
+		assert.NoError(t, err)
+	}))
+	defer mockServer.Close()
+	client := mockEdgeHostnamesHAPIClient(t, mockServer)
+
+	edgeHostnames, err := client.ListEdgeHostnames(context.Background(), listEdgeHostnamesRequest{
+		RecordNameSubstring: "example",
+		DNSZone:             "edgekey.net",
+		ChinaOnly:           true,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []hapi.EdgeHostname{{
+		EdgeHostnameID:    123,
+		RecordName:        "www.example.com",
+		DNSZone:           "edgekey.net",
+		SecurityType:      "ENHANCED-TLS",
+		UseDefaultMap:     true,
+		TTL:               300,
+		Map:               "e1.akamaiedge.net",
+		SlotNumber:        456,
+		IPVersionBehavior: "IPV6_IPV4_DUALSTACK",
+		ChinaCDN:          hapi.ChinaCDN{IsChinaCDN: true},
+	}}, edgeHostnames)
+}
+
+func TestPatchEdgeHostname(t *testing.T) {
+	tests := map[string]struct {
+		responseStatus   int
+		responseBody     string
+		expected         *edgeHostnameChange
+		withError        bool
+		expectedErrTitle string
+	}{
+		"202 accepted": {
+			responseStatus: http.StatusAccepted,
+			responseBody:   `{"action": "EDIT", "changeId": 66025603, "status": "PENDING", "statusMessage": "File successfully deployed"}`,
+			expected:       &edgeHostnameChange{Action: "EDIT", ChangeID: 66025603, Status: "PENDING", StatusMessage: "File successfully deployed"},
+		},
+		"404 not found": {
+			responseStatus:   http.StatusNotFound,
+			responseBody:     `{"type": "/hapi/problems/record-name-dns-zone-not-found", "title": "Invalid Record Name/DNS Zone", "status": 404}`,
+			withError:        true,
+			expectedErrTitle: "Invalid Record Name/DNS Zone",
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			mockServer := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				assert.Equal(t, "/hapi/v1/dns-zones/edgesuite.net/edge-hostnames/www.example.com?statusUpdateEmail=a%40example.com%2Cb%40example.com", r.URL.String())
+				assert.Equal(t, http.MethodPatch, r.Method)
+				assert.Equal(t, "application/json-patch+json", r.Header.Get("Content-Type"))
+				body, err := ioutil.ReadAll(r.Body)
+				require.NoError(t, err)
+				assert.JSONEq(t, `[{"op": "replace", "path": "/ttl", "value": "300"},
+					{"op": "replace", "path": "/ipVersionBehavior", "value": "IPV4"}]`, string(body))
+				w.WriteHeader(test.responseStatus)
+				_, err = w.Write([]byte(test.responseBody))
+				assert.NoError(t, err)
+			}))
+			defer mockServer.Close()
+			client := mockEdgeHostnamesHAPIClient(t, mockServer)
+
+			change, err := client.PatchEdgeHostname(context.Background(), patchEdgeHostnameRequest{
+				DNSZone:    "edgesuite.net",
+				RecordName: "www.example.com",
+				Patches: []edgeHostnamePatch{
+					{Op: "replace", Path: "/ttl", Value: "300"},
+					{Op: "replace", Path: "/ipVersionBehavior", Value: "IPV4"},
+				},
+				StatusUpdateEmail: []string{"a@example.com", "b@example.com"},
+			})
+			if test.withError {
+				var e *papi.Error
+				require.True(t, errors.As(err, &e), "want: *papi.Error; got: %s", err)
+				assert.Equal(t, test.responseStatus, e.StatusCode)
+				assert.Equal(t, test.expectedErrTitle, e.Title)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, test.expected, change)
+		})
+	}
+}
+
+func TestGetChangeRequest(t *testing.T) {
+	mockServer := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/hapi/v1/change-requests/66025603", r.URL.String())
+		assert.Equal(t, http.MethodGet, r.Method)
+		w.WriteHeader(http.StatusOK)
+		_, err := w.Write([]byte(`{"action": "DELETE", "changeId": 66025603, "status": "SUCCEEDED", "statusUpdateEmail": "a@example.com"}`))
+		assert.NoError(t, err)
+	}))
+	defer mockServer.Close()
+	client := mockEdgeHostnamesHAPIClient(t, mockServer)
+
+	change, err := client.GetChangeRequest(context.Background(), 66025603)
+	require.NoError(t, err)
+	assert.Equal(t, &edgeHostnameChange{Action: "DELETE", ChangeID: 66025603, Status: EdgeHostnameChangeSucceeded, StatusUpdateEmail: "a@example.com"}, change)
+}
+
+type mockhapi struct {
+	mock.Mock
+}
+
+func (m *mockhapi) DeleteEdgeHostname(ctx context.Context, r hapi.DeleteEdgeHostnameRequest) (*hapi.DeleteEdgeHostnameResponse, error) {
+	args := m.Called(ctx, r)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*hapi.DeleteEdgeHostnameResponse), args.Error(1)
+}
+
+func (m *mockhapi) ListEdgeHostnames(ctx context.Context, r listEdgeHostnamesRequest) ([]hapi.EdgeHostname, error) {
+	args := m.Called(ctx, r)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]hapi.EdgeHostname), args.Error(1)
+}
+
+func (m *mockhapi) PatchEdgeHostname(ctx context.Context, r patchEdgeHostnameRequest) (*edgeHostnameChange, error) {
+	args := m.Called(ctx, r)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*edgeHostnameChange), args.Error(1)
+}
+
+func (m *mockhapi) GetChangeRequest(ctx context.Context, changeID int) (*edgeHostnameChange, error) {
+	args := m.Called(ctx, changeID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*edgeHostnameChange), args.Error(1)
+}
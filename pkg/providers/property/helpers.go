@@ -1,13 +1,25 @@
 package property
 
 import (
+	"context"
+	"encoding/json"
 	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
 	"strings"
 
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v2/pkg/hapi"
 	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v2/pkg/papi"
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v2/pkg/session"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
 
+// papiHeader is the header of the requests to the PAPI endpoints which the PAPI client in use does not support,
+// the IDs are sent and returned with their prefixes
+var papiHeader = http.Header{"PAPI-Use-Prefixes": []string{"true"}}
+
 var certStatus = &schema.Resource{
 	Schema: map[string]*schema.Schema{
 		"target": {
@@ -91,6 +103,26 @@ func papiErrorToMap(err *papi.Error) map[string]interface{} {
 	}
 }
 
+// papiWarningsToDiags returns the given PAPI warnings as warning diagnostics with the given summary
+func papiWarningsToDiags(summary string, Warnings []*papi.Error) diag.Diagnostics {
+	var diags diag.Diagnostics
+	for _, w := range Warnings {
+		if w == nil {
+			continue
+		}
+		detail := w.Detail
+		if w.ErrorLocation != "" {
+			detail = fmt.Sprintf("%s (at %s)", detail, w.ErrorLocation)
+		}
+		diags = append(diags, diag.Diagnostic{
+			Severity: diag.Warning,
+			Summary:  fmt.Sprintf("%s: %s", summary, w.Title),
+			Detail:   detail,
+		})
+	}
+	return diags
+}
+
 // NetworkAlias parses the given network name or alias and returns its full name and any error
 func NetworkAlias(network string) (string, error) {
 
@@ -110,3 +142,55 @@ func NetworkAlias(network string) (string, error) {
 	}
 	return string(networkValue), nil
 }
+
+// execAPI sends the request with the header on the session of a client implementing API endpoints the clients in use
+// do not support, and returns the error of the response if its status is not the expected one, see apiError
+func execAPI(ctx context.Context, sess session.Session, method, uri string, header http.Header, status int, out interface{}, in ...interface{}) error {
+	req, err := http.NewRequestWithContext(ctx, method, uri, nil)
+	if err != nil {
+		return fmt.Errorf("failed to create request: %s", err)
+	}
+	for key, values := range header {
+		for _, v := range values {
+			req.Header.Add(key, v)
+		}
+	}
+
+	resp, err := sess.Exec(req, out, in...)
+	if err != nil {
+		return fmt.Errorf("request failed: %s", err)
+	}
+	if resp.StatusCode != status {
+		return apiError(resp)
+	}
+	return nil
+}
+
+// apiError parses the error from the response like the PAPI client. The PAPI and HAPI endpoints return the same
+// problem details, so the errors of both are returned as *papi.Error.
+func apiError(resp *http.Response) error {
+	e := &papi.Error{StatusCode: resp.StatusCode}
+
+	body, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		e.Title = "Failed to read error body"
+		e.Detail = err.Error()
+		return e
+	}
+	if err := json.Unmarshal(body, e); err != nil {
+		e.Title = "Failed to unmarshal error body"
+		e.Detail = err.Error()
+	}
+	e.StatusCode = resp.StatusCode
+	return e
+}
+
+// isNotFoundError returns true if the error is a PAPI error, or an error of the HAPI client, with the 404 status
+func isNotFoundError(err error) bool {
+	var e *papi.Error
+	if errors.As(err, &e) {
+		return e.StatusCode == http.StatusNotFound
+	}
+	var h *hapi.Error
+	return errors.As(err, &h) && h.Status == http.StatusNotFound
+}
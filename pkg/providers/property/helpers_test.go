@@ -2,9 +2,12 @@ package property
 
 import (
 	"fmt"
+	"net/http"
 	"testing"
 
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v2/pkg/hapi"
 	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v2/pkg/papi"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -65,3 +68,36 @@ func TestNetworkAlias(t *testing.T) {
 		})
 	}
 }
+
+func TestPapiWarningsToDiags(t *testing.T) {
+	warnings := []*papi.Error{
+		{Title: "Behavior option removed", Detail: "The option was removed.", ErrorLocation: "#/rules/behaviors/0"},
+		nil,
+		{Title: "Unstable rule format", Detail: "The rule format is not frozen."},
+	}
+	expected := diag.Diagnostics{
+		{Severity: diag.Warning, Summary: "conversion: Behavior option removed", Detail: "The option was removed. (at #/rules/behaviors/0)"},
+		{Severity: diag.Warning, Summary: "conversion: Unstable rule format", Detail: "The rule format is not frozen."},
+	}
+	assert.Equal(t, expected, papiWarningsToDiags("conversion", warnings))
+	assert.Empty(t, papiWarningsToDiags("conversion", nil))
+}
+
+func TestIsNotFoundError(t *testing.T) {
+	tests := map[string]struct {
+		err      error
+		expected bool
+	}{
+		"PAPI not found": {err: fmt.Errorf("get include: %w", &papi.Error{StatusCode: http.StatusNotFound}), expected: true},
+		"HAPI not found": {err: &hapi.Error{Status: http.StatusNotFound}, expected: true},
+		"PAPI forbidden": {err: &papi.Error{StatusCode: http.StatusForbidden}},
+		"HAPI forbidden": {err: &hapi.Error{Status: http.StatusForbidden}},
+		"other error":    {err: fmt.Errorf("request failed")},
+		"no error":       {},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, test.expected, isNotFoundError(test.err))
+		})
+	}
+}
@@ -0,0 +1,230 @@
+package property
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v2/pkg/papi"
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v2/pkg/session"
+)
+
+type (
+	// hostnameBuckets is the client of the PAPI hostname bucket endpoints, which the PAPI client in use does not support.
+	// The hostnames of a hostname bucket are changed per network with patches, without creating property versions.
+	hostnameBuckets interface {
+		HasHostnameBuckets(context.Context, propertyHostnamesRequest) (bool, error)
+		ListPropertyHostnames(context.Context, propertyHostnamesRequest) ([]papi.Hostname, error)
+		PatchPropertyHostnames(context.Context, patchPropertyHostnamesRequest) (string, error)
+		GetHostnameActivation(context.Context, hostnameActivationRequest) (*hostnameActivation, error)
+	}
+
+	hostnameBucketsClient struct {
+		session.Session
+	}
+
+	// propertyHostnamesRequest identifies the hostnames of a property on a network
+	propertyHostnamesRequest struct {
+		PropertyID string
+		ContractID string
+		GroupID    string
+		Network    papi.ActivationNetwork
+	}
+
+	patchPropertyHostnamesRequest struct {
+		propertyHostnamesRequest
+		Add          []papi.Hostname
+		Remove       []string
+		Note         string
+		NotifyEmails []string
+	}
+
+	hostnameActivationRequest struct {
+		propertyHostnamesRequest
+		HostnameActivationID string
+	}
+
+	hostnameActivation struct {
+		HostnameActivationID string                 `json:"hostnameActivationId"`
+		ActivationType       papi.ActivationType    `json:"activationType"`
+		Network              papi.ActivationNetwork `json:"network"`
+		Status               papi.ActivationStatus  `json:"status"`
+		Note                 string                 `json:"note"`
+		NotifyEmails         []string               `json:"notifyEmails"`
+		SubmitDate           string                 `json:"submitDate"`
+		UpdateDate           string                 `json:"updateDate"`
+	}
+
+	// bucketHostname is a hostname of a hostname bucket, with its settings on each network
+	bucketHostname struct {
+		CnameFrom                string                 `json:"cnameFrom"`
+		CnameType                papi.HostnameCnameType `json:"cnameType"`
+		StagingEdgeHostnameID    string                 `json:"stagingEdgeHostnameId"`
+		StagingCnameTo           string                 `json:"stagingCnameTo"`
+		StagingCertType          string                 `json:"stagingCertType"`
+		ProductionEdgeHostnameID string                 `json:"productionEdgeHostnameId"`
+		ProductionCnameTo        string                 `json:"productionCnameTo"`
+		ProductionCertType       string                 `json:"productionCertType"`
+		CertStatus               papi.CertStatusItem    `json:"certStatus"`
+	}
+)
+
+const (
+	// hostnamesPageSize is the number of hostnames listed per request
+	hostnamesPageSize = 999
+
+	// propertyTypeHostnameBucket is the type of the properties with hostname buckets
+	propertyTypeHostnameBucket = "HOSTNAME_BUCKET"
+)
+
+var (
+	// ErrHostnameActivationNotFound is returned when the hostname activation is not found
+	ErrHostnameActivationNotFound = errors.New("hostname activation not found")
+	// ErrHostnameBucketsNotEnabled is returned when the hostnames of a property without hostname buckets are patched
+	ErrHostnameBucketsNotEnabled = errors.New("hostname buckets are not enabled for the property")
+)
+
+// newHostnameBucketsClient returns the client of the PAPI hostname bucket endpoints
+func newHostnameBucketsClient(sess session.Session) hostnameBuckets {
+	return &hostnameBucketsClient{Session: sess}
+}
+
+func (r propertyHostnamesRequest) path(format string, args ...interface{}) url.URL {
+	uri := url.URL{Path: fmt.Sprintf("/papi/v1/properties/%s", r.PropertyID) + fmt.Sprintf(format, args...)}
+	q := uri.Query()
+	q.Add("contractId", r.ContractID)
+	q.Add("groupId", r.GroupID)
+	uri.RawQuery = q.Encode()
+	return uri
+}
+
+// HasHostnameBuckets returns whether the property has hostname buckets, which are enabled when it is created
+func (c *hostnameBucketsClient) HasHostnameBuckets(ctx context.Context, params propertyHostnamesRequest) (bool, error) {
+	uri := params.path("")
+	var resp struct {
+		Properties struct {
+			Items []struct {
+				PropertyType string `json:"propertyType"`
+			} `json:"items"`
+		} `json:"properties"`
+	}
+	if err := c.exec(ctx, http.MethodGet, uri.String(), http.StatusOK, &resp); err != nil {
+		return false, fmt.Errorf("get property: %w", err)
+	}
+	if len(resp.Properties.Items) == 0 {
+		return false, fmt.Errorf("%w: %s", ErrPropertyNotFound, params.PropertyID)
+	}
+	return resp.Properties.Items[0].PropertyType == propertyTypeHostnameBucket, nil
+}
+
+// ListPropertyHostnames returns the hostnames of the property on the network with their certificate status,
+// the settings of the hostnames on the network are returned in the fields of papi.Hostname
+func (c *hostnameBucketsClient) ListPropertyHostnames(ctx context.Context, params propertyHostnamesRequest) ([]papi.Hostname, error) {
+	var hostnames []papi.Hostname
+	for offset := 0; ; offset += hostnamesPageSize {
+		uri := params.path("/hostnames")
+		q := uri.Query()
+		q.Add("network", string(params.Network))
+		q.Add("includeCertStatus", "true")
+		q.Add("offset", strconv.Itoa(offset))
+		q.Add("limit", strconv.Itoa(hostnamesPageSize))
+		uri.RawQuery = q.Encode()
+
+		var resp struct {
+			Hostnames struct {
+				Items      []bucketHostname `json:"items"`
+				TotalItems int              `json:"totalItems"`
+			} `json:"hostnames"`
+		}
+		if err := c.exec(ctx, http.MethodGet, uri.String(), http.StatusOK, &resp); err != nil {
+			return nil, fmt.Errorf("list property hostnames: %w", err)
+		}
+		for _, h := range resp.Hostnames.Items {
+			hostnames = append(hostnames, h.onNetwork(params.Network))
+		}
+		if len(resp.Hostnames.Items) < hostnamesPageSize || len(hostnames) >= resp.Hostnames.TotalItems {
+			return hostnames, nil
+		}
+	}
+}
+
+// PatchPropertyHostnames adds and removes hostnames of the property on the network, and returns the ID of the
+// hostname activation which applies the change
+func (c *hostnameBucketsClient) PatchPropertyHostnames(ctx context.Context, params patchPropertyHostnamesRequest) (string, error) {
+	type add struct {
+		CnameType            papi.HostnameCnameType `json:"cnameType"`
+		EdgeHostnameID       string                 `json:"edgeHostnameId"`
+		CnameFrom            string                 `json:"cnameFrom"`
+		CertProvisioningType string                 `json:"certProvisioningType"`
+	}
+	body := struct {
+		Network      papi.ActivationNetwork `json:"network"`
+		Add          []add                  `json:"add,omitempty"`
+		Remove       []string               `json:"remove,omitempty"`
+		Note         string                 `json:"note,omitempty"`
+		NotifyEmails []string               `json:"notifyEmails,omitempty"`
+	}{
+		Network:      params.Network,
+		Remove:       params.Remove,
+		Note:         params.Note,
+		NotifyEmails: params.NotifyEmails,
+	}
+	for _, h := range params.Add {
+		body.Add = append(body.Add, add{
+			CnameType:            h.CnameType,
+			EdgeHostnameID:       h.EdgeHostnameID,
+			CnameFrom:            h.CnameFrom,
+			CertProvisioningType: h.CertProvisioningType,
+		})
+	}
+
+	uri := params.path("/hostnames")
+	var resp struct {
+		ActivationLink string `json:"activationLink"`
+	}
+	if err := c.exec(ctx, http.MethodPatch, uri.String(), http.StatusAccepted, &resp, body); err != nil {
+		return "", fmt.Errorf("patch property hostnames: %w", err)
+	}
+	return papi.ResponseLinkParse(resp.ActivationLink)
+}
+
+func (c *hostnameBucketsClient) GetHostnameActivation(ctx context.Context, params hostnameActivationRequest) (*hostnameActivation, error) {
+	uri := params.path("/hostname-activations/%s", params.HostnameActivationID)
+	var resp struct {
+		HostnameActivations struct {
+			Items []hostnameActivation `json:"items"`
+		} `json:"hostnameActivations"`
+	}
+	if err := c.exec(ctx, http.MethodGet, uri.String(), http.StatusOK, &resp); err != nil {
+		return nil, fmt.Errorf("get hostname activation: %w", err)
+	}
+	if len(resp.HostnameActivations.Items) == 0 {
+		return nil, fmt.Errorf("%w: %s of %s", ErrHostnameActivationNotFound, params.HostnameActivationID, params.PropertyID)
+	}
+	return &resp.HostnameActivations.Items[0], nil
+}
+
+func (c *hostnameBucketsClient) exec(ctx context.Context, method, uri string, status int, out interface{}, in ...interface{}) error {
+	return execAPI(ctx, c.Session, method, uri, papiHeader, status, out, in...)
+}
+
+// onNetwork returns the hostname with its settings on the given network
+func (h bucketHostname) onNetwork(network papi.ActivationNetwork) papi.Hostname {
+	hostname := papi.Hostname{
+		CnameFrom:  h.CnameFrom,
+		CnameType:  h.CnameType,
+		CertStatus: h.CertStatus,
+	}
+	if network == papi.ActivationNetworkProduction {
+		hostname.EdgeHostnameID, hostname.CnameTo, hostname.CertProvisioningType = h.ProductionEdgeHostnameID, h.ProductionCnameTo, h.ProductionCertType
+	} else {
+		hostname.EdgeHostnameID, hostname.CnameTo, hostname.CertProvisioningType = h.StagingEdgeHostnameID, h.StagingCnameTo, h.StagingCertType
+	}
+	if hostname.CnameType == "" {
+		hostname.CnameType = papi.HostnameCnameTypeEdgeHostname
+	}
+	return hostname
+}
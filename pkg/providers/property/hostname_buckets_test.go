@@ -0,0 +1,239 @@
+package property
+
+import (
+	"context"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v2/pkg/papi"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/tj/assert"
+)
+
+func mockHostnameBucketsClient(t *testing.T, mockServer *httptest.Server) hostnameBuckets {
+	return newHostnameBucketsClient(mockIncludesClient(t, mockServer).(*includesClient).Session)
+}
+
+var testHostnamesRequest = propertyHostnamesRequest{
+	PropertyID: "prp_1",
+	ContractID: "ctr_1",
+	GroupID:    "grp_1",
+	Network:    papi.ActivationNetworkProduction,
+}
+
+func TestHasHostnameBuckets(t *testing.T) {
+	tests := map[string]struct {
+		responseBody string
+		expected     bool
+		withError    error
+	}{
+		"hostname bucket property": {
+			responseBody: `{"properties": {"items": [{"propertyId": "prp_1", "propertyType": "HOSTNAME_BUCKET"}]}}`,
+			expected:     true,
+		},
+		"traditional property": {
+			responseBody: `{"properties": {"items": [{"propertyId": "prp_1", "propertyType": "TRADITIONAL"}]}}`,
+		},
+		"no property": {
+			responseBody: `{"properties": {"items": []}}`,
+			withError:    ErrPropertyNotFound,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			mockServer := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				assert.Equal(t, "/papi/v1/properties/prp_1?contractId=ctr_1&groupId=grp_1", r.URL.String())
+				assert.Equal(t, http.MethodGet, r.Method)
+				w.WriteHeader(http.StatusOK)
+				_, err := w.Write([]byte(test.responseBody))
+				assert.NoError(t, err)
+			}))
+			defer mockServer.Close()
+			client := mockHostnameBucketsClient(t, mockServer)
+
+			enabled, err := client.HasHostnameBuckets(context.Background(), testHostnamesRequest)
+			if test.withError != nil {
+				assert.True(t, errors.Is(err, test.withError), "want: %s; got: %s", test.withError, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, test.expected, enabled)
+		})
+	}
+}
+
+func TestListPropertyHostnames(t *testing.T) {
+	mockServer := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/papi/v1/properties/prp_1/hostnames?contractId=ctr_1&groupId=grp_1&includeCertStatus=true&limit=999&network=PRODUCTION&offset=0", r.URL.String())
+		assert.Equal(t, http.MethodGet, r.Method)
+		assert.Equal(t, "true", r.Header.Get("PAPI-Use-Prefixes"))
+		w.WriteHeader(http.StatusOK)
+		_, err := w.Write([]byte(`{"hostnames": {"items": [{"cnameFrom": "www.example.com", "cnameType": "EDGE_HOSTNAME",
+			"stagingEdgeHostnameId": "ehn_1", "stagingCnameTo": "www.example.com.edgesuite.net", "stagingCertType": "DEFAULT",
+			"productionEdgeHostnameId": "ehn_2", "productionCnameTo": "www.example.com.edgekey.net", "productionCertType": "CPS_MANAGED",
+			"certStatus": {"validationCname": {"hostname": "_acme-challenge.www.example.com", "target": "ac.1.example.com"},
+			"production": [{"status": "PENDING"}]}}], "totalItems": 1}}`))
+		assert.NoError(t, err)
+	}))
+	defer mockServer.Close()
+	client := mockHostnameBucketsClient(t, mockServer)
+
+	hostnames, err := client.ListPropertyHostnames(context.Background(), testHostnamesRequest)
+	require.NoError(t, err)
+	assert.Equal(t, []papi.Hostname{{
+		CnameFrom:            "www.example.com",
+		CnameType:            papi.HostnameCnameTypeEdgeHostname,
+		EdgeHostnameID:       "ehn_2",
+		CnameTo:              "www.example.com.edgekey.net",
+		CertProvisioningType: "CPS_MANAGED",
+		CertStatus: papi.CertStatusItem{
+			ValidationCname: papi.ValidationCname{Hostname: "_acme-challenge.www.example.com", Target: "ac.1.example.com"},
+			Production:      []papi.StatusItem{{Status: "PENDING"}},
+		},
+	}}, hostnames)
+}
+
+func TestPatchPropertyHostnames(t *testing.T) {
+	tests := map[string]struct {
+		responseStatus   int
+		responseBody     string
+		expectedID       string
+		withError        bool
+		expectedErrTitle string
+	}{
+		"202 accepted": {
+			responseStatus: http.StatusAccepted,
+			responseBody:   `{"activationLink": "/papi/v1/properties/prp_1/hostname-activations/atv_1?contractId=ctr_1&groupId=grp_1"}`,
+			expectedID:     "atv_1",
+		},
+		"400 bad request": {
+			responseStatus:   http.StatusBadRequest,
+			responseBody:     `{"type": "not-a-hostname-bucket", "title": "Not a hostname bucket property", "status": 400}`,
+			withError:        true,
+			expectedErrTitle: "Not a hostname bucket property",
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			mockServer := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				assert.Equal(t, "/papi/v1/properties/prp_1/hostnames?contractId=ctr_1&groupId=grp_1", r.URL.String())
+				assert.Equal(t, http.MethodPatch, r.Method)
+				body, err := ioutil.ReadAll(r.Body)
+				require.NoError(t, err)
+				assert.JSONEq(t, `{"network": "PRODUCTION", "note": "vanity hostnames", "remove": ["old.example.com"],
+					"add": [{"cnameType": "EDGE_HOSTNAME", "edgeHostnameId": "ehn_1", "cnameFrom": "www.example.com", "certProvisioningType": "DEFAULT"}]}`, string(body))
+				w.WriteHeader(test.responseStatus)
+				_, err = w.Write([]byte(test.responseBody))
+				assert.NoError(t, err)
+			}))
+			defer mockServer.Close()
+			client := mockHostnameBucketsClient(t, mockServer)
+
+			id, err := client.PatchPropertyHostnames(context.Background(), patchPropertyHostnamesRequest{
+				propertyHostnamesRequest: testHostnamesRequest,
+				Add: []papi.Hostname{{
+					CnameFrom:            "www.example.com",
+					CnameType:            papi.HostnameCnameTypeEdgeHostname,
+					EdgeHostnameID:       "ehn_1",
+					CertProvisioningType: "DEFAULT",
+				}},
+				Remove: []string{"old.example.com"},
+				Note:   "vanity hostnames",
+			})
+			if test.withError {
+				var e *papi.Error
+				require.True(t, errors.As(err, &e), "want: *papi.Error; got: %s", err)
+				assert.Equal(t, test.responseStatus, e.StatusCode)
+				assert.Equal(t, test.expectedErrTitle, e.Title)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, test.expectedID, id)
+		})
+	}
+}
+
+func TestGetHostnameActivation(t *testing.T) {
+	tests := map[string]struct {
+		responseBody string
+		expected     *hostnameActivation
+		withError    error
+	}{
+		"activation found": {
+			responseBody: `{"hostnameActivations": {"items": [{"hostnameActivationId": "atv_1", "activationType": "ACTIVATE",
+				"network": "PRODUCTION", "status": "ACTIVE", "notifyEmails": ["user@example.com"]}]}}`,
+			expected: &hostnameActivation{
+				HostnameActivationID: "atv_1",
+				ActivationType:       papi.ActivationTypeActivate,
+				Network:              papi.ActivationNetworkProduction,
+				Status:               papi.ActivationStatusActive,
+				NotifyEmails:         []string{"user@example.com"},
+			},
+		},
+		"no activation": {
+			responseBody: `{"hostnameActivations": {"items": []}}`,
+			withError:    ErrHostnameActivationNotFound,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			mockServer := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				assert.Equal(t, "/papi/v1/properties/prp_1/hostname-activations/atv_1?contractId=ctr_1&groupId=grp_1", r.URL.String())
+				assert.Equal(t, http.MethodGet, r.Method)
+				w.WriteHeader(http.StatusOK)
+				_, err := w.Write([]byte(test.responseBody))
+				assert.NoError(t, err)
+			}))
+			defer mockServer.Close()
+			client := mockHostnameBucketsClient(t, mockServer)
+
+			activation, err := client.GetHostnameActivation(context.Background(), hostnameActivationRequest{
+				propertyHostnamesRequest: testHostnamesRequest,
+				HostnameActivationID:     "atv_1",
+			})
+			if test.withError != nil {
+				assert.True(t, errors.Is(err, test.withError), "want: %s; got: %s", test.withError, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, test.expected, activation)
+		})
+	}
+}
+
+type mockhostnamebuckets struct {
+	mock.Mock
+}
+
+func (m *mockhostnamebuckets) HasHostnameBuckets(ctx context.Context, r propertyHostnamesRequest) (bool, error) {
+	args := m.Called(ctx, r)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *mockhostnamebuckets) ListPropertyHostnames(ctx context.Context, r propertyHostnamesRequest) ([]papi.Hostname, error) {
+	args := m.Called(ctx, r)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]papi.Hostname), args.Error(1)
+}
+
+func (m *mockhostnamebuckets) PatchPropertyHostnames(ctx context.Context, r patchPropertyHostnamesRequest) (string, error) {
+	args := m.Called(ctx, r)
+	return args.String(0), args.Error(1)
+}
+
+func (m *mockhostnamebuckets) GetHostnameActivation(ctx context.Context, r hostnameActivationRequest) (*hostnameActivation, error) {
+	args := m.Called(ctx, r)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*hostnameActivation), args.Error(1)
+}
@@ -0,0 +1,289 @@
+package property
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v2/pkg/papi"
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v2/pkg/session"
+)
+
+type (
+	// includes is the client of the PAPI include endpoints, which the PAPI client in use does not support.
+	// Requests and responses follow the PAPI client, so that errors are reported as *papi.Error.
+	includes interface {
+		CreateInclude(context.Context, createIncludeRequest) (string, error)
+		GetInclude(context.Context, includeRequest) (*include, error)
+		DeleteInclude(context.Context, includeRequest) error
+		CreateIncludeVersion(context.Context, includeVersionRequest) (int, error)
+		GetIncludeVersion(context.Context, includeVersionRequest) (*includeVersion, error)
+		GetIncludeRuleTree(context.Context, includeVersionRequest) (*includeRuleTree, error)
+		UpdateIncludeRuleTree(context.Context, updateIncludeRuleTreeRequest) (*includeRuleTree, error)
+		CreateIncludeActivation(context.Context, createIncludeActivationRequest) (string, error)
+		GetIncludeActivation(context.Context, includeActivationRequest) (*includeActivation, error)
+		ListIncludeActivations(context.Context, includeRequest) ([]includeActivation, error)
+		ListIncludeParents(context.Context, includeRequest) ([]includeParent, error)
+	}
+
+	includesClient struct {
+		session.Session
+	}
+
+	// includeRequest identifies an include
+	includeRequest struct {
+		IncludeID  string
+		ContractID string
+		GroupID    string
+	}
+
+	// includeVersionRequest identifies a version of an include
+	includeVersionRequest struct {
+		includeRequest
+		Version int
+	}
+
+	createIncludeRequest struct {
+		ContractID  string `json:"-"`
+		GroupID     string `json:"-"`
+		IncludeName string `json:"includeName"`
+		IncludeType string `json:"includeType"`
+		ProductID   string `json:"productId"`
+		RuleFormat  string `json:"ruleFormat,omitempty"`
+	}
+
+	updateIncludeRuleTreeRequest struct {
+		includeVersionRequest
+		Rules papi.RulesUpdate
+	}
+
+	createIncludeActivationRequest struct {
+		includeRequest
+		Activation includeActivation
+	}
+
+	includeActivationRequest struct {
+		includeRequest
+		ActivationID string
+	}
+
+	include struct {
+		IncludeID         string `json:"includeId"`
+		IncludeName       string `json:"includeName"`
+		IncludeType       string `json:"includeType"`
+		ContractID        string `json:"contractId"`
+		GroupID           string `json:"groupId"`
+		LatestVersion     int    `json:"latestVersion"`
+		StagingVersion    *int   `json:"stagingVersion"`
+		ProductionVersion *int   `json:"productionVersion"`
+	}
+
+	includeVersion struct {
+		IncludeVersion   int                `json:"includeVersion"`
+		ProductID        string             `json:"productId"`
+		StagingStatus    papi.VersionStatus `json:"stagingStatus"`
+		ProductionStatus papi.VersionStatus `json:"productionStatus"`
+		Note             string             `json:"note"`
+		UpdatedByUser    string             `json:"updatedByUser"`
+		UpdatedDate      string             `json:"updatedDate"`
+	}
+
+	includeRuleTree struct {
+		IncludeID      string        `json:"includeId"`
+		IncludeName    string        `json:"includeName"`
+		IncludeType    string        `json:"includeType"`
+		IncludeVersion int           `json:"includeVersion"`
+		RuleFormat     string        `json:"ruleFormat"`
+		Rules          papi.Rules    `json:"rules"`
+		Comments       string        `json:"comments,omitempty"`
+		Errors         []*papi.Error `json:"errors,omitempty"`
+		Warnings       []*papi.Error `json:"warnings,omitempty"`
+	}
+
+	includeActivation struct {
+		ActivationID           string                 `json:"activationId,omitempty"`
+		ActivationType         papi.ActivationType    `json:"activationType"`
+		IncludeVersion         int                    `json:"includeVersion"`
+		Network                papi.ActivationNetwork `json:"network"`
+		Status                 papi.ActivationStatus  `json:"status,omitempty"`
+		Note                   string                 `json:"note,omitempty"`
+		NotifyEmails           []string               `json:"notifyEmails"`
+		AcknowledgeAllWarnings bool                   `json:"acknowledgeAllWarnings"`
+		SubmitDate             string                 `json:"submitDate,omitempty"`
+		UpdateDate             string                 `json:"updateDate,omitempty"`
+	}
+
+	includeParent struct {
+		PropertyID                       string `json:"propertyId"`
+		PropertyName                     string `json:"propertyName"`
+		ContractID                       string `json:"contractId"`
+		GroupID                          string `json:"groupId"`
+		StagingVersion                   *int   `json:"stagingVersion"`
+		ProductionVersion                *int   `json:"productionVersion"`
+		IsIncludeUsedInStagingVersion    bool   `json:"isIncludeUsedInStagingVersion"`
+		IsIncludeUsedInProductionVersion bool   `json:"isIncludeUsedInProductionVersion"`
+	}
+)
+
+var (
+	// ErrIncludeNotFound is returned when the include is not found
+	ErrIncludeNotFound = errors.New("include not found")
+)
+
+// newIncludesClient returns the client of the PAPI include endpoints
+func newIncludesClient(sess session.Session) includes {
+	return &includesClient{Session: sess}
+}
+
+func (r includeRequest) path(format string, args ...interface{}) string {
+	uri := url.URL{Path: fmt.Sprintf("/papi/v1/includes/%s", r.IncludeID) + fmt.Sprintf(format, args...)}
+	q := uri.Query()
+	q.Add("contractId", r.ContractID)
+	q.Add("groupId", r.GroupID)
+	uri.RawQuery = q.Encode()
+	return uri.String()
+}
+
+func (c *includesClient) CreateInclude(ctx context.Context, params createIncludeRequest) (string, error) {
+	uri := url.URL{Path: "/papi/v1/includes"}
+	q := uri.Query()
+	q.Add("contractId", params.ContractID)
+	q.Add("groupId", params.GroupID)
+	uri.RawQuery = q.Encode()
+
+	var created struct {
+		IncludeLink string `json:"includeLink"`
+	}
+	if err := c.exec(ctx, http.MethodPost, uri.String(), http.StatusCreated, &created, params); err != nil {
+		return "", fmt.Errorf("create include: %w", err)
+	}
+	return papi.ResponseLinkParse(created.IncludeLink)
+}
+
+func (c *includesClient) GetInclude(ctx context.Context, params includeRequest) (*include, error) {
+	var resp struct {
+		Includes struct {
+			Items []include `json:"items"`
+		} `json:"includes"`
+	}
+	if err := c.exec(ctx, http.MethodGet, params.path(""), http.StatusOK, &resp); err != nil {
+		return nil, fmt.Errorf("get include: %w", err)
+	}
+	if len(resp.Includes.Items) == 0 {
+		return nil, fmt.Errorf("%w: %s", ErrIncludeNotFound, params.IncludeID)
+	}
+	return &resp.Includes.Items[0], nil
+}
+
+func (c *includesClient) DeleteInclude(ctx context.Context, params includeRequest) error {
+	if err := c.exec(ctx, http.MethodDelete, params.path(""), http.StatusOK, nil); err != nil {
+		return fmt.Errorf("delete include: %w", err)
+	}
+	return nil
+}
+
+func (c *includesClient) CreateIncludeVersion(ctx context.Context, params includeVersionRequest) (int, error) {
+	body := struct {
+		CreateFromVersion int `json:"createFromVersion"`
+	}{params.Version}
+	var created struct {
+		VersionLink string `json:"versionLink"`
+	}
+	if err := c.exec(ctx, http.MethodPost, params.path("/versions"), http.StatusCreated, &created, body); err != nil {
+		return 0, fmt.Errorf("create include version: %w", err)
+	}
+	version, err := papi.ResponseLinkParse(created.VersionLink)
+	if err != nil {
+		return 0, fmt.Errorf("create include version: %w: %s", papi.ErrInvalidResponseLink, err)
+	}
+	return strconv.Atoi(version)
+}
+
+func (c *includesClient) GetIncludeVersion(ctx context.Context, params includeVersionRequest) (*includeVersion, error) {
+	var resp struct {
+		Versions struct {
+			Items []includeVersion `json:"items"`
+		} `json:"versions"`
+	}
+	if err := c.exec(ctx, http.MethodGet, params.path("/versions/%d", params.Version), http.StatusOK, &resp); err != nil {
+		return nil, fmt.Errorf("get include version: %w", err)
+	}
+	if len(resp.Versions.Items) == 0 {
+		return nil, fmt.Errorf("%w: version %d of %s", ErrIncludeNotFound, params.Version, params.IncludeID)
+	}
+	return &resp.Versions.Items[0], nil
+}
+
+func (c *includesClient) GetIncludeRuleTree(ctx context.Context, params includeVersionRequest) (*includeRuleTree, error) {
+	uri := params.path("/versions/%d/rules", params.Version) + "&validateRules=true&validateMode=" + papi.RuleValidateModeFull
+	var rules includeRuleTree
+	if err := c.exec(ctx, http.MethodGet, uri, http.StatusOK, &rules); err != nil {
+		return nil, fmt.Errorf("get include rule tree: %w", err)
+	}
+	return &rules, nil
+}
+
+func (c *includesClient) UpdateIncludeRuleTree(ctx context.Context, params updateIncludeRuleTreeRequest) (*includeRuleTree, error) {
+	uri := params.path("/versions/%d/rules", params.Version) + "&validateRules=true"
+	var rules includeRuleTree
+	if err := c.exec(ctx, http.MethodPut, uri, http.StatusOK, &rules, params.Rules); err != nil {
+		return nil, fmt.Errorf("update include rule tree: %w", err)
+	}
+	return &rules, nil
+}
+
+func (c *includesClient) CreateIncludeActivation(ctx context.Context, params createIncludeActivationRequest) (string, error) {
+	var created struct {
+		ActivationLink string `json:"activationLink"`
+	}
+	if err := c.exec(ctx, http.MethodPost, params.path("/activations"), http.StatusCreated, &created, params.Activation); err != nil {
+		return "", fmt.Errorf("create include activation: %w", err)
+	}
+	return papi.ResponseLinkParse(created.ActivationLink)
+}
+
+func (c *includesClient) GetIncludeActivation(ctx context.Context, params includeActivationRequest) (*includeActivation, error) {
+	var resp struct {
+		Activations struct {
+			Items []includeActivation `json:"items"`
+		} `json:"activations"`
+	}
+	if err := c.exec(ctx, http.MethodGet, params.path("/activations/%s", params.ActivationID), http.StatusOK, &resp); err != nil {
+		return nil, fmt.Errorf("get include activation: %w", err)
+	}
+	if len(resp.Activations.Items) == 0 {
+		return nil, fmt.Errorf("%w: activation %s of %s", ErrIncludeNotFound, params.ActivationID, params.IncludeID)
+	}
+	return &resp.Activations.Items[0], nil
+}
+
+func (c *includesClient) ListIncludeActivations(ctx context.Context, params includeRequest) ([]includeActivation, error) {
+	var resp struct {
+		Activations struct {
+			Items []includeActivation `json:"items"`
+		} `json:"activations"`
+	}
+	if err := c.exec(ctx, http.MethodGet, params.path("/activations"), http.StatusOK, &resp); err != nil {
+		return nil, fmt.Errorf("list include activations: %w", err)
+	}
+	return resp.Activations.Items, nil
+}
+
+func (c *includesClient) ListIncludeParents(ctx context.Context, params includeRequest) ([]includeParent, error) {
+	var resp struct {
+		Properties struct {
+			Items []includeParent `json:"items"`
+		} `json:"properties"`
+	}
+	if err := c.exec(ctx, http.MethodGet, params.path("/parents"), http.StatusOK, &resp); err != nil {
+		return nil, fmt.Errorf("list include parents: %w", err)
+	}
+	return resp.Properties.Items, nil
+}
+
+func (c *includesClient) exec(ctx context.Context, method, uri string, status int, out interface{}, in ...interface{}) error {
+	return execAPI(ctx, c.Session, method, uri, papiHeader, status, out, in...)
+}
@@ -0,0 +1,257 @@
+package property
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v2/pkg/edgegrid"
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v2/pkg/papi"
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v2/pkg/session"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/tj/assert"
+
+	"github.com/akamai/terraform-provider-akamai/v2/pkg/tools"
+)
+
+func mockIncludesClient(t *testing.T, mockServer *httptest.Server) includes {
+	serverURL, err := url.Parse(mockServer.URL)
+	require.NoError(t, err)
+	certPool := x509.NewCertPool()
+	certPool.AddCert(mockServer.Certificate())
+	httpClient := &http.Client{
+		Transport: &http.Transport{
+			TLSClientConfig: &tls.Config{
+				RootCAs: certPool,
+			},
+		},
+	}
+	s, err := session.New(session.WithClient(httpClient), session.WithSigner(&edgegrid.Config{Host: serverURL.Host}))
+	require.NoError(t, err)
+	return newIncludesClient(s)
+}
+
+func TestCreateInclude(t *testing.T) {
+	tests := map[string]struct {
+		responseStatus   int
+		responseBody     string
+		expectedPath     string
+		expectedBody     string
+		expectedID       string
+		withError        bool
+		expectedErrTitle string
+	}{
+		"201 created": {
+			responseStatus: http.StatusCreated,
+			responseBody:   `{"includeLink": "/papi/v1/includes/inc_1?contractId=ctr_1&groupId=grp_1"}`,
+			expectedPath:   "/papi/v1/includes?contractId=ctr_1&groupId=grp_1",
+			expectedBody:   `{"includeName":"shared","includeType":"MICROSERVICES","productId":"prd_Web_App_Accel","ruleFormat":"v2021-05-05"}`,
+			expectedID:     "inc_1",
+		},
+		"403 forbidden": {
+			responseStatus:   http.StatusForbidden,
+			responseBody:     `{"type": "forbidden", "title": "Forbidden", "status": 403}`,
+			expectedPath:     "/papi/v1/includes?contractId=ctr_1&groupId=grp_1",
+			expectedBody:     `{"includeName":"shared","includeType":"MICROSERVICES","productId":"prd_Web_App_Accel","ruleFormat":"v2021-05-05"}`,
+			withError:        true,
+			expectedErrTitle: "Forbidden",
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			mockServer := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				assert.Equal(t, test.expectedPath, r.URL.String())
+				assert.Equal(t, http.MethodPost, r.Method)
+				assert.Equal(t, "true", r.Header.Get("PAPI-Use-Prefixes"))
+				body, err := ioutil.ReadAll(r.Body)
+				require.NoError(t, err)
+				assert.JSONEq(t, test.expectedBody, string(body))
+				w.WriteHeader(test.responseStatus)
+				_, err = w.Write([]byte(test.responseBody))
+				assert.NoError(t, err)
+			}))
+			defer mockServer.Close()
+			client := mockIncludesClient(t, mockServer)
+
+			id, err := client.CreateInclude(context.Background(), createIncludeRequest{
+				ContractID:  "ctr_1",
+				GroupID:     "grp_1",
+				IncludeName: "shared",
+				IncludeType: IncludeTypeMicroservices,
+				ProductID:   "prd_Web_App_Accel",
+				RuleFormat:  "v2021-05-05",
+			})
+			if test.withError {
+				var e *papi.Error
+				require.True(t, errors.As(err, &e), "want: *papi.Error; got: %s", err)
+				assert.Equal(t, test.responseStatus, e.StatusCode)
+				assert.Equal(t, test.expectedErrTitle, e.Title)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, test.expectedID, id)
+		})
+	}
+}
+
+func TestGetInclude(t *testing.T) {
+	tests := map[string]struct {
+		responseBody string
+		expected     *include
+		withError    error
+	}{
+		"include found": {
+			responseBody: `{"includes": {"items": [{"includeId": "inc_1", "includeName": "shared", "includeType": "MICROSERVICES",
+				"contractId": "ctr_1", "groupId": "grp_1", "latestVersion": 2, "stagingVersion": 1, "productionVersion": null}]}}`,
+			expected: &include{
+				IncludeID:      "inc_1",
+				IncludeName:    "shared",
+				IncludeType:    IncludeTypeMicroservices,
+				ContractID:     "ctr_1",
+				GroupID:        "grp_1",
+				LatestVersion:  2,
+				StagingVersion: tools.IntPtr(1),
+			},
+		},
+		"no include": {
+			responseBody: `{"includes": {"items": []}}`,
+			withError:    ErrIncludeNotFound,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			mockServer := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				assert.Equal(t, "/papi/v1/includes/inc_1?contractId=ctr_1&groupId=grp_1", r.URL.String())
+				assert.Equal(t, http.MethodGet, r.Method)
+				w.WriteHeader(http.StatusOK)
+				_, err := w.Write([]byte(test.responseBody))
+				assert.NoError(t, err)
+			}))
+			defer mockServer.Close()
+			client := mockIncludesClient(t, mockServer)
+
+			inc, err := client.GetInclude(context.Background(), includeRequest{IncludeID: "inc_1", ContractID: "ctr_1", GroupID: "grp_1"})
+			if test.withError != nil {
+				assert.True(t, errors.Is(err, test.withError), "want: %s; got: %s", test.withError, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, test.expected, inc)
+		})
+	}
+}
+
+func TestUpdateIncludeRuleTree(t *testing.T) {
+	mockServer := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/papi/v1/includes/inc_1/versions/2/rules?contractId=ctr_1&groupId=grp_1&validateRules=true", r.URL.String())
+		assert.Equal(t, http.MethodPut, r.Method)
+		assert.Equal(t, "application/vnd.akamai.papirules.v2021-05-05+json", r.Header.Get("Content-Type"))
+		w.WriteHeader(http.StatusOK)
+		_, err := w.Write([]byte(`{"includeId": "inc_1", "includeVersion": 2, "ruleFormat": "v2021-05-05", "rules": {"name": "default"},
+			"errors": [{"type": "error", "title": "Missing origin", "errorLocation": "#/rules/behaviors/0"}]}`))
+		assert.NoError(t, err)
+	}))
+	defer mockServer.Close()
+	client := mockIncludesClient(t, mockServer)
+
+	request := includeVersionRequest{
+		includeRequest: includeRequest{IncludeID: "inc_1", ContractID: "ctr_1", GroupID: "grp_1"},
+		Version:        2,
+	}
+	err := updateIncludeRules(context.Background(), client, request, `{"rules": {"name": "default"}}`, "v2021-05-05")
+	require.NoError(t, err)
+
+	t.Run("invalid rules", func(t *testing.T) {
+		err := updateIncludeRules(context.Background(), client, request, `{"rules":`, "v2021-05-05")
+		assert.Error(t, err)
+	})
+}
+
+type mockincludes struct {
+	mock.Mock
+}
+
+func (m *mockincludes) CreateInclude(ctx context.Context, r createIncludeRequest) (string, error) {
+	args := m.Called(ctx, r)
+	return args.String(0), args.Error(1)
+}
+
+func (m *mockincludes) GetInclude(ctx context.Context, r includeRequest) (*include, error) {
+	args := m.Called(ctx, r)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*include), args.Error(1)
+}
+
+func (m *mockincludes) DeleteInclude(ctx context.Context, r includeRequest) error {
+	args := m.Called(ctx, r)
+	return args.Error(0)
+}
+
+func (m *mockincludes) CreateIncludeVersion(ctx context.Context, r includeVersionRequest) (int, error) {
+	args := m.Called(ctx, r)
+	return args.Int(0), args.Error(1)
+}
+
+func (m *mockincludes) GetIncludeVersion(ctx context.Context, r includeVersionRequest) (*includeVersion, error) {
+	args := m.Called(ctx, r)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*includeVersion), args.Error(1)
+}
+
+func (m *mockincludes) GetIncludeRuleTree(ctx context.Context, r includeVersionRequest) (*includeRuleTree, error) {
+	args := m.Called(ctx, r)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*includeRuleTree), args.Error(1)
+}
+
+func (m *mockincludes) UpdateIncludeRuleTree(ctx context.Context, r updateIncludeRuleTreeRequest) (*includeRuleTree, error) {
+	args := m.Called(ctx, r)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*includeRuleTree), args.Error(1)
+}
+
+func (m *mockincludes) CreateIncludeActivation(ctx context.Context, r createIncludeActivationRequest) (string, error) {
+	args := m.Called(ctx, r)
+	return args.String(0), args.Error(1)
+}
+
+func (m *mockincludes) GetIncludeActivation(ctx context.Context, r includeActivationRequest) (*includeActivation, error) {
+	args := m.Called(ctx, r)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*includeActivation), args.Error(1)
+}
+
+func (m *mockincludes) ListIncludeActivations(ctx context.Context, r includeRequest) ([]includeActivation, error) {
+	args := m.Called(ctx, r)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]includeActivation), args.Error(1)
+}
+
+func (m *mockincludes) ListIncludeParents(ctx context.Context, r includeRequest) ([]includeParent, error) {
+	args := m.Called(ctx, r)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]includeParent), args.Error(1)
+}
@@ -59,6 +59,12 @@ var (
 	ErrPropertyNotFound = errors.New("property not found")
 	// ErrRulesNotFound is returned when no rules were found
 	ErrRulesNotFound = errors.New("property rules not found")
+	// ErrParentRuleNotFound is returned when the parent path of a child rule is not in the rule tree
+	ErrParentRuleNotFound = errors.New("parent rule not found")
+	// ErrRulesNotConverted is returned when the rules in the configuration are not converted to the rule format of the property
+	ErrRulesNotConverted = errors.New("rules are not converted to rule format")
+	// ErrAmbiguousRulePath is returned when a rule path goes through sibling rules with the same name
+	ErrAmbiguousRulePath = errors.New("rule path is ambiguous")
 
 	// PAPI property version errors
 
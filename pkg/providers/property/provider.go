@@ -21,7 +21,13 @@ type (
 	provider struct {
 		*schema.Provider
 
-		client papi.PAPI
+		client          papi.PAPI
+		includes        includes
+		hostnameBuckets hostnameBuckets
+		hapi            edgeHostnamesHAPI
+
+		// rulesSchemaDir is the directory of the rule format schemas used instead of the API
+		rulesSchemaDir string
 	}
 
 	// Option is a papi provider option
@@ -70,28 +76,45 @@ func Provider() *schema.Provider {
 				MaxItems:   1,
 				Deprecated: akamai.NoticeDeprecatedUseAlias("property"),
 			},
+			"rules_schema_dir": {
+				Optional:    true,
+				Type:        schema.TypeString,
+				Description: "Directory of rule format schemas, as <product_id>/<rule_format>.json, used to validate rules at plan time instead of fetching the schemas from the API",
+			},
 		},
 		DataSourcesMap: map[string]*schema.Resource{
-			"akamai_contract":                dataSourcePropertyContract(),
-			"akamai_contracts":               dataSourceAkamaiContracts(),
-			"akamai_cp_code":                 dataSourceCPCode(),
-			"akamai_group":                   dataSourcePropertyGroup(),
-			"akamai_groups":                  dataSourcePropertyMultipleGroups(),
-			"akamai_property_rules":          dataPropertyRules(),
-			"akamai_property_rule_formats":   dataPropertyRuleFormats(),
-			"akamai_property":                dataSourceAkamaiProperty(),
-			"akamai_property_rules_template": dataSourcePropertyRulesTemplate(),
-			"akamai_properties":              dataSourceAkamaiProperties(),
-			"akamai_property_products":       dataSourceAkamaiPropertyProducts(),
-			"akamai_property_hostnames":      dataSourceAkamaiPropertyHostnames(),
-			"akamai_properties_search":       dataSourcePropertiesSearch(),
+			"akamai_contract":                 dataSourcePropertyContract(),
+			"akamai_contracts":                dataSourceAkamaiContracts(),
+			"akamai_cp_code":                  dataSourceCPCode(),
+			"akamai_group":                    dataSourcePropertyGroup(),
+			"akamai_groups":                   dataSourcePropertyMultipleGroups(),
+			"akamai_property_rules":           dataPropertyRules(),
+			"akamai_property_rule_formats":    dataPropertyRuleFormats(),
+			"akamai_property":                 dataSourceAkamaiProperty(),
+			"akamai_property_rules_template":  dataSourcePropertyRulesTemplate(),
+			"akamai_properties":               dataSourceAkamaiProperties(),
+			"akamai_property_products":        dataSourceAkamaiPropertyProducts(),
+			"akamai_property_hostnames":       dataSourceAkamaiPropertyHostnames(),
+			"akamai_edge_hostnames":           dataSourceEdgeHostnames(),
+			"akamai_properties_search":        dataSourcePropertiesSearch(),
+			"akamai_property_activations":     dataSourcePropertyActivations(),
+			"akamai_property_include_rules":   dataSourcePropertyIncludeRules(),
+			"akamai_property_include_parents": dataSourcePropertyIncludeParents(),
+			"akamai_property_rules_builder":   dataSourcePropertyRulesBuilder(),
+			"akamai_property_rules_upgrade":   dataSourcePropertyRulesUpgrade(),
+			"akamai_property_version_diff":    dataSourcePropertyVersionDiff(),
 		},
 		ResourcesMap: map[string]*schema.Resource{
-			"akamai_cp_code":             resourceCPCode(),
-			"akamai_edge_hostname":       resourceSecureEdgeHostName(),
-			"akamai_property":            resourceProperty(),
-			"akamai_property_variables":  resourcePropertyVariables(),
-			"akamai_property_activation": resourcePropertyActivation(),
+			"akamai_cp_code":                     resourceCPCode(),
+			"akamai_edge_hostname":               resourceSecureEdgeHostName(),
+			"akamai_property":                    resourceProperty(),
+			"akamai_property_variables":          resourcePropertyVariables(),
+			"akamai_property_activation":         resourcePropertyActivation(),
+			"akamai_property_fallback":           resourcePropertyFallback(),
+			"akamai_property_include":            resourcePropertyInclude(),
+			"akamai_property_include_activation": resourcePropertyIncludeActivation(),
+			"akamai_property_rule":               resourcePropertyRule(),
+			"akamai_property_hostnames":          resourcePropertyHostnames(),
 		},
 	}
 	return provider
@@ -109,7 +132,31 @@ func (p *provider) Client(meta akamai.OperationMeta) papi.PAPI {
 	if p.client != nil {
 		return p.client
 	}
-	return papi.Client(meta.Session())
+	return papi.Client(complianceRecordSession{meta.SubproviderSession(p)})
+}
+
+// IncludesClient returns the client of the PAPI include endpoints
+func (p *provider) IncludesClient(meta akamai.OperationMeta) includes {
+	if p.includes != nil {
+		return p.includes
+	}
+	return newIncludesClient(meta.SubproviderSession(p))
+}
+
+// HostnameBucketsClient returns the client of the PAPI hostname bucket endpoints
+func (p *provider) HostnameBucketsClient(meta akamai.OperationMeta) hostnameBuckets {
+	if p.hostnameBuckets != nil {
+		return p.hostnameBuckets
+	}
+	return newHostnameBucketsClient(meta.SubproviderSession(p))
+}
+
+// HAPIClient returns the client of the Edge Hostnames API
+func (p *provider) HAPIClient(meta akamai.OperationMeta) edgeHostnamesHAPI {
+	if p.hapi != nil {
+		return p.hapi
+	}
+	return newEdgeHostnamesHAPIClient(meta.SubproviderSession(p))
 }
 
 func getPAPIV1Service(d *schema.ResourceData) error {
@@ -172,6 +219,15 @@ func (p *provider) Configure(log log.Interface, d *schema.ResourceData) diag.Dia
 		return diag.FromErr(err)
 	}
 
+	rulesSchemaDir, err := tools.GetStringValue("rules_schema_dir", d)
+	if err != nil {
+		if !errors.Is(err, tools.ErrNotFound) {
+			return diag.FromErr(err)
+		}
+		return nil
+	}
+	p.rulesSchemaDir = rulesSchemaDir
+
 	return nil
 }
 
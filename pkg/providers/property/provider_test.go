@@ -22,6 +22,8 @@ var testProvider *schema.Provider
 
 func TestMain(m *testing.M) {
 	testProvider = akamai.Provider(Subprovider())()
+	// rules are validated against the test schemas, instead of fetching schemas from the API
+	inst.rulesSchemaDir = "testdata/TestRulesSchema"
 	testAccProviders = map[string]*schema.Provider{
 		"akamai": testProvider,
 	}
@@ -58,6 +60,62 @@ func useClient(client papi.PAPI, f func()) {
 	f()
 }
 
+// useHostnameBucketsClient swaps out the hostname buckets client on the global instance for the duration of the given func
+func useHostnameBucketsClient(client hostnameBuckets, f func()) {
+	clientLock.Lock()
+	orig := inst.hostnameBuckets
+	inst.hostnameBuckets = client
+
+	defer func() {
+		inst.hostnameBuckets = orig
+		clientLock.Unlock()
+	}()
+
+	f()
+}
+
+// useEdgeHostnameClients swaps out the PAPI and HAPI clients on the global instance for the duration of the given func
+func useEdgeHostnameClients(client papi.PAPI, hapiClient edgeHostnamesHAPI, f func()) {
+	useClient(client, func() {
+		orig := inst.hapi
+		inst.hapi = hapiClient
+
+		defer func() {
+			inst.hapi = orig
+		}()
+
+		f()
+	})
+}
+
+// useHAPIClient swaps out the HAPI client on the global instance for the duration of the given func
+func useHAPIClient(client edgeHostnamesHAPI, f func()) {
+	clientLock.Lock()
+	orig := inst.hapi
+	inst.hapi = client
+
+	defer func() {
+		inst.hapi = orig
+		clientLock.Unlock()
+	}()
+
+	f()
+}
+
+// useIncludesClient swaps out the includes client on the global instance for the duration of the given func
+func useIncludesClient(client includes, f func()) {
+	clientLock.Lock()
+	orig := inst.includes
+	inst.includes = client
+
+	defer func() {
+		inst.includes = orig
+		clientLock.Unlock()
+	}()
+
+	f()
+}
+
 // TODO marks a test as being in a "pending" state and logs a message telling the user why. Such tests are expected to
 // fail for the time being and may exist for the sake of unfinished/future features or to document known buggy cases
 // that won't be fixed right away. The failure of a pending test is not considered an error and the test will therefore
@@ -44,11 +44,12 @@ func resourceCPCode() *schema.Resource {
 				StateFunc:  addPrefixToState("ctr_"),
 			},
 			"contract_id": {
-				Type:         schema.TypeString,
-				Optional:     true,
-				Computed:     true,
-				ExactlyOneOf: []string{"contract_id", "contract"},
-				StateFunc:    addPrefixToState("ctr_"),
+				Type:          schema.TypeString,
+				Optional:      true,
+				Computed:      true,
+				ConflictsWith: []string{"contract"},
+				StateFunc:     addPrefixToState("ctr_"),
+				Description:   "The contract ID of the CP code, the provider default_contract_id is used if not set",
 			},
 			"group": {
 				Type:       schema.TypeString,
@@ -58,11 +59,12 @@ func resourceCPCode() *schema.Resource {
 				StateFunc:  addPrefixToState("grp_"),
 			},
 			"group_id": {
-				Type:         schema.TypeString,
-				Optional:     true,
-				Computed:     true,
-				ExactlyOneOf: []string{"group_id", "group"},
-				StateFunc:    addPrefixToState("grp_"),
+				Type:          schema.TypeString,
+				Optional:      true,
+				Computed:      true,
+				ConflictsWith: []string{"group"},
+				StateFunc:     addPrefixToState("grp_"),
+				Description:   "The group ID of the CP code, the provider default_group_id is used if not set",
 			},
 			"product": {
 				Type:          schema.TypeString,
@@ -103,23 +105,15 @@ func resourceCPCodeCreate(ctx context.Context, d *schema.ResourceData, m interfa
 	}
 	productID = tools.AddPrefix(productID, "prd_")
 
-	// Schema guarantees group_id/group are strings and one or the other is set
-	var groupID string
-	if got, ok := d.GetOk("group_id"); ok {
-		groupID = got.(string)
-	} else {
-		groupID = d.Get("group").(string)
+	groupID, err := akamai.GetGroupID(d, m, "group_id", "group")
+	if err != nil {
+		return diag.FromErr(err)
 	}
-	groupID = tools.AddPrefix(groupID, "grp_")
 
-	// Schema guarantees contract_id/contract are strings and one or the other is set
-	var contractID string
-	if got, ok := d.GetOk("contract_id"); ok {
-		contractID = got.(string)
-	} else {
-		contractID = d.Get("contract").(string)
+	contractID, err := akamai.GetContractID(d, m, "contract_id", "contract")
+	if err != nil {
+		return diag.FromErr(err)
 	}
-	contractID = tools.AddPrefix(contractID, "ctr_")
 
 	// Because CPCodes can't be deleted, we re-use an existing CPCode if it's there
 	cpCode, err := findCPCode(ctx, name, contractID, groupID, meta)
@@ -152,14 +146,10 @@ func resourceCPCodeRead(ctx context.Context, d *schema.ResourceData, m interface
 		name = got.(string)
 	}
 
-	// Schema guarantees group_id/group are strings and one or the other is set
-	var groupID string
-	if got, ok := d.GetOk("group_id"); ok {
-		groupID = got.(string)
-	} else {
-		groupID = d.Get("group").(string)
+	groupID, err := akamai.GetGroupID(d, m, "group_id", "group")
+	if err != nil {
+		return diag.FromErr(err)
 	}
-	groupID = tools.AddPrefix(groupID, "grp_")
 	if err := d.Set("group_id", groupID); err != nil {
 		return diag.FromErr(fmt.Errorf("%w: %s", tools.ErrValueSet, err.Error()))
 	}
@@ -167,14 +157,10 @@ func resourceCPCodeRead(ctx context.Context, d *schema.ResourceData, m interface
 		return diag.FromErr(fmt.Errorf("%w: %s", tools.ErrValueSet, err.Error()))
 	}
 
-	// Schema guarantees contract_id/contract are strings and one or the other is set
-	var contractID string
-	if got, ok := d.GetOk("contract_id"); ok {
-		contractID = got.(string)
-	} else {
-		contractID = d.Get("contract").(string)
+	contractID, err := akamai.GetContractID(d, m, "contract_id", "contract")
+	if err != nil {
+		return diag.FromErr(err)
 	}
-	contractID = tools.AddPrefix(contractID, "ctr_")
 	if err := d.Set("contract_id", contractID); err != nil {
 		return diag.FromErr(fmt.Errorf("%w: %s", tools.ErrValueSet, err.Error()))
 	}
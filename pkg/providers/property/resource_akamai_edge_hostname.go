@@ -5,13 +5,16 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"strconv"
 	"strings"
 
 	"github.com/hashicorp/go-cty/cty"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v2/pkg/hapi"
 	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v2/pkg/papi"
 	"github.com/akamai/terraform-provider-akamai/v2/pkg/akamai"
 	"github.com/akamai/terraform-provider-akamai/v2/pkg/tools"
@@ -21,11 +24,15 @@ func resourceSecureEdgeHostName() *schema.Resource {
 	return &schema.Resource{
 		CreateContext: resourceSecureEdgeHostNameCreate,
 		ReadContext:   resourceSecureEdgeHostNameRead,
+		UpdateContext: resourceSecureEdgeHostNameUpdate,
 		DeleteContext: resourceSecureEdgeHostNameDelete,
 		Importer: &schema.ResourceImporter{
 			StateContext: resourceSecureEdgeHostNameImport,
 		},
 		Schema: akamaiSecureEdgeHostNameSchema,
+		Timeouts: &schema.ResourceTimeout{
+			Default: &PropertyResourceTimeout,
+		},
 	}
 }
 
@@ -52,11 +59,12 @@ var akamaiSecureEdgeHostNameSchema = map[string]*schema.Schema{
 		StateFunc:  addPrefixToState("ctr_"),
 	},
 	"contract_id": {
-		Type:         schema.TypeString,
-		Optional:     true,
-		Computed:     true,
-		ExactlyOneOf: []string{"contract_id", "contract"},
-		StateFunc:    addPrefixToState("ctr_"),
+		Type:          schema.TypeString,
+		Optional:      true,
+		Computed:      true,
+		ConflictsWith: []string{"contract"},
+		StateFunc:     addPrefixToState("ctr_"),
+		Description:   "The contract ID of the edge hostname, the provider default_contract_id is used if not set",
 	},
 	"group": {
 		Type:       schema.TypeString,
@@ -66,11 +74,12 @@ var akamaiSecureEdgeHostNameSchema = map[string]*schema.Schema{
 		StateFunc:  addPrefixToState("grp_"),
 	},
 	"group_id": {
-		Type:         schema.TypeString,
-		Optional:     true,
-		Computed:     true,
-		ExactlyOneOf: []string{"group_id", "group"},
-		StateFunc:    addPrefixToState("grp_"),
+		Type:          schema.TypeString,
+		Optional:      true,
+		Computed:      true,
+		ConflictsWith: []string{"group"},
+		StateFunc:     addPrefixToState("grp_"),
+		Description:   "The group ID of the edge hostname, the provider default_group_id is used if not set",
 	},
 	"edge_hostname": {
 		Type:             schema.TypeString,
@@ -83,7 +92,6 @@ var akamaiSecureEdgeHostNameSchema = map[string]*schema.Schema{
 	"ip_behavior": {
 		Type:     schema.TypeString,
 		Required: true,
-		ForceNew: true,
 		ValidateDiagFunc: func(val interface{}, path cty.Path) diag.Diagnostics {
 			v := val.(string)
 			key := path[len(path)-1].(cty.GetAttrStep).Name
@@ -106,6 +114,26 @@ var akamaiSecureEdgeHostNameSchema = map[string]*schema.Schema{
 		DiffSuppressFunc: suppressEdgeHostnameUseCases,
 		Description:      "A JSON encoded list of use cases",
 	},
+	"ttl": {
+		Type:             schema.TypeInt,
+		Optional:         true,
+		Computed:         true,
+		ValidateDiagFunc: validation.ToDiagFunc(validation.IntAtLeast(1)),
+		Description:      "The time to live of the edge hostname DNS record in seconds, read and changed with the Edge Hostnames API",
+	},
+	"status_update_email": {
+		Type:        schema.TypeList,
+		Optional:    true,
+		Elem:        &schema.Schema{Type: schema.TypeString, ValidateDiagFunc: tools.ValidateEmail},
+		Description: "The email addresses notified of the status of the edge hostname changes and deletion",
+	},
+	"delete_on_destroy": {
+		Type:        schema.TypeBool,
+		Optional:    true,
+		Default:     false,
+		Description: "Whether the edge hostname is deleted with the Edge Hostnames API when the resource is destroyed or replaced, otherwise it is only removed from the state",
+	},
+	akamai.PollIntervalField: akamai.PollIntervalSchema(),
 }
 
 func resourceSecureEdgeHostNameCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
@@ -114,14 +142,10 @@ func resourceSecureEdgeHostNameCreate(ctx context.Context, d *schema.ResourceDat
 
 	client := inst.Client(meta)
 
-	// Schema guarantees group_id/group are strings and one or the other is set
-	var groupID string
-	if got, ok := d.GetOk("group_id"); ok {
-		groupID = got.(string)
-	} else {
-		groupID = d.Get("group").(string)
+	groupID, err := akamai.GetGroupID(d, m, "group_id", "group")
+	if err != nil {
+		return diag.FromErr(err)
 	}
-	groupID = tools.AddPrefix(groupID, "grp_")
 	// set group/groupID into ResourceData
 	if err := d.Set("group_id", groupID); err != nil {
 		return diag.FromErr(fmt.Errorf("%w: %s", tools.ErrValueSet, err.Error()))
@@ -130,14 +154,10 @@ func resourceSecureEdgeHostNameCreate(ctx context.Context, d *schema.ResourceDat
 		return diag.FromErr(fmt.Errorf("%w: %s", tools.ErrValueSet, err.Error()))
 	}
 
-	// Schema guarantees contract_id/contract are strings and one or the other is set
-	var contractID string
-	if got, ok := d.GetOk("contract_id"); ok {
-		contractID = got.(string)
-	} else {
-		contractID = d.Get("contract").(string)
+	contractID, err := akamai.GetContractID(d, m, "contract_id", "contract")
+	if err != nil {
+		return diag.FromErr(err)
 	}
-	contractID = tools.AddPrefix(contractID, "ctr_")
 	// set contract/contract_id into ResourceData
 	if err := d.Set("contract_id", contractID); err != nil {
 		return diag.FromErr(fmt.Errorf("%w: %s", tools.ErrValueSet, err.Error()))
@@ -240,16 +260,92 @@ func resourceSecureEdgeHostNameCreate(ctx context.Context, d *schema.ResourceDat
 		d.SetId(ehnID)
 	}
 	logger.Debugf("Resulting EHN Id: %s ", ehnID)
+
+	var diags diag.Diagnostics
+	if ttl, err := tools.GetIntValue("ttl", d); err == nil {
+		err := patchEdgeHostname(ctx, d, meta, []edgeHostnamePatch{{Op: "replace", Path: "/ttl", Value: strconv.Itoa(ttl)}})
+		switch {
+		case isNotFoundError(err):
+			// the DNS record of a new edge hostname is created asynchronously, its TTL is set by the next update
+			logger.Warnf("Edge hostname %s is not available in HAPI yet: %s", edgeHostname, err)
+			if err := d.Set("ttl", 0); err != nil {
+				return diag.FromErr(fmt.Errorf("%w: %s", tools.ErrValueSet, err.Error()))
+			}
+			diags = append(diags, diag.Diagnostic{
+				Severity: diag.Warning,
+				Summary:  "edge hostname TTL not set",
+				Detail:   fmt.Sprintf("edge hostname %s is still being created, its TTL is set on the next apply", edgeHostname),
+			})
+		case err != nil:
+			return diag.FromErr(err)
+		}
+	}
+	return append(diags, resourceSecureEdgeHostNameRead(ctx, d, meta)...)
+}
+
+func resourceSecureEdgeHostNameUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	meta := akamai.Meta(m)
+	logger := meta.Log("HAPI", "resourceSecureEdgeHostNameUpdate")
+
+	var patches []edgeHostnamePatch
+	if d.HasChange("ip_behavior") {
+		oldBehavior, newBehavior := d.GetChange("ip_behavior")
+		if ipVersionBehavior := hapiIPVersionBehavior(newBehavior.(string)); ipVersionBehavior != hapiIPVersionBehavior(oldBehavior.(string)) {
+			patches = append(patches, edgeHostnamePatch{Op: "replace", Path: "/ipVersionBehavior", Value: ipVersionBehavior})
+		}
+	}
+	if d.HasChange("ttl") {
+		// HAPI does not restore the default TTL, the TTL of the edge hostname is kept when ttl is removed
+		if ttl := d.Get("ttl").(int); ttl > 0 {
+			patches = append(patches, edgeHostnamePatch{Op: "replace", Path: "/ttl", Value: strconv.Itoa(ttl)})
+		}
+	}
+	if len(patches) == 0 {
+		logger.Debug("No edge hostname settings to patch")
+		return resourceSecureEdgeHostNameRead(ctx, d, meta)
+	}
+
+	logger.Debugf("Patching edge hostname %s: %#v", d.Get("edge_hostname"), patches)
+	if err := patchEdgeHostname(ctx, d, meta, patches); err != nil {
+		d.Partial(true)
+		return diag.FromErr(err)
+	}
 	return resourceSecureEdgeHostNameRead(ctx, d, meta)
 }
 
-func resourceSecureEdgeHostNameDelete(_ context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+func resourceSecureEdgeHostNameDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	meta := akamai.Meta(m)
-	logger := meta.Log("PAPI", "resourceSecureEdgeHostNameDelete")
-	logger.Debug("DELETING")
-	logger.Info("PAPI does not support edge hostname deletion - resource will only be removed from state")
+	logger := meta.Log("HAPI", "resourceSecureEdgeHostNameDelete")
+	client := inst.HAPIClient(meta)
+
+	// many attributes force a replacement, which must not delete an edge hostname still in use unless asked to
+	if !d.Get("delete_on_destroy").(bool) {
+		logger.Info("delete_on_destroy is not set - edge hostname will only be removed from state")
+		d.SetId("")
+		return nil
+	}
+
+	recordName, dnsZone := splitEdgeHostname(d.Get("edge_hostname").(string))
+	logger.Debugf("Deleting edge hostname %s.%s", recordName, dnsZone)
+	change, err := client.DeleteEdgeHostname(ctx, hapi.DeleteEdgeHostnameRequest{
+		DNSZone:           dnsZone,
+		RecordName:        recordName,
+		StatusUpdateEmail: edgeHostnameStatusUpdateEmails(d),
+	})
+	if isNotFoundError(err) {
+		logger.Infof("Edge hostname %s.%s is already deleted", recordName, dnsZone)
+		d.SetId("")
+		return nil
+	}
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	name := fmt.Sprintf("edge hostname %s.%s deletion %d", recordName, dnsZone, change.ChangeID)
+	if err := waitForEdgeHostnameChange(ctx, d, meta, change, name); err != nil {
+		return diag.FromErr(err)
+	}
 	d.SetId("")
-	logger.Debugf("DONE")
 	return nil
 }
 
@@ -304,6 +400,9 @@ func resourceSecureEdgeHostNameImport(ctx context.Context, d *schema.ResourceDat
 	if err := d.Set("edge_hostname", edgehostnameDetails.EdgeHostname.Domain); err != nil {
 		return nil, fmt.Errorf("%w: %s", tools.ErrValueSet, err.Error())
 	}
+	if err := d.Set("delete_on_destroy", false); err != nil {
+		return nil, fmt.Errorf("%w: %s", tools.ErrValueSet, err.Error())
+	}
 	d.SetId(edgehostID)
 
 	return []*schema.ResourceData{d}, nil
@@ -315,14 +414,10 @@ func resourceSecureEdgeHostNameRead(ctx context.Context, d *schema.ResourceData,
 
 	client := inst.Client(meta)
 
-	// Schema guarantees group_id/group are strings and one or the other is set
-	var groupID string
-	if got, ok := d.GetOk("group_id"); ok {
-		groupID = got.(string)
-	} else {
-		groupID = d.Get("group").(string)
+	groupID, err := akamai.GetGroupID(d, m, "group_id", "group")
+	if err != nil {
+		return diag.FromErr(err)
 	}
-	groupID = tools.AddPrefix(groupID, "grp_")
 	// set group/groupID into ResourceData
 	if err := d.Set("group_id", groupID); err != nil {
 		return diag.FromErr(fmt.Errorf("%w: %s", tools.ErrValueSet, err.Error()))
@@ -331,14 +426,10 @@ func resourceSecureEdgeHostNameRead(ctx context.Context, d *schema.ResourceData,
 		return diag.FromErr(fmt.Errorf("%w: %s", tools.ErrValueSet, err.Error()))
 	}
 
-	// Schema guarantees contract_id/contract are strings and one or the other is set
-	var contractID string
-	if got, ok := d.GetOk("contract_id"); ok {
-		contractID = got.(string)
-	} else {
-		contractID = d.Get("contract").(string)
+	contractID, err := akamai.GetContractID(d, m, "contract_id", "contract")
+	if err != nil {
+		return diag.FromErr(err)
 	}
-	contractID = tools.AddPrefix(contractID, "ctr_")
 	// set contract/contract_id into ResourceData
 	if err := d.Set("contract_id", contractID); err != nil {
 		return diag.FromErr(fmt.Errorf("%w: %s", tools.ErrValueSet, err.Error()))
@@ -395,8 +486,54 @@ func resourceSecureEdgeHostNameRead(ctx context.Context, d *schema.ResourceData,
 	if err := d.Set("edge_hostname", foundEdgeHostname.Domain); err != nil {
 		return diag.FromErr(fmt.Errorf("%w: %s", tools.ErrValueSet, err.Error()))
 	}
+	diags := readEdgeHostnameHAPI(ctx, d, meta, foundEdgeHostname.Domain)
+	if diags.HasError() {
+		return diags
+	}
 	d.SetId(foundEdgeHostname.ID)
 
+	return diags
+}
+
+// readEdgeHostnameHAPI sets the ttl and ip_behavior of the edge hostname from HAPI, where they are changed.
+// The API client may have no access to HAPI if ttl is not set, so the HAPI errors are only warnings then.
+func readEdgeHostnameHAPI(ctx context.Context, d *schema.ResourceData, meta akamai.OperationMeta, domain string) diag.Diagnostics {
+	logger := meta.Log("HAPI", "readEdgeHostnameHAPI")
+	client := inst.HAPIClient(meta)
+
+	recordName, dnsZone := splitEdgeHostname(domain)
+	edgeHostnames, err := client.ListEdgeHostnames(ctx, listEdgeHostnamesRequest{
+		RecordNameSubstring: recordName,
+		DNSZone:             dnsZone,
+	})
+	if err != nil {
+		if _, ttlErr := tools.GetIntValue("ttl", d); ttlErr == nil {
+			return diag.FromErr(err)
+		}
+		logger.Warnf("Edge hostname %s could not be read from HAPI: %s", domain, err)
+		return diag.Diagnostics{{
+			Severity: diag.Warning,
+			Summary:  "edge hostname ttl and ip_behavior not read",
+			Detail:   fmt.Sprintf("edge hostname %s could not be read with the Edge Hostnames API: %s", domain, err),
+		}}
+	}
+	for _, h := range edgeHostnames {
+		if h.RecordName != recordName || h.DNSZone != dnsZone {
+			continue
+		}
+		if err := d.Set("ttl", h.TTL); err != nil {
+			return diag.FromErr(fmt.Errorf("%w: %s", tools.ErrValueSet, err.Error()))
+		}
+		// ip_behavior is kept as written, unless it selects another HAPI ipVersionBehavior
+		if ipBehavior := d.Get("ip_behavior").(string); ipBehavior == "" || hapiIPVersionBehavior(ipBehavior) != h.IPVersionBehavior {
+			if err := d.Set("ip_behavior", papiIPBehavior(h.IPVersionBehavior)); err != nil {
+				return diag.FromErr(fmt.Errorf("%w: %s", tools.ErrValueSet, err.Error()))
+			}
+		}
+		return nil
+	}
+	// the DNS record of a new edge hostname is created asynchronously
+	logger.Warnf("Edge hostname %s is not available in HAPI yet, its ttl and ip_behavior are not read", domain)
 	return nil
 }
 
@@ -455,15 +592,8 @@ func appendDefaultSuffixToEdgeHostname(i interface{}) string {
 }
 
 func findEdgeHostname(edgeHostnames papi.EdgeHostnameItems, domain string) (*papi.EdgeHostnameGetItem, error) {
-	suffix := "edgesuite.net"
 	if domain != "" {
-		if strings.HasSuffix(domain, "edgekey.net") {
-			suffix = "edgekey.net"
-		}
-		if strings.HasSuffix(domain, "akamaized.net") {
-			suffix = "akamaized.net"
-		}
-		prefix := strings.TrimSuffix(domain, "."+suffix)
+		prefix, suffix := splitEdgeHostname(domain)
 
 		for _, eHn := range edgeHostnames.Items {
 			if eHn.DomainPrefix == prefix && eHn.DomainSuffix == suffix {
@@ -481,3 +611,88 @@ func useCases2JSON(useCases []papi.UseCase) ([]byte, error) {
 	}
 	return json.MarshalIndent(useCases, "", "  ")
 }
+
+// splitEdgeHostname returns the prefix and the suffix of the edge hostname, which are the record name and the DNS zone
+// of the edge hostname in HAPI
+func splitEdgeHostname(domain string) (string, string) {
+	suffix := "edgesuite.net"
+	if strings.HasSuffix(domain, "edgekey.net") {
+		suffix = "edgekey.net"
+	}
+	if strings.HasSuffix(domain, "akamaized.net") {
+		suffix = "akamaized.net"
+	}
+	return strings.TrimSuffix(domain, "."+suffix), suffix
+}
+
+// hapiIPVersionBehavior returns the HAPI ipVersionBehavior of the PAPI ip_behavior
+func hapiIPVersionBehavior(ipBehavior string) string {
+	switch strings.ToUpper(ipBehavior) {
+	case papi.EHIPVersionV6Compliance:
+		return "IPV6_IPV4_DUALSTACK"
+	case papi.EHIPVersionV6Performance:
+		return "IPV6"
+	}
+	return papi.EHIPVersionV4
+}
+
+// papiIPBehavior returns the PAPI ip_behavior of the HAPI ipVersionBehavior
+func papiIPBehavior(ipVersionBehavior string) string {
+	switch ipVersionBehavior {
+	case "IPV6_IPV4_DUALSTACK":
+		return papi.EHIPVersionV6Compliance
+	case "IPV6":
+		return papi.EHIPVersionV6Performance
+	}
+	return papi.EHIPVersionV4
+}
+
+func edgeHostnameStatusUpdateEmails(d *schema.ResourceData) []string {
+	var emails []string
+	if v, err := tools.GetListValue("status_update_email", d); err == nil {
+		for _, email := range v {
+			emails = append(emails, email.(string))
+		}
+	}
+	return emails
+}
+
+// patchEdgeHostname patches the edge hostname with HAPI and waits until the change is applied
+func patchEdgeHostname(ctx context.Context, d *schema.ResourceData, meta akamai.OperationMeta, patches []edgeHostnamePatch) error {
+	client := inst.HAPIClient(meta)
+
+	recordName, dnsZone := splitEdgeHostname(appendDefaultSuffixToEdgeHostname(d.Get("edge_hostname")))
+	change, err := client.PatchEdgeHostname(ctx, patchEdgeHostnameRequest{
+		DNSZone:           dnsZone,
+		RecordName:        recordName,
+		Patches:           patches,
+		StatusUpdateEmail: edgeHostnameStatusUpdateEmails(d),
+	})
+	if err != nil {
+		return err
+	}
+	return waitForEdgeHostnameChange(ctx, d, meta, change, fmt.Sprintf("edge hostname %s.%s change %d", recordName, dnsZone, change.ChangeID))
+}
+
+// waitForEdgeHostnameChange polls the change request until it succeeds
+func waitForEdgeHostnameChange(ctx context.Context, d *schema.ResourceData, meta akamai.OperationMeta, change *edgeHostnameChange, name string) error {
+	client := inst.HAPIClient(meta)
+
+	poller, err := akamai.NewActivationPoller(d, ActivationPollInterval, meta.Log("HAPI", "waitForEdgeHostnameChange"), name)
+	if err != nil {
+		return err
+	}
+	for change.Status != EdgeHostnameChangeSucceeded {
+		if change.Status == EdgeHostnameChangeFailed {
+			return fmt.Errorf("%s failed: %s", name, change.StatusMessage)
+		}
+		if err := poller.Wait(ctx, change.Status); err != nil {
+			return fmt.Errorf("%s did not finish: %w", name, err)
+		}
+		change, err = client.GetChangeRequest(ctx, change.ChangeID)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
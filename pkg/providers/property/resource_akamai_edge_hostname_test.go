@@ -2,18 +2,23 @@ package property
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"errors"
 	"fmt"
+	"net/http"
 	"regexp"
 	"testing"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
 
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v2/pkg/hapi"
 	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v2/pkg/papi"
 )
 
@@ -514,6 +519,9 @@ func TestResourceEdgeHostname(t *testing.T) {
 		t.Run(name, func(t *testing.T) {
 			client := &mockpapi{}
 			test.init(client)
+			// without delete_on_destroy the edge hostnames are only removed from the state, HAPI is only read
+			hapiClient := &mockhapi{}
+			hapiClient.On("ListEdgeHostnames", mock.Anything, mock.Anything).Return([]hapi.EdgeHostname{}, nil).Maybe()
 			var checkFuncs []resource.TestCheckFunc
 			for k, v := range test.expectedAttributes {
 				checkFuncs = append(checkFuncs, resource.TestCheckResourceAttr("akamai_edge_hostname.edgehostname", k, v))
@@ -521,7 +529,7 @@ func TestResourceEdgeHostname(t *testing.T) {
 			for k, v := range test.expectedOutputs {
 				checkFuncs = append(checkFuncs, resource.TestCheckOutput(k, v))
 			}
-			useClient(client, func() {
+			useEdgeHostnameClients(client, hapiClient, func() {
 				resource.UnitTest(t, resource.TestCase{
 					Providers: testAccProviders,
 					Steps: []resource.TestStep{
@@ -610,7 +618,13 @@ func TestResourceEdgeHostnames_WithImport(t *testing.T) {
 
 		expectGetEdgeHostname(client, "eh_1", "ctr_1", "grp_2")
 		expectGetEdgeHostnames(client, "ctr_1", "grp_2")
-		useClient(client, func() {
+		hapiClient := &mockhapi{}
+		hapiClient.On("ListEdgeHostnames", mock.Anything, listEdgeHostnamesRequest{RecordNameSubstring: "test", DNSZone: "akamaized.net"}).
+			Return([]hapi.EdgeHostname{
+				{RecordName: "test-2", DNSZone: "akamaized.net", TTL: 300, IPVersionBehavior: "IPV6"},
+				{RecordName: "test", DNSZone: "akamaized.net", TTL: 21600, UseDefaultTTL: true, IPVersionBehavior: "IPV4"},
+			}, nil)
+		useEdgeHostnameClients(client, hapiClient, func() {
 			resource.UnitTest(t, resource.TestCase{
 				Providers: testAccProviders,
 				Steps: []resource.TestStep{
@@ -626,20 +640,188 @@ func TestResourceEdgeHostnames_WithImport(t *testing.T) {
 							assert.Equal(t, "grp_2", rs.Attributes["group_id"])
 							assert.Equal(t, "ctr_1", rs.Attributes["contract_id"])
 							assert.Equal(t, "eh_1", rs.Attributes["id"])
+							assert.Equal(t, "IPV4", rs.Attributes["ip_behavior"])
+							assert.Equal(t, "21600", rs.Attributes["ttl"])
 							return nil
 						},
-						ImportStateId:           id,
-						ResourceName:            "akamai_edge_hostname.importedgehostname",
-						ImportStateVerify:       true,
-						ImportStateVerifyIgnore: []string{"ip_behavior"},
+						ImportStateId:     id,
+						ResourceName:      "akamai_edge_hostname.importedgehostname",
+						ImportStateVerify: true,
 					},
 				},
 			})
 		})
 		client.AssertExpectations(t)
+		hapiClient.AssertExpectations(t)
 	})
 }
 
+func TestResourceEdgeHostnameUpdate(t *testing.T) {
+	client := &mockpapi{}
+	client.On("GetEdgeHostnames", mock.Anything, papi.GetEdgeHostnamesRequest{
+		ContractID: "ctr_2",
+		GroupID:    "grp_2",
+	}).Return(&papi.GetEdgeHostnamesResponse{
+		ContractID: "ctr_2",
+		GroupID:    "grp_2",
+		EdgeHostnames: papi.EdgeHostnameItems{Items: []papi.EdgeHostnameGetItem{
+			{
+				ID:                "eh_1",
+				Domain:            "test.edgesuite.net",
+				ProductID:         "prd_2",
+				DomainPrefix:      "test",
+				DomainSuffix:      "edgesuite.net",
+				IPVersionBehavior: "IPV4",
+			},
+		}},
+	}, nil)
+
+	hapiClient := &mockhapi{}
+	emails := []string{"user@example.com"}
+	// the patches change the edge hostname read back from HAPI
+	edgeHostnames := []hapi.EdgeHostname{{RecordName: "test", DNSZone: "edgesuite.net", TTL: 21600, UseDefaultTTL: true, IPVersionBehavior: "IPV4"}}
+	hapiClient.On("ListEdgeHostnames", mock.Anything, listEdgeHostnamesRequest{RecordNameSubstring: "test", DNSZone: "edgesuite.net"}).
+		Return(edgeHostnames, nil)
+	hapiClient.On("PatchEdgeHostname", mock.Anything, patchEdgeHostnameRequest{
+		DNSZone:           "edgesuite.net",
+		RecordName:        "test",
+		Patches:           []edgeHostnamePatch{{Op: "replace", Path: "/ttl", Value: "300"}},
+		StatusUpdateEmail: emails,
+	}).Return(&edgeHostnameChange{ChangeID: 1, Status: EdgeHostnameChangePending}, nil).Once().
+		Run(func(mock.Arguments) {
+			edgeHostnames[0].TTL, edgeHostnames[0].UseDefaultTTL = 300, false
+		})
+	hapiClient.On("GetChangeRequest", mock.Anything, 1).
+		Return(&edgeHostnameChange{ChangeID: 1, Status: EdgeHostnameChangeSucceeded}, nil).Once()
+	hapiClient.On("PatchEdgeHostname", mock.Anything, patchEdgeHostnameRequest{
+		DNSZone:    "edgesuite.net",
+		RecordName: "test",
+		Patches: []edgeHostnamePatch{
+			{Op: "replace", Path: "/ipVersionBehavior", Value: "IPV6_IPV4_DUALSTACK"},
+			{Op: "replace", Path: "/ttl", Value: "600"},
+		},
+		StatusUpdateEmail: emails,
+	}).Return(&edgeHostnameChange{ChangeID: 2, Status: EdgeHostnameChangeSucceeded}, nil).Once().
+		Run(func(mock.Arguments) {
+			edgeHostnames[0].TTL, edgeHostnames[0].IPVersionBehavior = 600, "IPV6_IPV4_DUALSTACK"
+		})
+	hapiClient.On("DeleteEdgeHostname", mock.Anything, hapi.DeleteEdgeHostnameRequest{
+		DNSZone:           "edgesuite.net",
+		RecordName:        "test",
+		StatusUpdateEmail: emails,
+	}).Return(&hapi.DeleteEdgeHostnameResponse{ChangeID: 3, Status: EdgeHostnameChangePending}, nil).Once()
+	hapiClient.On("GetChangeRequest", mock.Anything, 3).
+		Return(&edgeHostnameChange{ChangeID: 3, Status: EdgeHostnameChangeSucceeded}, nil).Once()
+
+	useEdgeHostnameClients(client, hapiClient, func() {
+		resource.UnitTest(t, resource.TestCase{
+			Providers: testAccProviders,
+			Steps: []resource.TestStep{
+				{
+					Config: loadFixtureString("testdata/TestResourceEdgeHostname/ttl_create.tf"),
+					Check: resource.ComposeAggregateTestCheckFunc(
+						resource.TestCheckResourceAttr("akamai_edge_hostname.edgehostname", "id", "eh_1"),
+						resource.TestCheckResourceAttr("akamai_edge_hostname.edgehostname", "ttl", "300"),
+						resource.TestCheckResourceAttr("akamai_edge_hostname.edgehostname", "ip_behavior", "IPV4"),
+					),
+				},
+				{
+					Config: loadFixtureString("testdata/TestResourceEdgeHostname/ttl_update.tf"),
+					Check: resource.ComposeAggregateTestCheckFunc(
+						resource.TestCheckResourceAttr("akamai_edge_hostname.edgehostname", "id", "eh_1"),
+						resource.TestCheckResourceAttr("akamai_edge_hostname.edgehostname", "ttl", "600"),
+						resource.TestCheckResourceAttr("akamai_edge_hostname.edgehostname", "ip_behavior", "IPV6_COMPLIANCE"),
+					),
+				},
+			},
+		})
+	})
+	client.AssertExpectations(t)
+	hapiClient.AssertExpectations(t)
+}
+
+func TestReadEdgeHostnameHAPI(t *testing.T) {
+	request := listEdgeHostnamesRequest{RecordNameSubstring: "test", DNSZone: "edgesuite.net"}
+	forbidden := &papi.Error{StatusCode: http.StatusForbidden, Title: "Forbidden"}
+
+	tests := map[string]struct {
+		givenData   map[string]interface{}
+		init        func(*mockhapi)
+		expectedTTL int
+		withWarning bool
+		withError   bool
+	}{
+		"ttl read": {
+			givenData: map[string]interface{}{},
+			init: func(m *mockhapi) {
+				m.On("ListEdgeHostnames", AnyCTX, request).Return([]hapi.EdgeHostname{
+					{RecordName: "test", DNSZone: "edgesuite.net", TTL: 300, IPVersionBehavior: "IPV4"},
+				}, nil)
+			},
+			expectedTTL: 300,
+		},
+		"HAPI error without ttl": {
+			givenData: map[string]interface{}{},
+			init: func(m *mockhapi) {
+				m.On("ListEdgeHostnames", AnyCTX, request).Return(nil, forbidden)
+			},
+			withWarning: true,
+		},
+		"HAPI error with ttl": {
+			givenData: map[string]interface{}{"ttl": 600},
+			init: func(m *mockhapi) {
+				m.On("ListEdgeHostnames", AnyCTX, request).Return(nil, forbidden)
+			},
+			expectedTTL: 600,
+			withError:   true,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			hapiClient := &mockhapi{}
+			test.init(hapiClient)
+			d := schema.TestResourceDataRaw(t, resourceSecureEdgeHostName().Schema, test.givenData)
+
+			var diags diag.Diagnostics
+			useHAPIClient(hapiClient, func() {
+				diags = readEdgeHostnameHAPI(context.Background(), d, &noCacheMeta{}, "test.edgesuite.net")
+			})
+			assert.Equal(t, test.withError, diags.HasError())
+			assert.Equal(t, test.withWarning, len(diags) == 1 && diags[0].Severity == diag.Warning)
+			assert.Equal(t, test.expectedTTL, d.Get("ttl"))
+			hapiClient.AssertExpectations(t)
+		})
+	}
+}
+
+func TestSplitEdgeHostname(t *testing.T) {
+	tests := map[string]struct {
+		domain, recordName, dnsZone string
+	}{
+		"edgesuite.net": {domain: "www.example.com.edgesuite.net", recordName: "www.example.com", dnsZone: "edgesuite.net"},
+		"edgekey.net":   {domain: "www.example.com.edgekey.net", recordName: "www.example.com", dnsZone: "edgekey.net"},
+		"akamaized.net": {domain: "example.akamaized.net", recordName: "example", dnsZone: "akamaized.net"},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			recordName, dnsZone := splitEdgeHostname(test.domain)
+			assert.Equal(t, test.recordName, recordName)
+			assert.Equal(t, test.dnsZone, dnsZone)
+		})
+	}
+}
+
+func TestHAPIIPVersionBehavior(t *testing.T) {
+	assert.Equal(t, "IPV4", hapiIPVersionBehavior("ipv4"))
+	assert.Equal(t, "IPV6_IPV4_DUALSTACK", hapiIPVersionBehavior("IPV6_COMPLIANCE"))
+	assert.Equal(t, "IPV6", hapiIPVersionBehavior("IPV6_PERFORMANCE"))
+
+	for _, ipBehavior := range []string{papi.EHIPVersionV4, papi.EHIPVersionV6Compliance, papi.EHIPVersionV6Performance} {
+		assert.Equal(t, ipBehavior, papiIPBehavior(hapiIPVersionBehavior(ipBehavior)))
+	}
+}
+
 func TestFindEdgeHostname(t *testing.T) {
 	tests := map[string]struct {
 		hostnames papi.EdgeHostnameItems
@@ -15,6 +15,7 @@ import (
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/customdiff"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 
 	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v2/pkg/papi"
 	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v2/pkg/session"
@@ -67,7 +68,7 @@ func resourceProperty() *schema.Resource {
 		return nil
 	}
 
-	diffSuppressRules := func(_, old, new string, _ *schema.ResourceData) bool {
+	diffSuppressRules := func(_, old, new string, d *schema.ResourceData) bool {
 		logger := akamai.Log("PAPI", "suppressRulesJSON")
 
 		if old == "" || new == "" {
@@ -85,7 +86,7 @@ func resourceProperty() *schema.Resource {
 			return false
 		}
 
-		return compareRuleTree(&oldRules, &newRules)
+		return compareRuleTree(&oldRules, &newRules, getIgnoreRulePaths(d)...)
 	}
 
 	return &schema.Resource{
@@ -94,8 +95,11 @@ func resourceProperty() *schema.Resource {
 		UpdateContext: resourcePropertyUpdate,
 		DeleteContext: resourcePropertyDelete,
 		CustomizeDiff: customdiff.All(
+			ruleFormatCustomDiff,
+			rulesSchemaCustomDiff,
 			rulesCustomDiff,
 			hostNamesCustomDiff,
+			cloneHostnamesCustomDiff,
 			versionsComputedValuesCustomDiff,
 		),
 		Importer: &schema.ResourceImporter{
@@ -118,12 +122,12 @@ func resourceProperty() *schema.Resource {
 			},
 
 			"group_id": {
-				Type:         schema.TypeString,
-				Optional:     true,
-				Computed:     true,
-				ExactlyOneOf: []string{"group_id", "group"},
-				StateFunc:    addPrefixToState("grp_"),
-				Description:  "Group ID to be assigned to the Property",
+				Type:          schema.TypeString,
+				Optional:      true,
+				Computed:      true,
+				ConflictsWith: []string{"group"},
+				StateFunc:     addPrefixToState("grp_"),
+				Description:   "Group ID to be assigned to the Property, the provider default_group_id is used if not set",
 			},
 			"group": {
 				Type:       schema.TypeString,
@@ -134,12 +138,12 @@ func resourceProperty() *schema.Resource {
 			},
 
 			"contract_id": {
-				Type:         schema.TypeString,
-				Optional:     true,
-				Computed:     true,
-				ExactlyOneOf: []string{"contract_id", "contract"},
-				StateFunc:    addPrefixToState("ctr_"),
-				Description:  "Contract ID to be assigned to the Property",
+				Type:          schema.TypeString,
+				Optional:      true,
+				Computed:      true,
+				ConflictsWith: []string{"contract"},
+				StateFunc:     addPrefixToState("ctr_"),
+				Description:   "Contract ID to be assigned to the Property, the provider default_contract_id is used if not set",
 			},
 			"contract": {
 				Type:       schema.TypeString,
@@ -167,23 +171,11 @@ func resourceProperty() *schema.Resource {
 
 			// Optional
 			"rule_format": {
-				Type:        schema.TypeString,
-				Optional:    true,
-				Computed:    true,
-				Description: "Specify the rule format version (defaults to latest version available when created)",
-				ValidateDiagFunc: func(v interface{}, _ cty.Path) diag.Diagnostics {
-					format := v.(string)
-					if format == "" || format == "latest" {
-						return nil
-					}
-
-					if !regexp.MustCompile(`^v[0-9]{4}-[0-9]{2}-[0-9]{2}$`).MatchString(format) {
-						url := "https://developer.akamai.com/api/core_features/property_manager/vlatest.html#behaviors"
-						return diag.Errorf(`"rule_format" must be of the form vYYYY-MM-DD (with a leading "v") see %s`, url)
-					}
-
-					return nil
-				},
+				Type:             schema.TypeString,
+				Optional:         true,
+				Computed:         true,
+				Description:      "Specify the rule format version (defaults to latest version available when created)",
+				ValidateDiagFunc: validateRuleFormat,
 			},
 			"rules": {
 				Type:             schema.TypeString,
@@ -200,10 +192,17 @@ func resourceProperty() *schema.Resource {
 					return v.(string)
 				},
 			},
+			"ignore_rule_paths": {
+				Type:        schema.TypeList,
+				Optional:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString, ValidateDiagFunc: tools.IsNotBlank},
+				Description: "Paths of the child rules managed by akamai_property_rule resources, as the names of the rules below the default rule separated by \"/\", which are left out of the rules of the property",
+			},
 			"hostnames": {
-				Type:     schema.TypeSet,
-				Optional: true,
-				Set:      hashHostname,
+				Type:             schema.TypeSet,
+				Optional:         true,
+				Set:              hashHostname,
+				DiffSuppressFunc: suppressCopiedHostnames,
 				Elem: &schema.Resource{
 					Schema: map[string]*schema.Schema{
 						"cname_from": {
@@ -254,6 +253,43 @@ func resourceProperty() *schema.Resource {
 					},
 				},
 			},
+			"clone_from": {
+				Type:             schema.TypeList,
+				Optional:         true,
+				MaxItems:         1,
+				DiffSuppressFunc: suppressAfterCreate,
+				Description:      "The property version the property is cloned from when it is created, changes are ignored after the property is created",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"property_id": {
+							Type:             schema.TypeString,
+							Required:         true,
+							DiffSuppressFunc: suppressAfterCreate,
+							StateFunc:        addPrefixToState("prp_"),
+							Description:      "The ID of the property to clone",
+						},
+						"version": {
+							Type:             schema.TypeInt,
+							Required:         true,
+							DiffSuppressFunc: suppressAfterCreate,
+							ValidateDiagFunc: validation.ToDiagFunc(validation.IntAtLeast(1)),
+							Description:      "The version of the property to clone",
+						},
+						"etag": {
+							Type:             schema.TypeString,
+							Optional:         true,
+							DiffSuppressFunc: suppressAfterCreate,
+							Description:      "The etag of the version to clone, the property is not created if the version has changed since",
+						},
+						"copy_hostnames": {
+							Type:             schema.TypeBool,
+							Optional:         true,
+							DiffSuppressFunc: suppressAfterCreate,
+							Description:      "Whether to copy the hostnames of the cloned version",
+						},
+					},
+				},
+			},
 
 			// Computed
 			"latest_version": {
@@ -276,6 +312,11 @@ func resourceProperty() *schema.Resource {
 				Computed:    true,
 				Description: "Required property's version to be read",
 			},
+			"unconverted_rules_hash": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Hash of the rules in the configuration when they were last converted to a new rule format",
+			},
 			"rule_errors": {
 				Type:     schema.TypeList,
 				Computed: true,
@@ -347,6 +388,21 @@ func validatePropertyName(v interface{}, _ cty.Path) diag.Diagnostics {
 	return nil
 }
 
+// validateRuleFormat validates if rule_format is "latest" or a frozen rule format of the form vYYYY-MM-DD
+func validateRuleFormat(v interface{}, _ cty.Path) diag.Diagnostics {
+	format := v.(string)
+	if format == "" || format == "latest" {
+		return nil
+	}
+
+	if !regexp.MustCompile(`^v[0-9]{4}-[0-9]{2}-[0-9]{2}$`).MatchString(format) {
+		url := "https://developer.akamai.com/api/core_features/property_manager/vlatest.html#behaviors"
+		return diag.Errorf(`"rule_format" must be of the form vYYYY-MM-DD (with a leading "v") see %s`, url)
+	}
+
+	return nil
+}
+
 // rulesCustomDiff compares Rules.Criteria and Rules.Children fields from terraform state and from a new configuration.
 // If some of these fields are empty lists in the new configuration and are nil in the terraform state, then this function
 // returns no difference for these fields
@@ -383,6 +439,34 @@ func rulesCustomDiff(_ context.Context, diff *schema.ResourceDiff, _ interface{}
 		return fmt.Errorf("cannot parse rules JSON from config: %s", err)
 	}
 
+	// on a rule format change the rules managed by akamai_property_rule resources are converted on update
+	if ignorePaths := getIgnoreRulePaths(diff); len(ignorePaths) > 0 && !diff.HasChange("rule_format") {
+		// compareRuleTree reorders the compared rules, so they are compared on copies
+		var oldRules, newRules papi.RulesUpdate
+		if err := json.Unmarshal([]byte(oldValue), &oldRules); err != nil {
+			return fmt.Errorf("cannot parse rules JSON from state: %s", err)
+		}
+		if err := json.Unmarshal([]byte(newValue), &newRules); err != nil {
+			return fmt.Errorf("cannot parse rules JSON from config: %s", err)
+		}
+		// the ignored rules are left out of the comparison by name, which must select a single rule
+		if err := checkRulePaths(&newRules.Rules, ignorePaths); err != nil {
+			return err
+		}
+		if err := checkRulePaths(&oldRules.Rules, ignorePaths); err != nil {
+			return err
+		}
+		if compareRuleTree(&oldRules, &newRules, ignorePaths...) {
+			if err = diff.SetNew("rules", oldValue); err != nil {
+				return fmt.Errorf("cannot set a new diff value for 'rules' %s", err)
+			}
+			return nil
+		}
+		if err := copyRulePaths(&newRulesUpdate.Rules, &oldRulesUpdate.Rules, ignorePaths); err != nil {
+			return err
+		}
+	}
+
 	rules, err := compareFields(&oldRulesUpdate, &newRulesUpdate)
 	if err != nil {
 		return fmt.Errorf("cannot encode rules JSON %s", err)
@@ -399,6 +483,141 @@ func rulesCustomDiff(_ context.Context, diff *schema.ResourceDiff, _ interface{}
 	return nil
 }
 
+// ruleFormatCustomDiff converts the rules of an existing property when rule_format changes and the rules in the
+// configuration are the rules from state, so that the plan shows the rules as PAPI converts them to the new rule format
+// instead of uploading the unchanged rules under the new rule format
+func ruleFormatCustomDiff(ctx context.Context, diff *schema.ResourceDiff, m interface{}) error {
+	if diff.Id() == "" || !diff.NewValueKnown("rule_format") || !diff.NewValueKnown("rules") {
+		return nil
+	}
+	if !diff.HasChange("rule_format") {
+		return unconvertedRulesCustomDiff(diff)
+	}
+	o, n := diff.GetChange("rule_format")
+	if o.(string) == "" || n.(string) == "" {
+		return nil
+	}
+	RuleFormat := n.(string)
+
+	o, n = diff.GetChange("rules")
+	oldValue, newValue := o.(string), n.(string)
+	if oldValue == "" {
+		return nil
+	}
+	if newValue != "" {
+		var oldRules, newRules papi.RulesUpdate
+		if err := json.Unmarshal([]byte(oldValue), &oldRules); err != nil {
+			return fmt.Errorf("cannot parse rules JSON from state: %s", err)
+		}
+		if err := json.Unmarshal([]byte(newValue), &newRules); err != nil {
+			return fmt.Errorf("cannot parse rules JSON from config: %s", err)
+		}
+		if !compareRuleTree(&oldRules, &newRules, getIgnoreRulePaths(diff)...) {
+			// the rules were changed along with the rule format, so they are expected to be in the new rule format
+			return nil
+		}
+	}
+
+	meta := akamai.Meta(m)
+	logger := meta.Log("PAPI", "ruleFormatCustomDiff")
+	ctx = log.NewContext(ctx, logger)
+
+	Property := papi.Property{
+		PropertyID: diff.Id(),
+		ContractID: diff.Get("contract_id").(string),
+		GroupID:    diff.Get("group_id").(string),
+	}
+	version := diff.Get("latest_version").(int)
+	if v, ok := diff.GetOk("read_version"); ok && v.(int) != 0 {
+		version = v.(int)
+	}
+
+	Rules, _, _, Warnings, err := fetchConvertedPropertyVersionRules(ctx, inst.Client(meta), Property, version, RuleFormat)
+	if err != nil {
+		return fmt.Errorf("cannot convert rules to rule format %q: %w", RuleFormat, err)
+	}
+	for _, w := range Warnings {
+		if w != nil {
+			logger.Warnf("rule format conversion to %s: %s %s", RuleFormat, w.Detail, w.ErrorLocation)
+		}
+	}
+
+	rulesBytes, err := json.Marshal(Rules)
+	if err != nil {
+		return err
+	}
+	if err = diff.SetNew("rules", string(rulesBytes)); err != nil {
+		return fmt.Errorf("cannot set a new diff value for 'rules' %s", err)
+	}
+	if newValue != "" {
+		hash, err := rulesHash(newValue)
+		if err != nil {
+			return err
+		}
+		if err = diff.SetNew("unconverted_rules_hash", hash); err != nil {
+			return fmt.Errorf("cannot set a new diff value for 'unconverted_rules_hash' %s", err)
+		}
+	}
+	return nil
+}
+
+// unconvertedRulesCustomDiff returns an error when the rules in the configuration are still the rules which were
+// converted to the new rule format by an earlier apply, as the plan would otherwise silently revert the conversion
+func unconvertedRulesCustomDiff(diff *schema.ResourceDiff) error {
+	hash := diff.Get("unconverted_rules_hash").(string)
+	o, n := diff.GetChange("rules")
+	oldValue, newValue := o.(string), n.(string)
+	if hash == "" || oldValue == "" || newValue == "" {
+		return nil
+	}
+	// rules which are not valid JSON are reported by rulesCustomDiff
+	newHash, err := rulesHash(newValue)
+	if err != nil || newHash != hash {
+		return nil
+	}
+	var oldRules, newRules papi.RulesUpdate
+	if err := json.Unmarshal([]byte(oldValue), &oldRules); err != nil {
+		return nil
+	}
+	if err := json.Unmarshal([]byte(newValue), &newRules); err != nil {
+		return nil
+	}
+	if compareRuleTree(&oldRules, &newRules, getIgnoreRulePaths(diff)...) {
+		// the conversion did not change the rules
+		return nil
+	}
+	return fmt.Errorf("%w %s: the rules in the configuration are the rules from before the conversion, "+
+		"replace them with the converted rules returned by the akamai_property_rules_upgrade data source", ErrRulesNotConverted, diff.Get("rule_format"))
+}
+
+// rulesHash returns the hash of the rules JSON, which does not depend on its formatting
+func rulesHash(rulesJSON string) (string, error) {
+	var rules papi.RulesUpdate
+	if err := json.Unmarshal([]byte(rulesJSON), &rules); err != nil {
+		return "", fmt.Errorf("cannot parse rules JSON from config: %s", err)
+	}
+	rulesBytes, err := json.Marshal(rules)
+	if err != nil {
+		return "", err
+	}
+	return tools.GetSHAString(string(rulesBytes)), nil
+}
+
+// getIgnoreRulePaths returns the paths of the child rules which are managed by akamai_property_rule resources
+func getIgnoreRulePaths(d tools.ResourceDataFetcher) []string {
+	values, err := tools.GetListValue("ignore_rule_paths", d)
+	if err != nil {
+		return nil
+	}
+	paths := make([]string, 0, len(values))
+	for _, v := range values {
+		if path, ok := v.(string); ok {
+			paths = append(paths, path)
+		}
+	}
+	return paths
+}
+
 // unifyRulesDiff is invoked on first planning for property creation
 // Its main purpose is to unify the rules JSON with what we expect will be created by PAPI
 // It is used in order to prevent diffs on output on subsequent terraform applies
@@ -451,6 +670,20 @@ func hostNamesCustomDiff(_ context.Context, d *schema.ResourceDiff, m interface{
 	return nil
 }
 
+// cloneHostnamesCustomDiff rejects hostnames configured along with the hostnames copied from the cloned property,
+// as the configured hostnames would replace the copied ones when the property is created
+func cloneHostnamesCustomDiff(_ context.Context, d *schema.ResourceDiff, _ interface{}) error {
+	if d.Id() != "" {
+		return nil
+	}
+	copyHostnames, _ := d.Get("clone_from.0.copy_hostnames").(bool)
+	hostnames, _ := d.Get("hostnames").(*schema.Set)
+	if copyHostnames && hostnames != nil && hostnames.Len() > 0 {
+		return fmt.Errorf("'hostnames' cannot be set when 'clone_from.copy_hostnames' is true, the configured hostnames would replace the copied ones")
+	}
+	return nil
+}
+
 // versionsComputedValuesCustomDiff sets `latest_version`, `staging_version` and `production_version` fields as computed
 // if a new version of property is expected to be created
 func versionsComputedValuesCustomDiff(_ context.Context, d *schema.ResourceDiff, m interface{}) error {
@@ -490,17 +723,15 @@ func resourcePropertyCreate(ctx context.Context, d *schema.ResourceData, m inter
 	// Schema guarantees these types
 	PropertyName := d.Get("name").(string)
 
-	GroupID, err := tools.ResolveKeyStringState(d, "group_id", "group")
+	GroupID, err := akamai.GetGroupID(d, m, "group_id", "group")
 	if err != nil {
 		return diag.FromErr(err)
 	}
-	GroupID = tools.AddPrefix(GroupID, "grp_")
 
-	ContractID := d.Get("contract_id").(string)
-	if ContractID == "" {
-		ContractID = d.Get("contract").(string)
+	ContractID, err := akamai.GetContractID(d, m, "contract_id", "contract")
+	if err != nil {
+		return diag.FromErr(err)
 	}
-	ContractID = tools.AddPrefix(ContractID, "ctr_")
 
 	ProductID := d.Get("product_id").(string)
 	if ProductID == "" {
@@ -512,7 +743,12 @@ func resourcePropertyCreate(ctx context.Context, d *schema.ResourceData, m inter
 
 	RulesJSON := []byte(d.Get("rules").(string))
 
-	PropertyID, err := createProperty(ctx, client, PropertyName, GroupID, ContractID, ProductID, RuleFormat)
+	CloneFrom, err := getPropertyCloneFrom(d)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	PropertyID, err := createProperty(ctx, client, PropertyName, GroupID, ContractID, ProductID, RuleFormat, CloneFrom)
 	if err != nil {
 		if strings.Contains(err.Error(), "\"statusCode\": 404") {
 			// find out what is missing from the request
@@ -823,17 +1059,52 @@ func resourcePropertyUpdate(ctx context.Context, d *schema.ResourceData, m inter
 			return diag.Errorf("rules are not valid JSON: %s", err)
 		}
 
-		MIME := fmt.Sprintf("application/vnd.akamai.papirules.%s+json", RuleFormat)
-		h := http.Header{"Content-Type": []string{MIME}}
-		ctx := session.ContextWithOptions(ctx, session.WithContextHeaders(h))
-
-		if err := updatePropertyRules(ctx, client, Property, Rules); err != nil {
+		var ConvertTo string
+		if FormatNeedsUpdate {
+			ConvertTo = RuleFormat
+		}
+		Warnings, err := savePropertyRules(ctx, client, Property, Rules, RuleFormat, ConvertTo, getIgnoreRulePaths(d))
+		if err != nil {
 			d.Partial(true)
 			return diag.FromErr(err)
 		}
+		if FormatNeedsUpdate {
+			diags = append(diags, papiWarningsToDiags(fmt.Sprintf("rule format conversion to %s", RuleFormat), Warnings)...)
+		}
 	}
 
-	return resourcePropertyRead(ctx, d, m)
+	return append(diags, resourcePropertyRead(ctx, d, m)...)
+}
+
+// savePropertyRules saves the rules to the latest version of the property, keeping the rules at the ignored paths
+// as they are in that version. The rules are converted to ConvertTo first when it is set, and the conversion warnings
+// are returned. The rules of the property stay locked from the read to the save, as akamai_property_rule resources
+// may update the rules at the ignored paths at the same time.
+func savePropertyRules(ctx context.Context, client papi.PAPI, Property papi.Property, Rules papi.RulesUpdate, RuleFormat, ConvertTo string, ignorePaths []string) ([]*papi.Error, error) {
+	unlock := lockPropertyRules(Property.PropertyID)
+	defer unlock()
+
+	var Warnings []*papi.Error
+	if ConvertTo != "" || len(ignorePaths) > 0 {
+		var Current papi.RulesUpdate
+		var err error
+		Current, _, _, Warnings, err = fetchConvertedPropertyVersionRules(ctx, client, Property, Property.LatestVersion, ConvertTo)
+		if err != nil {
+			return nil, err
+		}
+		if err := copyRulePaths(&Rules.Rules, &Current.Rules, ignorePaths); err != nil {
+			return nil, err
+		}
+	}
+
+	MIME := fmt.Sprintf("application/vnd.akamai.papirules.%s+json", RuleFormat)
+	h := http.Header{"Content-Type": []string{MIME}}
+	ctx = session.ContextWithOptions(ctx, session.WithContextHeaders(h))
+
+	if err := updatePropertyRules(ctx, client, Property, Rules); err != nil {
+		return nil, err
+	}
+	return Warnings, nil
 }
 
 func resourcePropertyDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
@@ -962,7 +1233,8 @@ func resPropForbiddenAttrs() []string {
 	}
 }
 
-func createProperty(ctx context.Context, client papi.PAPI, PropertyName, GroupID, ContractID, ProductID, RuleFormat string) (PropertyID string, err error) {
+// createProperty creates a property, which is cloned from another property version if CloneFrom is not nil
+func createProperty(ctx context.Context, client papi.PAPI, PropertyName, GroupID, ContractID, ProductID, RuleFormat string, CloneFrom *papi.PropertyCloneFrom) (PropertyID string, err error) {
 	req := papi.CreatePropertyRequest{
 		ContractID: ContractID,
 		GroupID:    GroupID,
@@ -970,6 +1242,7 @@ func createProperty(ctx context.Context, client papi.PAPI, PropertyName, GroupID
 			ProductID:    ProductID,
 			PropertyName: PropertyName,
 			RuleFormat:   RuleFormat,
+			CloneFrom:    CloneFrom,
 		},
 	}
 
@@ -987,6 +1260,58 @@ func createProperty(ctx context.Context, client papi.PAPI, PropertyName, GroupID
 	return
 }
 
+// getPropertyCloneFrom returns the property version to clone from the clone_from block, or nil if it is not set
+func getPropertyCloneFrom(d *schema.ResourceData) (*papi.PropertyCloneFrom, error) {
+	cloneFrom, err := tools.GetListValue("clone_from", d)
+	if err != nil {
+		if errors.Is(err, tools.ErrNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	block, ok := cloneFrom[0].(map[string]interface{})
+	if !ok {
+		return nil, fmt.Errorf("%w: %s, %q", tools.ErrInvalidType, "clone_from", "map[string]interface{}")
+	}
+
+	res := &papi.PropertyCloneFrom{}
+	PropertyID, _ := block["property_id"].(string)
+	res.PropertyID = tools.AddPrefix(PropertyID, "prp_")
+	res.Version, _ = block["version"].(int)
+	res.CloneFromVersionEtag, _ = block["etag"].(string)
+	res.CopyHostnames, _ = block["copy_hostnames"].(bool)
+	return res, nil
+}
+
+// suppressAfterCreate ignores the changes of attributes which are only used when the property is created
+func suppressAfterCreate(_, _, _ string, d *schema.ResourceData) bool {
+	return d.Id() != ""
+}
+
+// suppressCopiedHostnames keeps the hostnames copied from the cloned property as long as no hostnames are configured
+func suppressCopiedHostnames(k, _, new string, d *schema.ResourceData) bool {
+	if copyHostnames, _ := d.Get("clone_from.0.copy_hostnames").(bool); !copyHostnames || d.Id() == "" {
+		return false
+	}
+	if k == "hostnames.#" {
+		return new == "0"
+	}
+
+	// The hostnames read back without configured hostnames are the ones in the state, so a removed hostname is only
+	// found among them if none are configured
+	parts := strings.Split(k, ".")
+	if len(parts) < 2 || new != "" {
+		return false
+	}
+	hostnames := d.Get("hostnames").(*schema.Set)
+	for _, h := range hostnames.List() {
+		if strconv.Itoa(hostnames.F(h)) == parts[1] {
+			return true
+		}
+	}
+	return false
+}
+
 func removeProperty(ctx context.Context, client papi.PAPI, PropertyID, GroupID, ContractID string) error {
 	req := papi.RemovePropertyRequest{
 		PropertyID: PropertyID,
@@ -1217,6 +1542,12 @@ func fetchPropertyVersionHostnames(ctx context.Context, client papi.PAPI, Proper
 
 // Fetch rules for latest version of given property
 func fetchPropertyVersionRules(ctx context.Context, client papi.PAPI, Property papi.Property, version int) (Rules papi.RulesUpdate, Format string, Errors, Warnings []*papi.Error, err error) {
+	return fetchConvertedPropertyVersionRules(ctx, client, Property, version, "")
+}
+
+// Fetch rules for the given property version converted by PAPI to RuleFormat, the rules are returned in their own
+// rule format when RuleFormat is empty
+func fetchConvertedPropertyVersionRules(ctx context.Context, client papi.PAPI, Property papi.Property, version int, RuleFormat string) (Rules papi.RulesUpdate, Format string, Errors, Warnings []*papi.Error, err error) {
 	req := papi.GetRuleTreeRequest{
 		PropertyID:      Property.PropertyID,
 		GroupID:         Property.GroupID,
@@ -1224,6 +1555,7 @@ func fetchPropertyVersionRules(ctx context.Context, client papi.PAPI, Property p
 		PropertyVersion: version,
 		ValidateRules:   true,
 		ValidateMode:    papi.RuleValidateModeFull,
+		RuleFormat:      RuleFormat,
 	}
 
 	logger := log.FromContext(ctx).WithFields(logFields(req))
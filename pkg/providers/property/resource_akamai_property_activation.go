@@ -24,21 +24,20 @@ func resourcePropertyActivation() *schema.Resource {
 		ReadContext:   resourcePropertyActivationRead,
 		UpdateContext: resourcePropertyActivationUpdate,
 		DeleteContext: resourcePropertyActivationDelete,
-		Schema:        akamaiPropertyActivationSchema,
+		CustomizeDiff: validateComplianceRecord,
+		Importer: &schema.ResourceImporter{
+			StateContext: resourcePropertyActivationImport,
+		},
+		Schema: akamaiPropertyActivationSchema,
 		Timeouts: &schema.ResourceTimeout{
 			Default: &PropertyResourceTimeout,
 		},
 	}
 }
 
-const (
-	// ActivationPollMinimum is the minimum polling interval for activation creation
-	ActivationPollMinimum = time.Minute
-)
-
 var (
-	// ActivationPollInterval is the interval for polling an activation status on creation
-	ActivationPollInterval = ActivationPollMinimum
+	// ActivationPollInterval is the first interval for polling an activation status when poll_interval is not set
+	ActivationPollInterval = akamai.DefaultActivationPollInterval
 
 	// PropertyResourceTimeout is the default timeout for the resource operations
 	PropertyResourceTimeout = time.Minute * 90
@@ -115,6 +114,8 @@ var akamaiPropertyActivationSchema = map[string]*schema.Schema{
 		Optional:    true,
 		Description: "assigns a log message to the activation request",
 	},
+	"compliance_record":      complianceRecordSchema(),
+	akamai.PollIntervalField: akamai.PollIntervalSchema(),
 }
 
 func papiError() *schema.Resource {
@@ -230,8 +231,12 @@ func resourcePropertyActivationCreate(ctx context.Context, d *schema.ResourceDat
 		if err != nil && !errors.Is(err, tools.ErrNotFound) {
 			return diag.FromErr(err)
 		}
+		record, err := getComplianceRecord(d)
+		if err != nil {
+			return diag.FromErr(err)
+		}
 
-		create, err := client.CreateActivation(ctx, papi.CreateActivationRequest{
+		create, err := client.CreateActivation(contextWithComplianceRecord(ctx, record), papi.CreateActivationRequest{
 			PropertyID: propertyID,
 			Activation: papi.Activation{
 				ActivationType:         papi.ActivationTypeActivate,
@@ -269,32 +274,13 @@ func resourcePropertyActivationCreate(ctx context.Context, d *schema.ResourceDat
 		return diag.FromErr(fmt.Errorf("%w: %s", tools.ErrValueSet, err.Error()))
 	}
 
-	for activation.Status != papi.ActivationStatusActive {
-		if activation.Status == papi.ActivationStatusAborted {
-			return diag.FromErr(fmt.Errorf("activation request aborted"))
-		}
-		if activation.Status == papi.ActivationStatusFailed {
-			return diag.FromErr(fmt.Errorf("activation request failed in downstream system"))
-		}
-		select {
-		case <-time.After(tools.MaxDuration(ActivationPollInterval, ActivationPollMinimum)):
-			act, err := client.GetActivation(ctx, papi.GetActivationRequest{
-				ActivationID: activation.ActivationID,
-				PropertyID:   propertyID,
-			})
-			if err != nil {
-				return diag.FromErr(err)
-			}
-			activation = act.Activation
-
-		case <-ctx.Done():
-			if errors.Is(ctx.Err(), context.DeadlineExceeded) {
-				return diag.Diagnostics{DiagWarnActivationTimeout}
-			} else if errors.Is(ctx.Err(), context.Canceled) {
-				return diag.Diagnostics{DiagWarnActivationCanceled}
-			}
-			return diag.FromErr(fmt.Errorf("activation context terminated: %w", ctx.Err()))
-		}
+	poller, err := akamai.NewActivationPoller(d, ActivationPollInterval, logger, fmt.Sprintf("property %s activation on %s", propertyID, network))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	activation, err = waitForPropertyActivation(ctx, client, poller, propertyID, activation, "activation", nil)
+	if err != nil {
+		return activationWaitDiags(err)
 	}
 
 	if err := d.Set("version", activation.PropertyVersion); err != nil {
@@ -374,8 +360,12 @@ func resourcePropertyActivationDelete(ctx context.Context, d *schema.ResourceDat
 		if err != nil && !errors.Is(err, tools.ErrNotFound) {
 			return diag.FromErr(err)
 		}
+		record, err := getComplianceRecord(d)
+		if err != nil {
+			return diag.FromErr(err)
+		}
 
-		deleteActivation, err := client.CreateActivation(ctx, papi.CreateActivationRequest{
+		deleteActivation, err := client.CreateActivation(contextWithComplianceRecord(ctx, record), papi.CreateActivationRequest{
 			PropertyID: propertyID,
 			Activation: papi.Activation{
 				ActivationType:         papi.ActivationTypeDeactivate,
@@ -416,39 +406,90 @@ func resourcePropertyActivationDelete(ctx context.Context, d *schema.ResourceDat
 	}
 
 	// deactivations also use status Active for when they are fully processed
-	for activation.Status != papi.ActivationStatusActive {
-		if activation.Status == papi.ActivationStatusAborted {
-			return diag.FromErr(fmt.Errorf("deactivation request aborted"))
-		}
-		if activation.Status == papi.ActivationStatusFailed {
-			return diag.FromErr(fmt.Errorf("deactivation request failed in downstream system"))
-		}
-		select {
-		case <-time.After(tools.MaxDuration(ActivationPollInterval, ActivationPollMinimum)):
-			act, err := client.GetActivation(ctx, papi.GetActivationRequest{
-				ActivationID: activation.ActivationID,
-				PropertyID:   propertyID,
-			})
-			if err != nil {
-				return diag.FromErr(err)
-			}
-			activation = act.Activation
+	poller, err := akamai.NewActivationPoller(d, ActivationPollInterval, logger, fmt.Sprintf("property %s deactivation on %s", propertyID, network))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	if _, err := waitForPropertyActivation(ctx, client, poller, propertyID, activation, "deactivation", setActivationErrors(d)); err != nil {
+		return diag.FromErr(err)
+	}
 
-			if err := d.Set("errors", flattenErrorArray(act.Errors)); err != nil {
-				return diag.FromErr(fmt.Errorf("%w: %s", tools.ErrValueSet, err.Error()))
-			}
-			if err := d.Set("warnings", flattenErrorArray(act.Warnings)); err != nil {
-				return diag.FromErr(fmt.Errorf("%w: %s", tools.ErrValueSet, err.Error()))
-			}
+	d.SetId("")
+
+	return nil
+}
 
-		case <-ctx.Done():
-			return diag.FromErr(fmt.Errorf("activation context terminated: %w", ctx.Err()))
+// waitForActivation polls an activation with the status function until it is active, which is also the final status
+// of deactivations. An error is returned when the activation is aborted or fails, or when the context is done before.
+func waitForActivation(ctx context.Context, poller *akamai.ActivationPoller, kind string, status papi.ActivationStatus,
+	poll func() (papi.ActivationStatus, error)) error {
+	for status != papi.ActivationStatusActive {
+		if status == papi.ActivationStatusAborted {
+			return fmt.Errorf("%s request aborted", kind)
+		}
+		if status == papi.ActivationStatusFailed {
+			return fmt.Errorf("%s request failed in downstream system", kind)
+		}
+		if err := poller.Wait(ctx, string(status)); err != nil {
+			return fmt.Errorf("activation context terminated: %w", err)
+		}
+		var err error
+		if status, err = poll(); err != nil {
+			return err
 		}
 	}
+	return nil
+}
 
-	d.SetId("")
+// waitForPropertyActivation polls the property activation until it is active, see waitForActivation.
+// The update function, if set, is called with every polled activation.
+func waitForPropertyActivation(ctx context.Context, client papi.PAPI, poller *akamai.ActivationPoller, propertyID string,
+	activation *papi.Activation, kind string, update func(*papi.GetActivationResponse) error) (*papi.Activation, error) {
+	err := waitForActivation(ctx, poller, kind, activation.Status, func() (papi.ActivationStatus, error) {
+		act, err := client.GetActivation(ctx, papi.GetActivationRequest{
+			ActivationID: activation.ActivationID,
+			PropertyID:   propertyID,
+		})
+		if err != nil {
+			return "", err
+		}
+		activation = act.Activation
 
-	return nil
+		if update != nil {
+			if err := update(act); err != nil {
+				return "", err
+			}
+		}
+		return activation.Status, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return activation, nil
+}
+
+// activationWaitDiags returns the diagnostics of an activation which could not be waited for. The activation
+// continues when the wait times out or is canceled, so these are only warnings.
+func activationWaitDiags(err error) diag.Diagnostics {
+	if errors.Is(err, context.DeadlineExceeded) {
+		return diag.Diagnostics{DiagWarnActivationTimeout}
+	} else if errors.Is(err, context.Canceled) {
+		return diag.Diagnostics{DiagWarnActivationCanceled}
+	}
+	return diag.FromErr(err)
+}
+
+// setActivationErrors returns a function setting the errors and warnings of a polled activation
+func setActivationErrors(d *schema.ResourceData) func(*papi.GetActivationResponse) error {
+	return func(act *papi.GetActivationResponse) error {
+		if err := d.Set("errors", flattenErrorArray(act.Errors)); err != nil {
+			return fmt.Errorf("%w: %s", tools.ErrValueSet, err.Error())
+		}
+		if err := d.Set("warnings", flattenErrorArray(act.Warnings)); err != nil {
+			return fmt.Errorf("%w: %s", tools.ErrValueSet, err.Error())
+		}
+		return nil
+	}
 }
 
 func flattenErrorArray(errors []*papi.Error) string {
@@ -661,8 +702,12 @@ func resourcePropertyActivationUpdate(ctx context.Context, d *schema.ResourceDat
 		for _, contact := range notifySet.List() {
 			notify = append(notify, cast.ToString(contact))
 		}
+		record, err := getComplianceRecord(d)
+		if err != nil {
+			return diag.FromErr(err)
+		}
 
-		create, err := client.CreateActivation(ctx, papi.CreateActivationRequest{
+		create, err := client.CreateActivation(contextWithComplianceRecord(ctx, record), papi.CreateActivationRequest{
 			PropertyID: propertyID,
 			Activation: papi.Activation{
 				ActivationType:         papi.ActivationTypeActivate,
@@ -706,34 +751,13 @@ func resourcePropertyActivationUpdate(ctx context.Context, d *schema.ResourceDat
 		return diag.FromErr(fmt.Errorf("%w: %s", tools.ErrValueSet, err.Error()))
 	}
 
-	for propertyActivation.Status != papi.ActivationStatusActive {
-		if propertyActivation.Status == papi.ActivationStatusAborted {
-			return diag.FromErr(fmt.Errorf("activation request aborted"))
-		}
-		if propertyActivation.Status == papi.ActivationStatusFailed {
-			return diag.FromErr(fmt.Errorf("activation request failed in downstream system"))
-		}
-		select {
-		case <-time.After(tools.MaxDuration(ActivationPollInterval, ActivationPollMinimum)):
-			act, err := client.GetActivation(ctx, papi.GetActivationRequest{
-				ActivationID: propertyActivation.ActivationID,
-				PropertyID:   propertyID,
-			})
-			if err != nil {
-				return diag.FromErr(err)
-			}
-			propertyActivation = act.Activation
-
-			if err := d.Set("errors", flattenErrorArray(act.Errors)); err != nil {
-				return diag.FromErr(fmt.Errorf("%w: %s", tools.ErrValueSet, err.Error()))
-			}
-			if err := d.Set("warnings", flattenErrorArray(act.Warnings)); err != nil {
-				return diag.FromErr(fmt.Errorf("%w: %s", tools.ErrValueSet, err.Error()))
-			}
-
-		case <-ctx.Done():
-			return diag.FromErr(fmt.Errorf("activation context terminated: %w", ctx.Err()))
-		}
+	poller, err := akamai.NewActivationPoller(d, ActivationPollInterval, logger, fmt.Sprintf("property %s activation on %s", propertyID, network))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	propertyActivation, err = waitForPropertyActivation(ctx, client, poller, propertyID, propertyActivation, "activation", setActivationErrors(d))
+	if err != nil {
+		return diag.FromErr(err)
 	}
 
 	if err := d.Set("version", propertyActivation.PropertyVersion); err != nil {
@@ -757,6 +781,69 @@ func resourcePropertyActivationUpdate(ctx context.Context, d *schema.ResourceDat
 	return nil
 }
 
+func resourcePropertyActivationImport(ctx context.Context, d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
+	meta := akamai.Meta(m)
+	logger := meta.Log("PAPI", "resourcePropertyActivationImport")
+	client := inst.Client(meta)
+	logger.Debugf("Import property activation")
+
+	// create a context with logging for api calls
+	ctx = session.ContextWithOptions(
+		ctx,
+		session.WithContextLog(logger),
+	)
+
+	parts := strings.Split(d.Id(), ":")
+	if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+		return nil, fmt.Errorf("colon-separated property ID and network have to be supplied in import: %s", d.Id())
+	}
+	propertyID := tools.AddPrefix(parts[0], "prp_")
+	alias, err := NetworkAlias(parts[1])
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", parts[1], err)
+	}
+	network := papi.ActivationNetwork(alias)
+
+	// version is not set on import, so the version active on the network is resolved
+	version, err := resolveVersion(ctx, d, client, propertyID, network)
+	if err != nil {
+		return nil, fmt.Errorf("could not find the version of property %s active on %s: %w", propertyID, network, err)
+	}
+
+	activation, err := lookupActivation(ctx, client, lookupActivationRequest{
+		propertyID: propertyID,
+		version:    version,
+		network:    network,
+		activationType: map[papi.ActivationType]struct{}{
+			papi.ActivationTypeActivate: {},
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+	if activation == nil {
+		return nil, fmt.Errorf("property %s has no activation of version %d on %s", propertyID, version, network)
+	}
+
+	attrs := map[string]interface{}{
+		"property_id":                    propertyID,
+		"network":                        string(network),
+		"version":                        version,
+		"activation_id":                  activation.ActivationID,
+		"status":                         string(activation.Status),
+		"contact":                        activation.NotifyEmails,
+		"note":                           activation.Note,
+		"auto_acknowledge_rule_warnings": true,
+	}
+	if err := tools.SetAttrs(d, attrs); err != nil {
+		return nil, fmt.Errorf("%w: %s", tools.ErrValueSet, err.Error())
+	}
+
+	d.SetId(propertyID + ":" + string(network))
+	logger.Debugf("Import property activation: %s version %d", d.Id(), version)
+	return []*schema.ResourceData{d}, nil
+}
+
 func resolvePropertyID(d *schema.ResourceData) (string, error) {
 	propertyID, err := tools.GetStringValue("property_id", d)
 	if errors.Is(err, tools.ErrNotFound) {
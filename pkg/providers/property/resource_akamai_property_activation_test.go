@@ -1,13 +1,23 @@
 package property
 
 import (
+	"context"
+	"errors"
 	"fmt"
 	"regexp"
 	"testing"
+	"time"
 
+	"github.com/apex/log"
+	"github.com/apex/log/handlers/discard"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/tj/assert"
 
 	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v2/pkg/papi"
+	"github.com/akamai/terraform-provider-akamai/v2/pkg/akamai"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 )
 
@@ -206,6 +216,51 @@ func TestResourcePAPIPropertyActivation(t *testing.T) {
 				},
 			},
 		},
+		"import property activation": {
+			init: func(m *mockpapi) {
+				// create
+				expectGetRuleTree(m, "prp_test", 1, ruleTreeResponseValid, nil).Once()
+				expectGetActivations(m, "prp_test", papi.GetActivationsResponse{}, nil).Once()
+				expectCreateActivation(m, "prp_test", papi.ActivationTypeActivate, 1, "STAGING",
+					[]string{"user@example.com"}, "property activation note for creating", "atv_activation1", nil).Once()
+				expectGetActivation(m, "prp_test", "atv_activation1", 1, "STAGING", papi.ActivationStatusActive, nil).Once()
+				// import resolves the version active on the network
+				m.On("GetLatestVersion", mock.Anything, papi.GetLatestVersionRequest{
+					PropertyID:  "prp_test",
+					ActivatedOn: "STAGING",
+				}).Return(&papi.GetPropertyVersionsResponse{
+					Version: papi.PropertyVersionGetItem{PropertyVersion: 1},
+				}, nil)
+				// reads, import and delete
+				expectGetActivations(m, "prp_test", activationsResponseImported, nil)
+				expectCreateActivation(m, "prp_test", papi.ActivationTypeDeactivate, 1, "STAGING",
+					[]string{"user@example.com"}, "property activation note for creating", "atv_deactivation", nil).Once()
+				expectGetActivation(m, "prp_test", "atv_deactivation", 1, "STAGING", papi.ActivationStatusActive, nil).Once()
+			},
+			steps: []resource.TestStep{
+				{
+					Config: loadFixtureString("./testdata/TestPropertyActivation/ok/resource_property_activation.tf"),
+				},
+				{
+					Config:            loadFixtureString("./testdata/TestPropertyActivation/ok/resource_property_activation.tf"),
+					ResourceName:      "akamai_property_activation.test",
+					ImportState:       true,
+					ImportStateId:     "prp_test:STAGING",
+					ImportStateVerify: true,
+				},
+			},
+		},
+		"import property activation - invalid ID": {
+			steps: []resource.TestStep{
+				{
+					Config:        loadFixtureString("./testdata/TestPropertyActivation/ok/resource_property_activation.tf"),
+					ResourceName:  "akamai_property_activation.test",
+					ImportState:   true,
+					ImportStateId: "prp_test",
+					ExpectError:   regexp.MustCompile("colon-separated property ID and network have to be supplied in import"),
+				},
+			},
+		},
 	}
 
 	for name, test := range tests {
@@ -313,6 +368,22 @@ var (
 			},
 		}},
 	}
+	activationsResponseImported = papi.GetActivationsResponse{
+		Activations: papi.ActivationsItems{Items: []*papi.Activation{{
+			AccountID:       "act_1-6JHGX",
+			ActivationID:    "atv_activation1",
+			ActivationType:  "ACTIVATE",
+			GroupID:         "grp_91533",
+			PropertyName:    "test",
+			PropertyID:      "prp_test",
+			PropertyVersion: 1,
+			Network:         "STAGING",
+			Status:          "ACTIVE",
+			SubmitDate:      "2020-10-28T15:04:05Z",
+			NotifyEmails:    []string{"user@example.com"},
+			Note:            "property activation note for creating",
+		}}},
+	}
 	expectGetActivations = func(m *mockpapi, propertyID string, response papi.GetActivationsResponse, err error) *mock.Call {
 		if err != nil {
 			return m.On(
@@ -397,3 +468,81 @@ var (
 		}, nil)
 	}
 )
+
+func TestWaitForActivation(t *testing.T) {
+	tests := map[string]struct {
+		statuses    []papi.ActivationStatus
+		pollErr     error
+		timeout     time.Duration
+		withError   string
+		withWarning bool
+	}{
+		"active after polling": {
+			statuses: []papi.ActivationStatus{papi.ActivationStatusPending, papi.ActivationStatusZone1, papi.ActivationStatusActive},
+		},
+		"already active": {
+			statuses: []papi.ActivationStatus{papi.ActivationStatusActive},
+		},
+		"aborted": {
+			statuses:  []papi.ActivationStatus{papi.ActivationStatusPending, papi.ActivationStatusAborted},
+			withError: "test request aborted",
+		},
+		"failed": {
+			statuses:  []papi.ActivationStatus{papi.ActivationStatusPending, papi.ActivationStatusFailed},
+			withError: "test request failed in downstream system",
+		},
+		"poll error": {
+			statuses:  []papi.ActivationStatus{papi.ActivationStatusPending},
+			pollErr:   errors.New("oops"),
+			withError: "oops",
+		},
+		"timeout": {
+			statuses:    []papi.ActivationStatus{papi.ActivationStatusPending},
+			timeout:     time.Millisecond,
+			withWarning: true,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			d := schema.TestResourceDataRaw(t, map[string]*schema.Schema{
+				akamai.PollIntervalField: akamai.PollIntervalSchema(),
+			}, map[string]interface{}{})
+			interval := time.Millisecond
+			if test.timeout > 0 {
+				interval = time.Minute
+			}
+			poller, err := akamai.NewActivationPoller(d, interval, &log.Logger{Handler: discard.New()}, "test")
+			require.NoError(t, err)
+
+			ctx := context.Background()
+			if test.timeout > 0 {
+				var cancel context.CancelFunc
+				ctx, cancel = context.WithTimeout(ctx, test.timeout)
+				defer cancel()
+			}
+
+			polls := 0
+			err = waitForActivation(ctx, poller, "test", test.statuses[0], func() (papi.ActivationStatus, error) {
+				polls++
+				if test.pollErr != nil {
+					return "", test.pollErr
+				}
+				return test.statuses[polls], nil
+			})
+			if test.withWarning {
+				diags := activationWaitDiags(err)
+				require.Len(t, diags, 1)
+				assert.Equal(t, diag.Warning, diags[0].Severity)
+				return
+			}
+			if test.withError != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), test.withError)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, len(test.statuses)-1, polls)
+		})
+	}
+}
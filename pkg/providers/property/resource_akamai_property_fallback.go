@@ -0,0 +1,284 @@
+package property
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/spf13/cast"
+
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v2/pkg/papi"
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v2/pkg/session"
+	"github.com/akamai/terraform-provider-akamai/v2/pkg/akamai"
+	"github.com/akamai/terraform-provider-akamai/v2/pkg/tools"
+)
+
+func resourcePropertyFallback() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourcePropertyFallbackCreate,
+		ReadContext:   resourcePropertyFallbackRead,
+		UpdateContext: resourcePropertyFallbackUpdate,
+		DeleteContext: resourcePropertyFallbackDelete,
+		Schema:        akamaiPropertyFallbackSchema,
+		Timeouts: &schema.ResourceTimeout{
+			Default: &PropertyResourceTimeout,
+		},
+	}
+}
+
+var (
+	// ErrFastFallbackNotAvailable is returned when an activation can not fall back to the previous version
+	ErrFastFallbackNotAvailable = errors.New("fast fallback is not available")
+
+	// ErrFastFallbackExpired is returned when the fast fallback window of an activation is closed
+	ErrFastFallbackExpired = errors.New("fast fallback window is closed")
+)
+
+var akamaiPropertyFallbackSchema = map[string]*schema.Schema{
+	"property_id": {
+		Type:             schema.TypeString,
+		Required:         true,
+		ForceNew:         true,
+		StateFunc:        addPrefixToState("prp_"),
+		ValidateDiagFunc: tools.IsNotBlank,
+	},
+	"network": {
+		Type:        schema.TypeString,
+		Optional:    true,
+		ForceNew:    true,
+		Default:     papi.ActivationNetworkProduction,
+		Description: "The network of the activation to fall back from, PRODUCTION by default",
+	},
+	"activation_id": {
+		Type:        schema.TypeString,
+		Optional:    true,
+		Computed:    true,
+		ForceNew:    true,
+		Description: "The activation to fall back from, the latest activation on the network is used if not set",
+	},
+	"contact": {
+		Type:     schema.TypeSet,
+		Required: true,
+		Elem:     &schema.Schema{Type: schema.TypeString},
+	},
+	"note": {
+		Type:        schema.TypeString,
+		Optional:    true,
+		Description: "assigns a log message to the fallback activation request",
+	},
+	"fallback_version": {
+		Type:        schema.TypeInt,
+		Computed:    true,
+		Description: "The property version that was active before the activation and is active after the fallback",
+	},
+	"status": {
+		Type:     schema.TypeString,
+		Computed: true,
+	},
+	akamai.PollIntervalField: akamai.PollIntervalSchema(),
+}
+
+func resourcePropertyFallbackCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	meta := akamai.Meta(m)
+	logger := meta.Log("PAPI", "resourcePropertyFallbackCreate")
+	client := inst.Client(meta)
+
+	logger.Debug("resourcePropertyFallbackCreate call")
+
+	// create a context with logging for api calls
+	ctx = session.ContextWithOptions(
+		ctx,
+		session.WithContextLog(logger),
+	)
+
+	propertyID, err := resolvePropertyID(d)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	network, err := networkAlias(d)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	activationID, err := tools.GetStringValue("activation_id", d)
+	if err != nil && !errors.Is(err, tools.ErrNotFound) {
+		return diag.FromErr(err)
+	}
+	if activationID == "" {
+		latest, err := latestActivation(ctx, client, propertyID, network)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+		activationID = latest.ActivationID
+	}
+
+	act, err := client.GetActivation(ctx, papi.GetActivationRequest{
+		PropertyID:   propertyID,
+		ActivationID: activationID,
+	})
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	version, err := checkFastFallback(act.Activation, time.Now())
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	notifySet, err := tools.GetSetValue("contact", d)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	var notify []string
+	for _, contact := range notifySet.List() {
+		notify = append(notify, cast.ToString(contact))
+	}
+	note, err := tools.GetStringValue("note", d)
+	if err != nil && !errors.Is(err, tools.ErrNotFound) {
+		return diag.FromErr(err)
+	}
+
+	logger.Infof("falling back from activation %s to version %d of property %s on %s", activationID, version, propertyID, network)
+	create, err := client.CreateActivation(ctx, papi.CreateActivationRequest{
+		PropertyID: propertyID,
+		Activation: papi.Activation{
+			ActivationType:         papi.ActivationTypeActivate,
+			Network:                network,
+			PropertyVersion:        version,
+			NotifyEmails:           notify,
+			AcknowledgeAllWarnings: true,
+			UseFastFallback:        true,
+			Note:                   note,
+		},
+	})
+	if err != nil {
+		return diag.FromErr(fmt.Errorf("create fallback activation failed: %w", err))
+	}
+	d.SetId(create.ActivationID)
+
+	attrs := map[string]interface{}{
+		"property_id":      propertyID,
+		"network":          string(network),
+		"activation_id":    activationID,
+		"fallback_version": version,
+	}
+	if err := tools.SetAttrs(d, attrs); err != nil {
+		return diag.FromErr(fmt.Errorf("%w: %s", tools.ErrValueSet, err.Error()))
+	}
+
+	fallback, err := client.GetActivation(ctx, papi.GetActivationRequest{
+		PropertyID:   propertyID,
+		ActivationID: create.ActivationID,
+	})
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	poller, err := akamai.NewActivationPoller(d, ActivationPollInterval, logger, fmt.Sprintf("property %s fallback on %s", propertyID, network))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	activation, err := waitForPropertyActivation(ctx, client, poller, propertyID, fallback.Activation, "fallback", nil)
+	if err != nil {
+		return activationWaitDiags(err)
+	}
+
+	if err := d.Set("status", string(activation.Status)); err != nil {
+		return diag.FromErr(fmt.Errorf("%w: %s", tools.ErrValueSet, err.Error()))
+	}
+	return nil
+}
+
+func resourcePropertyFallbackRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	meta := akamai.Meta(m)
+	logger := meta.Log("PAPI", "resourcePropertyFallbackRead")
+	client := inst.Client(meta)
+
+	logger.Debug("resourcePropertyFallbackRead call")
+	// create a context with logging for api calls
+	ctx = session.ContextWithOptions(
+		ctx,
+		session.WithContextLog(logger),
+	)
+
+	propertyID, err := resolvePropertyID(d)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	act, err := client.GetActivation(ctx, papi.GetActivationRequest{
+		PropertyID:   propertyID,
+		ActivationID: d.Id(),
+	})
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	attrs := map[string]interface{}{
+		"fallback_version": act.Activation.PropertyVersion,
+		"status":           string(act.Activation.Status),
+	}
+	if err := tools.SetAttrs(d, attrs); err != nil {
+		return diag.FromErr(fmt.Errorf("%w: %s", tools.ErrValueSet, err.Error()))
+	}
+	return nil
+}
+
+func resourcePropertyFallbackUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	// contact, note and poll_interval are only used when the fallback is submitted
+	return resourcePropertyFallbackRead(ctx, d, m)
+}
+
+func resourcePropertyFallbackDelete(_ context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	meta := akamai.Meta(m)
+	logger := meta.Log("PAPI", "resourcePropertyFallbackDelete")
+
+	logger.Warnf("fallback activation %s can not be reverted, removing it from the state only", d.Id())
+	d.SetId("")
+	return nil
+}
+
+// latestActivation returns the most recent completed activation of the property on the network
+func latestActivation(ctx context.Context, client papi.PAPI, propertyID string, network papi.ActivationNetwork) (*papi.Activation, error) {
+	activations, err := client.GetActivations(ctx, papi.GetActivationsRequest{
+		PropertyID: propertyID,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var latest *papi.Activation
+	var latestSubmitDate time.Time
+	for _, a := range activations.Activations.Items {
+		if a.Network != network || a.ActivationType != papi.ActivationTypeActivate || a.Status != papi.ActivationStatusActive {
+			continue
+		}
+		submitDate, err := tools.ParseDate(tools.DateTimeFormat, a.SubmitDate)
+		if err != nil {
+			return nil, err
+		}
+		if latest == nil || latestSubmitDate.Before(submitDate) {
+			latest = a
+			latestSubmitDate = submitDate
+		}
+	}
+	if latest == nil {
+		return nil, fmt.Errorf("%w: property %s has no active version on %s", ErrFastFallbackNotAvailable, propertyID, network)
+	}
+	return latest, nil
+}
+
+// checkFastFallback returns the version the activation can fall back to, or an error if the fallback window is closed
+func checkFastFallback(activation *papi.Activation, now time.Time) (int, error) {
+	info := activation.FallbackInfo
+	if info == nil || !info.CanFastFallback || info.FallbackVersion == 0 {
+		return 0, fmt.Errorf("%w: activation %s of version %d", ErrFastFallbackNotAvailable, activation.ActivationID, activation.PropertyVersion)
+	}
+	expiration := time.Unix(int64(info.FastFallbackExpirationTime), 0)
+	if !now.Before(expiration) {
+		return 0, fmt.Errorf("%w: activation %s could fall back until %s", ErrFastFallbackExpired, activation.ActivationID, expiration.UTC().Format(time.RFC3339))
+	}
+	return info.FallbackVersion, nil
+}
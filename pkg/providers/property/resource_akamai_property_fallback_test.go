@@ -0,0 +1,164 @@
+package property
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v2/pkg/papi"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/tj/assert"
+)
+
+func TestResourcePropertyFallback(t *testing.T) {
+	expiration := int(time.Now().Add(30 * time.Minute).Unix())
+	client := &mockpapi{}
+	expectGetActivations(client, "prp_test", activationsResponseFallback, nil).Once()
+	client.On("GetActivation", mock.Anything, papi.GetActivationRequest{
+		PropertyID:   "prp_test",
+		ActivationID: "atv_prod2",
+	}).Return(&papi.GetActivationResponse{
+		Activation: &papi.Activation{
+			ActivationID:    "atv_prod2",
+			PropertyVersion: 2,
+			Network:         papi.ActivationNetworkProduction,
+			Status:          papi.ActivationStatusActive,
+			FallbackInfo: &papi.ActivationFallbackInfo{
+				CanFastFallback:            true,
+				FallbackVersion:            1,
+				FastFallbackExpirationTime: expiration,
+			},
+		},
+	}, nil).Once()
+	client.On("CreateActivation", mock.Anything, papi.CreateActivationRequest{
+		PropertyID: "prp_test",
+		Activation: papi.Activation{
+			ActivationType:         papi.ActivationTypeActivate,
+			Network:                papi.ActivationNetworkProduction,
+			PropertyVersion:        1,
+			NotifyEmails:           []string{"user@example.com"},
+			AcknowledgeAllWarnings: true,
+			UseFastFallback:        true,
+			Note:                   "roll back release 2",
+		},
+	}).Return(&papi.CreateActivationResponse{ActivationID: "atv_fallback"}, nil).Once()
+	expectGetActivation(client, "prp_test", "atv_fallback", 1, papi.ActivationNetworkProduction, papi.ActivationStatusActive, nil)
+
+	useClient(client, func() {
+		resource.UnitTest(t, resource.TestCase{
+			Providers: testAccProviders,
+			Steps: []resource.TestStep{{
+				Config: loadFixtureString("testdata/TestResPropertyFallback/property_fallback.tf"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("akamai_property_fallback.test", "id", "atv_fallback"),
+					resource.TestCheckResourceAttr("akamai_property_fallback.test", "activation_id", "atv_prod2"),
+					resource.TestCheckResourceAttr("akamai_property_fallback.test", "fallback_version", "1"),
+					resource.TestCheckResourceAttr("akamai_property_fallback.test", "status", "ACTIVE"),
+				),
+			}},
+		})
+	})
+
+	client.AssertExpectations(t)
+}
+
+func TestCheckFastFallback(t *testing.T) {
+	now := time.Date(2021, 6, 1, 12, 0, 0, 0, time.UTC)
+
+	tests := map[string]struct {
+		activation      *papi.Activation
+		expectedVersion int
+		withError       error
+	}{
+		"window open": {
+			activation: &papi.Activation{ActivationID: "atv_1", PropertyVersion: 2, FallbackInfo: &papi.ActivationFallbackInfo{
+				CanFastFallback:            true,
+				FallbackVersion:            1,
+				FastFallbackExpirationTime: int(now.Add(time.Minute).Unix()),
+			}},
+			expectedVersion: 1,
+		},
+		"window closed": {
+			activation: &papi.Activation{ActivationID: "atv_1", PropertyVersion: 2, FallbackInfo: &papi.ActivationFallbackInfo{
+				CanFastFallback:            true,
+				FallbackVersion:            1,
+				FastFallbackExpirationTime: int(now.Add(-time.Minute).Unix()),
+			}},
+			withError: ErrFastFallbackExpired,
+		},
+		"cannot fall back": {
+			activation: &papi.Activation{ActivationID: "atv_1", PropertyVersion: 2, FallbackInfo: &papi.ActivationFallbackInfo{
+				FallbackVersion:            1,
+				FastFallbackExpirationTime: int(now.Add(time.Minute).Unix()),
+			}},
+			withError: ErrFastFallbackNotAvailable,
+		},
+		"no fallback info": {
+			activation: &papi.Activation{ActivationID: "atv_1", PropertyVersion: 1},
+			withError:  ErrFastFallbackNotAvailable,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			version, err := checkFastFallback(test.activation, now)
+			if test.withError != nil {
+				assert.True(t, errors.Is(err, test.withError), "want: %s; got: %s", test.withError, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, test.expectedVersion, version)
+		})
+	}
+}
+
+func TestLatestActivation(t *testing.T) {
+	t.Run("latest production activation", func(t *testing.T) {
+		client := &mockpapi{}
+		expectGetActivations(client, "prp_test", activationsResponseFallback, nil)
+
+		activation, err := latestActivation(context.Background(), client, "prp_test", papi.ActivationNetworkProduction)
+		require.NoError(t, err)
+		assert.Equal(t, "atv_prod2", activation.ActivationID)
+	})
+
+	t.Run("no activation on network", func(t *testing.T) {
+		client := &mockpapi{}
+		expectGetActivations(client, "prp_test", papi.GetActivationsResponse{}, nil)
+
+		_, err := latestActivation(context.Background(), client, "prp_test", papi.ActivationNetworkProduction)
+		assert.True(t, errors.Is(err, ErrFastFallbackNotAvailable))
+	})
+}
+
+var activationsResponseFallback = papi.GetActivationsResponse{
+	Activations: papi.ActivationsItems{Items: []*papi.Activation{
+		{
+			ActivationID:    "atv_prod1",
+			ActivationType:  papi.ActivationTypeActivate,
+			PropertyVersion: 1,
+			Network:         papi.ActivationNetworkProduction,
+			Status:          papi.ActivationStatusActive,
+			SubmitDate:      "2021-06-01T10:00:00Z",
+		},
+		{
+			ActivationID:    "atv_prod2",
+			ActivationType:  papi.ActivationTypeActivate,
+			PropertyVersion: 2,
+			Network:         papi.ActivationNetworkProduction,
+			Status:          papi.ActivationStatusActive,
+			SubmitDate:      "2021-06-01T11:30:00Z",
+		},
+		{
+			ActivationID:    "atv_staging3",
+			ActivationType:  papi.ActivationTypeActivate,
+			PropertyVersion: 3,
+			Network:         papi.ActivationNetworkStaging,
+			Status:          papi.ActivationStatusActive,
+			SubmitDate:      "2021-06-01T11:45:00Z",
+		},
+	}},
+}
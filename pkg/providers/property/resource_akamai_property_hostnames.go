@@ -0,0 +1,550 @@
+package property
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/apex/log"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v2/pkg/papi"
+	"github.com/akamai/terraform-provider-akamai/v2/pkg/akamai"
+	"github.com/akamai/terraform-provider-akamai/v2/pkg/tools"
+)
+
+const (
+	// maxHostnamesPerPatch is the number of hostnames added or removed by a single hostname bucket patch
+	maxHostnamesPerPatch = 1000
+)
+
+func resourcePropertyHostnames() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourcePropertyHostnamesCreate,
+		ReadContext:   resourcePropertyHostnamesRead,
+		UpdateContext: resourcePropertyHostnamesUpdate,
+		DeleteContext: resourcePropertyHostnamesDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: resourcePropertyHostnamesImport,
+		},
+		Timeouts: &schema.ResourceTimeout{
+			Default: &PropertyResourceTimeout,
+		},
+		Schema: map[string]*schema.Schema{
+			"property_id": {
+				Type:             schema.TypeString,
+				Required:         true,
+				ForceNew:         true,
+				StateFunc:        addPrefixToState("prp_"),
+				ValidateDiagFunc: tools.IsNotBlank,
+				Description:      "ID of the hostname bucket property",
+			},
+			"contract_id": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Computed:    true,
+				ForceNew:    true,
+				StateFunc:   addPrefixToState("ctr_"),
+				Description: "Contract ID of the property, looked up from the property if not set",
+			},
+			"group_id": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Computed:    true,
+				ForceNew:    true,
+				StateFunc:   addPrefixToState("grp_"),
+				Description: "Group ID of the property, looked up from the property if not set",
+			},
+			"network": {
+				Type:             schema.TypeString,
+				Optional:         true,
+				ForceNew:         true,
+				Default:          papi.ActivationNetworkStaging,
+				ValidateDiagFunc: tools.ValidateNetwork,
+				StateFunc: func(v interface{}) string {
+					if alias, err := NetworkAlias(v.(string)); err == nil {
+						return alias
+					}
+					return v.(string)
+				},
+			},
+			"hostnames": {
+				Type:        schema.TypeSet,
+				Required:    true,
+				Description: "The hostnames of the property on the network managed by this resource, other hostnames of the property are left as they are",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"cname_from": {
+							Type:             schema.TypeString,
+							Required:         true,
+							ValidateDiagFunc: tools.IsNotBlank,
+						},
+						"edge_hostname_id": {
+							Type:             schema.TypeString,
+							Required:         true,
+							ValidateDiagFunc: tools.IsNotBlank,
+							Description:      "ID of the edge hostname, including the ehn_ prefix",
+						},
+						"cert_provisioning_type": {
+							Type:     schema.TypeString,
+							Optional: true,
+							Default:  "CPS_MANAGED",
+						},
+						"cname_type": {
+							Type:     schema.TypeString,
+							Optional: true,
+							Default:  string(papi.HostnameCnameTypeEdgeHostname),
+						},
+					},
+				},
+			},
+			"note": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "assigns a log message to the hostname activations",
+			},
+			"notify_emails": {
+				Type:     schema.TypeSet,
+				Optional: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+			"activation_id": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "ID of the last hostname activation",
+			},
+			"cert_status": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "Certificate provisioning status of each managed hostname",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"cname_from": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"cname_to": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"target": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"hostname": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"production_status": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"staging_status": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+					},
+				},
+			},
+			akamai.PollIntervalField: akamai.PollIntervalSchema(),
+		},
+	}
+}
+
+func resourcePropertyHostnamesCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	meta := akamai.Meta(m)
+	ctx = log.NewContext(ctx, meta.Log("PAPI", "resourcePropertyHostnamesCreate"))
+
+	request, err := getPropertyHostnamesRequest(ctx, d, m)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	if err := tools.SetAttrs(d, map[string]interface{}{
+		"property_id": request.PropertyID,
+		"contract_id": request.ContractID,
+		"group_id":    request.GroupID,
+		"network":     string(request.Network),
+	}); err != nil {
+		return diag.FromErr(fmt.Errorf("%w: %s", tools.ErrValueSet, err.Error()))
+	}
+
+	client := inst.HostnameBucketsClient(meta)
+	current, err := client.ListPropertyHostnames(ctx, request)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	// hostnames already on the network with the same settings are not patched again
+	var diags diag.Diagnostics
+	add, _ := hostnamesToPatch(current, mapToBucketHostnames(d.Get("hostnames").(*schema.Set).List()))
+	if err := patchPropertyHostnames(ctx, d, client, request, add, nil); err != nil {
+		if diags = activationWaitDiags(err); diags.HasError() {
+			return diags
+		}
+	}
+
+	d.SetId(fmt.Sprintf("%s:%s", request.PropertyID, request.Network))
+	return append(diags, resourcePropertyHostnamesRead(ctx, d, m)...)
+}
+
+func resourcePropertyHostnamesRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	meta := akamai.Meta(m)
+	ctx = log.NewContext(ctx, meta.Log("PAPI", "resourcePropertyHostnamesRead"))
+
+	request, err := getPropertyHostnamesRequest(ctx, d, m)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	current, err := inst.HostnameBucketsClient(meta).ListPropertyHostnames(ctx, request)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	// only the hostnames managed by the resource are kept, hostnames removed outside of terraform are dropped
+	hostnames := managedHostnames(current, mapToBucketHostnames(d.Get("hostnames").(*schema.Set).List()))
+
+	attrs := map[string]interface{}{
+		"hostnames":   flattenBucketHostnames(hostnames),
+		"cert_status": flattenBucketCertStatus(hostnames),
+	}
+	if err := tools.SetAttrs(d, attrs); err != nil {
+		return diag.FromErr(fmt.Errorf("%w: %s", tools.ErrValueSet, err.Error()))
+	}
+	return nil
+}
+
+func resourcePropertyHostnamesUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	meta := akamai.Meta(m)
+	ctx = log.NewContext(ctx, meta.Log("PAPI", "resourcePropertyHostnamesUpdate"))
+
+	if !d.HasChange("hostnames") {
+		return nil
+	}
+	request, err := getPropertyHostnamesRequest(ctx, d, m)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	client := inst.HostnameBucketsClient(meta)
+	current, err := client.ListPropertyHostnames(ctx, request)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	// the hostnames are compared with the ones on the network, which may differ from the state after a failed
+	// patch or changes outside of terraform, and only the hostnames managed by the resource are removed
+	var diags diag.Diagnostics
+	o, n := d.GetChange("hostnames")
+	newHostnames := mapToBucketHostnames(n.(*schema.Set).List())
+	add, _ := hostnamesToPatch(current, newHostnames)
+	_, remove := hostnamesToPatch(managedHostnames(current, mapToBucketHostnames(o.(*schema.Set).List())), newHostnames)
+	if err := patchPropertyHostnames(ctx, d, client, request, add, remove); err != nil {
+		if diags = activationWaitDiags(err); diags.HasError() {
+			d.Partial(true)
+			return diags
+		}
+	}
+
+	return append(diags, resourcePropertyHostnamesRead(ctx, d, m)...)
+}
+
+func resourcePropertyHostnamesDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	meta := akamai.Meta(m)
+	ctx = log.NewContext(ctx, meta.Log("PAPI", "resourcePropertyHostnamesDelete"))
+
+	request, err := getPropertyHostnamesRequest(ctx, d, m)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	client := inst.HostnameBucketsClient(meta)
+	current, err := client.ListPropertyHostnames(ctx, request)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	var diags diag.Diagnostics
+	_, remove := hostnamesToPatch(managedHostnames(current, mapToBucketHostnames(d.Get("hostnames").(*schema.Set).List())), nil)
+	if err := patchPropertyHostnames(ctx, d, client, request, nil, remove); err != nil {
+		if diags = activationWaitDiags(err); diags.HasError() {
+			return diags
+		}
+	}
+
+	d.SetId("")
+	return diags
+}
+
+// resourcePropertyHostnamesImport imports all the hostnames of the property on the network, the ID is the property ID
+// and the network separated by a colon, the network is staging if not set
+func resourcePropertyHostnamesImport(ctx context.Context, d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
+	meta := akamai.Meta(m)
+	ctx = log.NewContext(ctx, meta.Log("PAPI", "resourcePropertyHostnamesImport"))
+
+	parts := strings.Split(d.Id(), ":")
+	if len(parts) > 2 || parts[0] == "" {
+		return nil, fmt.Errorf("property ID and optionally the network separated by a colon have to be supplied in import: %s", d.Id())
+	}
+	network := string(papi.ActivationNetworkStaging)
+	if len(parts) == 2 {
+		alias, err := NetworkAlias(parts[1])
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", parts[1], err)
+		}
+		network = alias
+	}
+	if err := tools.SetAttrs(d, map[string]interface{}{
+		"property_id": tools.AddPrefix(parts[0], "prp_"),
+		"network":     network,
+	}); err != nil {
+		return nil, fmt.Errorf("%w: %s", tools.ErrValueSet, err.Error())
+	}
+
+	request, err := getPropertyHostnamesRequest(ctx, d, m)
+	if err != nil {
+		return nil, err
+	}
+	current, err := inst.HostnameBucketsClient(meta).ListPropertyHostnames(ctx, request)
+	if err != nil {
+		return nil, err
+	}
+	if err := tools.SetAttrs(d, map[string]interface{}{
+		"contract_id": request.ContractID,
+		"group_id":    request.GroupID,
+		"hostnames":   flattenBucketHostnames(current),
+	}); err != nil {
+		return nil, fmt.Errorf("%w: %s", tools.ErrValueSet, err.Error())
+	}
+
+	d.SetId(fmt.Sprintf("%s:%s", request.PropertyID, request.Network))
+	return []*schema.ResourceData{d}, nil
+}
+
+// getPropertyHostnamesRequest returns the request identifying the hostnames of the resource, the contract and group
+// are looked up from the property if not set
+func getPropertyHostnamesRequest(ctx context.Context, d *schema.ResourceData, m interface{}) (propertyHostnamesRequest, error) {
+	propertyID, err := tools.GetStringValue("property_id", d)
+	if err != nil {
+		return propertyHostnamesRequest{}, err
+	}
+	network, err := tools.GetStringValue("network", d)
+	if err != nil {
+		return propertyHostnamesRequest{}, err
+	}
+	alias, err := NetworkAlias(network)
+	if err != nil {
+		return propertyHostnamesRequest{}, err
+	}
+	request := propertyHostnamesRequest{
+		PropertyID: tools.AddPrefix(propertyID, "prp_"),
+		Network:    papi.ActivationNetwork(alias),
+	}
+
+	contractID, err := tools.GetStringValue("contract_id", d)
+	if err != nil && !errors.Is(err, tools.ErrNotFound) {
+		return propertyHostnamesRequest{}, err
+	}
+	groupID, err := tools.GetStringValue("group_id", d)
+	if err != nil && !errors.Is(err, tools.ErrNotFound) {
+		return propertyHostnamesRequest{}, err
+	}
+	if contractID == "" || groupID == "" {
+		property, err := fetchLatestProperty(ctx, inst.Client(akamai.Meta(m)), request.PropertyID, "", "")
+		if err != nil {
+			return propertyHostnamesRequest{}, err
+		}
+		contractID, groupID = property.ContractID, property.GroupID
+	}
+	request.ContractID = tools.AddPrefix(contractID, "ctr_")
+	request.GroupID = tools.AddPrefix(groupID, "grp_")
+	return request, nil
+}
+
+// patchPropertyHostnames removes and then adds the hostnames in patches of at most maxHostnamesPerPatch hostnames,
+// and waits for the hostname activation of each patch before sending the next one. The property must have hostname
+// buckets enabled.
+func patchPropertyHostnames(ctx context.Context, d *schema.ResourceData, client hostnameBuckets, request propertyHostnamesRequest, add []papi.Hostname, remove []string) error {
+	logger := log.FromContext(ctx)
+
+	if len(add) == 0 && len(remove) == 0 {
+		return nil
+	}
+	enabled, err := client.HasHostnameBuckets(ctx, request)
+	if err != nil {
+		return err
+	}
+	if !enabled {
+		return fmt.Errorf("%w: %s", ErrHostnameBucketsNotEnabled, request.PropertyID)
+	}
+
+	var note string
+	if v, err := tools.GetStringValue("note", d); err == nil {
+		note = v
+	}
+	var notifyEmails []string
+	if v, err := tools.GetSetValue("notify_emails", d); err == nil {
+		for _, email := range v.List() {
+			notifyEmails = append(notifyEmails, email.(string))
+		}
+	}
+
+	var patches []patchPropertyHostnamesRequest
+	for start := 0; start < len(remove); start += maxHostnamesPerPatch {
+		end := start + maxHostnamesPerPatch
+		if end > len(remove) {
+			end = len(remove)
+		}
+		patches = append(patches, patchPropertyHostnamesRequest{Remove: remove[start:end]})
+	}
+	for start := 0; start < len(add); start += maxHostnamesPerPatch {
+		end := start + maxHostnamesPerPatch
+		if end > len(add) {
+			end = len(add)
+		}
+		patches = append(patches, patchPropertyHostnamesRequest{Add: add[start:end]})
+	}
+
+	for _, patch := range patches {
+		patch.propertyHostnamesRequest = request
+		patch.Note = note
+		patch.NotifyEmails = notifyEmails
+
+		logger.Debugf("patching hostnames of property %s on %s: adding %d, removing %d", request.PropertyID, request.Network, len(patch.Add), len(patch.Remove))
+		activationID, err := client.PatchPropertyHostnames(ctx, patch)
+		if err != nil {
+			return err
+		}
+		if err := d.Set("activation_id", activationID); err != nil {
+			return fmt.Errorf("%w: %s", tools.ErrValueSet, err.Error())
+		}
+		if err := waitForHostnameActivation(ctx, d, client, hostnameActivationRequest{propertyHostnamesRequest: request, HostnameActivationID: activationID}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// waitForHostnameActivation polls the hostname activation until it is active, see waitForActivation
+func waitForHostnameActivation(ctx context.Context, d *schema.ResourceData, client hostnameBuckets, request hostnameActivationRequest) error {
+	activation, err := client.GetHostnameActivation(ctx, request)
+	if err != nil {
+		return err
+	}
+
+	name := fmt.Sprintf("property %s hostname activation %s on %s", request.PropertyID, request.HostnameActivationID, request.Network)
+	poller, err := akamai.NewActivationPoller(d, ActivationPollInterval, log.FromContext(ctx), name)
+	if err != nil {
+		return err
+	}
+	return waitForActivation(ctx, poller, "hostname activation", activation.Status, func() (papi.ActivationStatus, error) {
+		activation, err := client.GetHostnameActivation(ctx, request)
+		if err != nil {
+			return "", err
+		}
+		return activation.Status, nil
+	})
+}
+
+// managedHostnames returns the hostnames with the names of the managed hostnames
+func managedHostnames(hostnames, managed []papi.Hostname) []papi.Hostname {
+	names := make(map[string]struct{}, len(managed))
+	for _, h := range managed {
+		names[h.CnameFrom] = struct{}{}
+	}
+	var res []papi.Hostname
+	for _, h := range hostnames {
+		if _, ok := names[h.CnameFrom]; ok {
+			res = append(res, h)
+		}
+	}
+	return res
+}
+
+// hostnamesToPatch returns the hostnames to add to change the old hostnames into the new ones, new hostnames and
+// hostnames with changed settings, and the names of the hostnames to remove
+func hostnamesToPatch(old, new []papi.Hostname) ([]papi.Hostname, []string) {
+	oldByName := make(map[string]papi.Hostname, len(old))
+	for _, h := range old {
+		oldByName[h.CnameFrom] = h
+	}
+	newNames := make(map[string]struct{}, len(new))
+
+	var add []papi.Hostname
+	for _, h := range new {
+		newNames[h.CnameFrom] = struct{}{}
+		o, ok := oldByName[h.CnameFrom]
+		if !ok || o.EdgeHostnameID != h.EdgeHostnameID || o.CertProvisioningType != h.CertProvisioningType || o.CnameType != h.CnameType {
+			add = append(add, h)
+		}
+	}
+	var remove []string
+	for _, h := range old {
+		if _, ok := newNames[h.CnameFrom]; !ok {
+			remove = append(remove, h.CnameFrom)
+		}
+	}
+	sort.Slice(add, func(i, j int) bool {
+		return add[i].CnameFrom < add[j].CnameFrom
+	})
+	sort.Strings(remove)
+	return add, remove
+}
+
+// mapToBucketHostnames converts the hostnames of the resource to papi.Hostname
+func mapToBucketHostnames(givenList []interface{}) []papi.Hostname {
+	var hostnames []papi.Hostname
+	for _, v := range givenList {
+		m, ok := v.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		hostnames = append(hostnames, papi.Hostname{
+			CnameFrom:            m["cname_from"].(string),
+			EdgeHostnameID:       tools.AddPrefix(m["edge_hostname_id"].(string), "ehn_"),
+			CertProvisioningType: m["cert_provisioning_type"].(string),
+			CnameType:            papi.HostnameCnameType(m["cname_type"].(string)),
+		})
+	}
+	return hostnames
+}
+
+func flattenBucketHostnames(hostnames []papi.Hostname) []interface{} {
+	res := make([]interface{}, 0, len(hostnames))
+	for _, h := range hostnames {
+		res = append(res, map[string]interface{}{
+			"cname_from":             h.CnameFrom,
+			"edge_hostname_id":       h.EdgeHostnameID,
+			"cert_provisioning_type": h.CertProvisioningType,
+			"cname_type":             string(h.CnameType),
+		})
+	}
+	return res
+}
+
+// flattenBucketCertStatus returns the certificate status of the hostnames ordered by hostname
+func flattenBucketCertStatus(hostnames []papi.Hostname) []interface{} {
+	sorted := append([]papi.Hostname(nil), hostnames...)
+	sort.Slice(sorted, func(i, j int) bool {
+		return sorted[i].CnameFrom < sorted[j].CnameFrom
+	})
+	res := make([]interface{}, 0, len(sorted))
+	for _, h := range sorted {
+		status := map[string]interface{}{
+			"cname_from": h.CnameFrom,
+			"cname_to":   h.CnameTo,
+			"hostname":   h.CertStatus.ValidationCname.Hostname,
+			"target":     h.CertStatus.ValidationCname.Target,
+		}
+		if len(h.CertStatus.Staging) > 0 {
+			status["staging_status"] = h.CertStatus.Staging[0].Status
+		}
+		if len(h.CertStatus.Production) > 0 {
+			status["production_status"] = h.CertStatus.Production[0].Status
+		}
+		res = append(res, status)
+	}
+	return res
+}
@@ -0,0 +1,181 @@
+package property
+
+import (
+	"context"
+	"errors"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v2/pkg/papi"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/stretchr/testify/mock"
+	"github.com/tj/assert"
+)
+
+func TestResourcePropertyHostnames(t *testing.T) {
+	hostname := func(name, edgeHostnameID, certType string) papi.Hostname {
+		return papi.Hostname{CnameFrom: name, CnameType: papi.HostnameCnameTypeEdgeHostname, EdgeHostnameID: edgeHostnameID, CertProvisioningType: certType}
+	}
+	request := propertyHostnamesRequest{PropertyID: "prp_1", ContractID: "ctr_1", GroupID: "grp_1", Network: papi.ActivationNetworkProduction}
+
+	// the hostnames on the network, changed by the patches
+	unmanaged := hostname("other.example.com", "ehn_3", "CPS_MANAGED")
+	unmanaged.CertStatus = papi.CertStatusItem{Production: []papi.StatusItem{{Status: "DEPLOYED"}}}
+	server := []papi.Hostname{unmanaged}
+
+	client := &mockhostnamebuckets{}
+	client.On("HasHostnameBuckets", AnyCTX, request).Return(true, nil)
+	listCall := client.On("ListPropertyHostnames", AnyCTX, request)
+	listCall.Run(func(mock.Arguments) {
+		listCall.ReturnArguments = mock.Arguments{append([]papi.Hostname(nil), server...), nil}
+	})
+	patch := func(add []papi.Hostname, remove []string, activationID string) {
+		client.On("PatchPropertyHostnames", AnyCTX, patchPropertyHostnamesRequest{
+			propertyHostnamesRequest: request,
+			Add:                      add,
+			Remove:                   remove,
+			Note:                     "vanity hostnames",
+		}).Run(func(mock.Arguments) {
+			var patched []papi.Hostname
+			for _, h := range server {
+				removed := false
+				for _, name := range remove {
+					removed = removed || name == h.CnameFrom
+				}
+				for _, a := range add {
+					removed = removed || a.CnameFrom == h.CnameFrom
+				}
+				if !removed {
+					patched = append(patched, h)
+				}
+			}
+			server = append(patched, add...)
+		}).Return(activationID, nil).Once()
+		client.On("GetHostnameActivation", AnyCTX, hostnameActivationRequest{propertyHostnamesRequest: request, HostnameActivationID: activationID}).
+			Return(&hostnameActivation{HostnameActivationID: activationID, Status: papi.ActivationStatusActive}, nil)
+	}
+	patch([]papi.Hostname{hostname("a.example.com", "ehn_1", "CPS_MANAGED"), hostname("b.example.com", "ehn_1", "CPS_MANAGED")}, nil, "atv_1")
+	patch(nil, []string{"a.example.com"}, "atv_2")
+	patch([]papi.Hostname{hostname("c.example.com", "ehn_2", "DEFAULT")}, nil, "atv_3")
+	patch(nil, []string{"b.example.com", "c.example.com"}, "atv_4")
+
+	useHostnameBucketsClient(client, func() {
+		resource.UnitTest(t, resource.TestCase{
+			Providers: testAccProviders,
+			Steps: []resource.TestStep{{
+				Config: loadFixtureString("testdata/TestResPropertyHostnames/create.tf"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("akamai_property_hostnames.test", "id", "prp_1:PRODUCTION"),
+					resource.TestCheckResourceAttr("akamai_property_hostnames.test", "network", "PRODUCTION"),
+					resource.TestCheckResourceAttr("akamai_property_hostnames.test", "hostnames.#", "2"),
+					resource.TestCheckResourceAttr("akamai_property_hostnames.test", "activation_id", "atv_1"),
+					resource.TestCheckResourceAttr("akamai_property_hostnames.test", "cert_status.#", "2"),
+					resource.TestCheckResourceAttr("akamai_property_hostnames.test", "cert_status.0.cname_from", "a.example.com"),
+				),
+			}, {
+				Config: loadFixtureString("testdata/TestResPropertyHostnames/update.tf"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("akamai_property_hostnames.test", "hostnames.#", "2"),
+					resource.TestCheckResourceAttr("akamai_property_hostnames.test", "activation_id", "atv_3"),
+					resource.TestCheckResourceAttr("akamai_property_hostnames.test", "cert_status.1.cname_from", "c.example.com"),
+				),
+			}},
+		})
+	})
+	client.AssertExpectations(t)
+	assert.Equal(t, []papi.Hostname{unmanaged}, server)
+}
+
+func TestResourcePropertyHostnamesNotHostnameBucket(t *testing.T) {
+	request := propertyHostnamesRequest{PropertyID: "prp_1", ContractID: "ctr_1", GroupID: "grp_1", Network: papi.ActivationNetworkProduction}
+
+	client := &mockhostnamebuckets{}
+	client.On("ListPropertyHostnames", AnyCTX, request).Return([]papi.Hostname{}, nil)
+	client.On("HasHostnameBuckets", AnyCTX, request).Return(false, nil)
+
+	useHostnameBucketsClient(client, func() {
+		resource.UnitTest(t, resource.TestCase{
+			Providers: testAccProviders,
+			Steps: []resource.TestStep{{
+				Config:      loadFixtureString("testdata/TestResPropertyHostnames/create.tf"),
+				ExpectError: regexp.MustCompile("hostname buckets are not enabled for the property: prp_1"),
+			}},
+		})
+	})
+	client.AssertExpectations(t)
+}
+
+func TestPatchPropertyHostnamesErrors(t *testing.T) {
+	request := propertyHostnamesRequest{PropertyID: "prp_1", ContractID: "ctr_1", GroupID: "grp_1", Network: papi.ActivationNetworkProduction}
+	add := []papi.Hostname{{CnameFrom: "a.example.com", CnameType: papi.HostnameCnameTypeEdgeHostname, EdgeHostnameID: "ehn_1", CertProvisioningType: "CPS_MANAGED"}}
+
+	tests := map[string]struct {
+		init         func(*mockhostnamebuckets)
+		expectedDiag diag.Diagnostics
+		withError    error
+	}{
+		"hostname activation times out": {
+			init: func(client *mockhostnamebuckets) {
+				client.On("HasHostnameBuckets", AnyCTX, request).Return(true, nil)
+				client.On("PatchPropertyHostnames", AnyCTX, patchPropertyHostnamesRequest{propertyHostnamesRequest: request, Add: add}).Return("atv_1", nil)
+				client.On("GetHostnameActivation", AnyCTX, hostnameActivationRequest{propertyHostnamesRequest: request, HostnameActivationID: "atv_1"}).
+					Return(&hostnameActivation{HostnameActivationID: "atv_1", Status: papi.ActivationStatusPending}, nil)
+			},
+			expectedDiag: diag.Diagnostics{DiagWarnActivationTimeout},
+		},
+		"hostname buckets not enabled": {
+			init: func(client *mockhostnamebuckets) {
+				client.On("HasHostnameBuckets", AnyCTX, request).Return(false, nil)
+			},
+			withError: ErrHostnameBucketsNotEnabled,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			client := &mockhostnamebuckets{}
+			test.init(client)
+			d := schema.TestResourceDataRaw(t, resourcePropertyHostnames().Schema, map[string]interface{}{})
+			ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+			defer cancel()
+
+			err := patchPropertyHostnames(ctx, d, client, request, add, nil)
+			if test.withError != nil {
+				assert.True(t, errors.Is(err, test.withError), "want: %s; got: %s", test.withError, err)
+			} else {
+				assert.Equal(t, test.expectedDiag, activationWaitDiags(err))
+			}
+			client.AssertExpectations(t)
+		})
+	}
+}
+
+func TestManagedHostnames(t *testing.T) {
+	hostname := func(name, edgeHostnameID string) papi.Hostname {
+		return papi.Hostname{CnameFrom: name, CnameType: papi.HostnameCnameTypeEdgeHostname, EdgeHostnameID: edgeHostnameID, CertProvisioningType: "CPS_MANAGED"}
+	}
+	current := []papi.Hostname{hostname("a.example.com", "ehn_2"), hostname("b.example.com", "ehn_1"), hostname("other.example.com", "ehn_3")}
+	managed := []papi.Hostname{hostname("a.example.com", "ehn_1"), hostname("c.example.com", "ehn_1")}
+
+	assert.Equal(t, []papi.Hostname{hostname("a.example.com", "ehn_2")}, managedHostnames(current, managed))
+	assert.Empty(t, managedHostnames(current, nil))
+}
+
+func TestHostnamesToPatch(t *testing.T) {
+	hostname := func(name, edgeHostnameID string) papi.Hostname {
+		return papi.Hostname{CnameFrom: name, CnameType: papi.HostnameCnameTypeEdgeHostname, EdgeHostnameID: edgeHostnameID, CertProvisioningType: "CPS_MANAGED"}
+	}
+	old := []papi.Hostname{hostname("b.example.com", "ehn_1"), hostname("a.example.com", "ehn_1"), hostname("c.example.com", "ehn_1")}
+	new := []papi.Hostname{hostname("d.example.com", "ehn_1"), hostname("c.example.com", "ehn_2"), hostname("a.example.com", "ehn_1")}
+
+	add, remove := hostnamesToPatch(old, new)
+	assert.Equal(t, []papi.Hostname{hostname("c.example.com", "ehn_2"), hostname("d.example.com", "ehn_1")}, add)
+	assert.Equal(t, []string{"b.example.com"}, remove)
+
+	add, remove = hostnamesToPatch(old, nil)
+	assert.Empty(t, add)
+	assert.Equal(t, []string{"a.example.com", "b.example.com", "c.example.com"}, remove)
+}
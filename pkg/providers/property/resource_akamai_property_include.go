@@ -0,0 +1,456 @@
+package property
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/customdiff"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v2/pkg/papi"
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v2/pkg/session"
+	"github.com/akamai/terraform-provider-akamai/v2/pkg/akamai"
+	"github.com/akamai/terraform-provider-akamai/v2/pkg/tools"
+)
+
+const (
+	// IncludeTypeMicroservices is the type of includes which are managed independently of their parent properties
+	IncludeTypeMicroservices = "MICROSERVICES"
+
+	// IncludeTypeCommonSettings is the type of includes which hold settings shared by their parent properties
+	IncludeTypeCommonSettings = "COMMON_SETTINGS"
+)
+
+func resourcePropertyInclude() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourcePropertyIncludeCreate,
+		ReadContext:   resourcePropertyIncludeRead,
+		UpdateContext: resourcePropertyIncludeUpdate,
+		DeleteContext: resourcePropertyIncludeDelete,
+		CustomizeDiff: customdiff.All(
+			rulesSchemaCustomDiff,
+			rulesCustomDiff,
+			includeVersionCustomDiff,
+		),
+		Importer: &schema.ResourceImporter{
+			StateContext: resourcePropertyIncludeImport,
+		},
+		Schema: akamaiPropertyIncludeSchema,
+	}
+}
+
+var akamaiPropertyIncludeSchema = map[string]*schema.Schema{
+	"contract_id": {
+		Type:        schema.TypeString,
+		Optional:    true,
+		Computed:    true,
+		ForceNew:    true,
+		StateFunc:   addPrefixToState("ctr_"),
+		Description: "Contract ID of the include, the provider default_contract_id is used if not set",
+	},
+	"group_id": {
+		Type:        schema.TypeString,
+		Optional:    true,
+		Computed:    true,
+		ForceNew:    true,
+		StateFunc:   addPrefixToState("grp_"),
+		Description: "Group ID of the include, the provider default_group_id is used if not set",
+	},
+	"name": {
+		Type:             schema.TypeString,
+		Required:         true,
+		ForceNew:         true,
+		ValidateDiagFunc: validatePropertyName,
+		Description:      "Name of the include",
+	},
+	"type": {
+		Type:             schema.TypeString,
+		Required:         true,
+		ForceNew:         true,
+		ValidateDiagFunc: tools.ValidateStringInSlice([]string{IncludeTypeMicroservices, IncludeTypeCommonSettings}),
+		Description:      "Type of the include, MICROSERVICES or COMMON_SETTINGS",
+	},
+	"product_id": {
+		Type:        schema.TypeString,
+		Required:    true,
+		ForceNew:    true,
+		StateFunc:   addPrefixToState("prd_"),
+		Description: "Product ID of the include",
+	},
+	"rule_format": {
+		Type:             schema.TypeString,
+		Required:         true,
+		ValidateDiagFunc: validateRuleFormat,
+		Description:      "Rule format version of the include rules",
+	},
+	"rules": {
+		Type:             schema.TypeString,
+		Optional:         true,
+		Computed:         true,
+		ValidateDiagFunc: tools.ValidateJSON,
+		DiffSuppressFunc: suppressIncludeRules,
+		StateFunc: func(v interface{}) string {
+			if json.Valid([]byte(v.(string))) {
+				return compactJSON([]byte(v.(string)))
+			}
+			return v.(string)
+		},
+		Description: "Include rules as JSON",
+	},
+	"latest_version": {
+		Type:        schema.TypeInt,
+		Computed:    true,
+		Description: "Include's current latest version number",
+	},
+	"staging_version": {
+		Type:        schema.TypeInt,
+		Computed:    true,
+		Description: "Include's version currently activated in staging (zero when not active in staging)",
+	},
+	"production_version": {
+		Type:        schema.TypeInt,
+		Computed:    true,
+		Description: "Include's version currently activated in production (zero when not active in production)",
+	},
+	"rule_errors": {
+		Type:     schema.TypeList,
+		Computed: true,
+		Elem:     papiError(),
+	},
+	"rule_warnings": {
+		Type:     schema.TypeList,
+		Computed: true,
+		Elem:     papiError(),
+	},
+}
+
+func suppressIncludeRules(_, old, new string, _ *schema.ResourceData) bool {
+	if old == "" || new == "" {
+		return old == new
+	}
+	return compareRulesJSON(old, new)
+}
+
+// includeVersionCustomDiff sets `latest_version` as computed if a new version of the include may be created
+func includeVersionCustomDiff(_ context.Context, d *schema.ResourceDiff, _ interface{}) error {
+	oldRules, newRules := d.GetChange("rules")
+	if d.HasChange("rule_format") || !compareRulesJSON(oldRules.(string), newRules.(string)) {
+		if err := d.SetNewComputed("latest_version"); err != nil {
+			return fmt.Errorf("%w: %s", tools.ErrValueSet, err.Error())
+		}
+	}
+	return nil
+}
+
+func resourcePropertyIncludeCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	meta := akamai.Meta(m)
+	logger := meta.Log("PAPI", "resourcePropertyIncludeCreate")
+	client := inst.IncludesClient(meta)
+
+	logger.Debug("resourcePropertyIncludeCreate call")
+	// create a context with logging for api calls
+	ctx = session.ContextWithOptions(
+		ctx,
+		session.WithContextLog(logger),
+	)
+
+	contractID, err := akamai.GetContractID(d, m, "contract_id")
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	groupID, err := akamai.GetGroupID(d, m, "group_id")
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	name, err := tools.GetStringValue("name", d)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	includeType, err := tools.GetStringValue("type", d)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	productID, err := tools.GetStringValue("product_id", d)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	productID = tools.AddPrefix(productID, "prd_")
+	ruleFormat, err := tools.GetStringValue("rule_format", d)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	includeID, err := client.CreateInclude(ctx, createIncludeRequest{
+		ContractID:  contractID,
+		GroupID:     groupID,
+		IncludeName: name,
+		IncludeType: includeType,
+		ProductID:   productID,
+		RuleFormat:  ruleFormat,
+	})
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	// Save minimum state BEFORE moving on
+	d.SetId(includeID)
+	attrs := map[string]interface{}{
+		"contract_id": contractID,
+		"group_id":    groupID,
+		"product_id":  productID,
+	}
+	if err := tools.SetAttrs(d, attrs); err != nil {
+		return diag.FromErr(fmt.Errorf("%w: %s", tools.ErrValueSet, err.Error()))
+	}
+
+	rulesJSON, err := tools.GetStringValue("rules", d)
+	if err != nil && !errors.Is(err, tools.ErrNotFound) {
+		return diag.FromErr(err)
+	}
+	if rulesJSON != "" {
+		request := includeVersionRequest{
+			includeRequest: includeRequest{IncludeID: includeID, ContractID: contractID, GroupID: groupID},
+			Version:        1,
+		}
+		if err := updateIncludeRules(ctx, client, request, rulesJSON, ruleFormat); err != nil {
+			d.Partial(true)
+			return diag.FromErr(err)
+		}
+	}
+
+	return resourcePropertyIncludeRead(ctx, d, m)
+}
+
+func resourcePropertyIncludeRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	meta := akamai.Meta(m)
+	logger := meta.Log("PAPI", "resourcePropertyIncludeRead")
+	client := inst.IncludesClient(meta)
+
+	logger.Debug("resourcePropertyIncludeRead call")
+	// create a context with logging for api calls
+	ctx = session.ContextWithOptions(
+		ctx,
+		session.WithContextLog(logger),
+	)
+
+	request, err := getIncludeRequest(d)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	inc, err := client.GetInclude(ctx, request)
+	if isNotFoundError(err) {
+		logger.Warnf("include %s was not found, removing it from the state", request.IncludeID)
+		d.SetId("")
+		return nil
+	}
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	versionRequest := includeVersionRequest{includeRequest: request, Version: inc.LatestVersion}
+	version, err := client.GetIncludeVersion(ctx, versionRequest)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	rules, err := client.GetIncludeRuleTree(ctx, versionRequest)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	if len(rules.Errors) > 0 {
+		msg, err := json.MarshalIndent(papiErrorsToList(rules.Errors), "", "\t")
+		if err != nil {
+			return diag.FromErr(fmt.Errorf("error marshaling API error: %s", err))
+		}
+		logger.Errorf("Include has rule errors %s", msg)
+	}
+
+	rulesJSON, err := json.Marshal(papi.RulesUpdate{Rules: rules.Rules, Comments: rules.Comments})
+	if err != nil {
+		return diag.Errorf("received rules that could not be rendered to JSON: %s", err)
+	}
+
+	var stagingVersion, productionVersion int
+	if inc.StagingVersion != nil {
+		stagingVersion = *inc.StagingVersion
+	}
+	if inc.ProductionVersion != nil {
+		productionVersion = *inc.ProductionVersion
+	}
+
+	attrs := map[string]interface{}{
+		"contract_id":        inc.ContractID,
+		"group_id":           inc.GroupID,
+		"name":               inc.IncludeName,
+		"type":               inc.IncludeType,
+		"product_id":         version.ProductID,
+		"rule_format":        rules.RuleFormat,
+		"rules":              string(rulesJSON),
+		"latest_version":     inc.LatestVersion,
+		"staging_version":    stagingVersion,
+		"production_version": productionVersion,
+		"rule_errors":        papiErrorsToList(rules.Errors),
+		"rule_warnings":      papiErrorsToList(rules.Warnings),
+	}
+	if err := tools.SetAttrs(d, attrs); err != nil {
+		return diag.FromErr(fmt.Errorf("%w: %s", tools.ErrValueSet, err.Error()))
+	}
+	return nil
+}
+
+func resourcePropertyIncludeUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	meta := akamai.Meta(m)
+	logger := meta.Log("PAPI", "resourcePropertyIncludeUpdate")
+	client := inst.IncludesClient(meta)
+
+	logger.Debug("resourcePropertyIncludeUpdate call")
+	// create a context with logging for api calls
+	ctx = session.ContextWithOptions(
+		ctx,
+		session.WithContextLog(logger),
+	)
+
+	if !d.HasChanges("rules", "rule_format") {
+		logger.Debug("No changes to rules or rule_format (no update required)")
+		return nil
+	}
+
+	request, err := getIncludeRequest(d)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	inc, err := client.GetInclude(ctx, request)
+	if err != nil {
+		d.Partial(true)
+		return diag.FromErr(err)
+	}
+	versionRequest := includeVersionRequest{includeRequest: request, Version: inc.LatestVersion}
+	version, err := client.GetIncludeVersion(ctx, versionRequest)
+	if err != nil {
+		d.Partial(true)
+		return diag.FromErr(err)
+	}
+
+	// an activated version can not be edited, the changes are applied on a new version
+	if version.StagingStatus != papi.VersionStatusInactive || version.ProductionStatus != papi.VersionStatusInactive {
+		newVersion, err := client.CreateIncludeVersion(ctx, versionRequest)
+		if err != nil {
+			d.Partial(true)
+			return diag.FromErr(err)
+		}
+		logger.Debugf("created version %d of include %s", newVersion, request.IncludeID)
+		versionRequest.Version = newVersion
+	}
+
+	ruleFormat, err := tools.GetStringValue("rule_format", d)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	rulesJSON, err := tools.GetStringValue("rules", d)
+	if err != nil && !errors.Is(err, tools.ErrNotFound) {
+		return diag.FromErr(err)
+	}
+	if rulesJSON == "" {
+		// only the rule format changes, the rules of the version are kept
+		rules, err := client.GetIncludeRuleTree(ctx, versionRequest)
+		if err != nil {
+			d.Partial(true)
+			return diag.FromErr(err)
+		}
+		encoded, err := json.Marshal(papi.RulesUpdate{Rules: rules.Rules, Comments: rules.Comments})
+		if err != nil {
+			return diag.FromErr(err)
+		}
+		rulesJSON = string(encoded)
+	}
+	if err := updateIncludeRules(ctx, client, versionRequest, rulesJSON, ruleFormat); err != nil {
+		d.Partial(true)
+		return diag.FromErr(err)
+	}
+
+	return resourcePropertyIncludeRead(ctx, d, m)
+}
+
+func resourcePropertyIncludeDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	meta := akamai.Meta(m)
+	logger := meta.Log("PAPI", "resourcePropertyIncludeDelete")
+	client := inst.IncludesClient(meta)
+
+	logger.Debug("resourcePropertyIncludeDelete call")
+	// create a context with logging for api calls
+	ctx = session.ContextWithOptions(
+		ctx,
+		session.WithContextLog(logger),
+	)
+
+	request, err := getIncludeRequest(d)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	if err := client.DeleteInclude(ctx, request); err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId("")
+	return nil
+}
+
+func resourcePropertyIncludeImport(_ context.Context, d *schema.ResourceData, _ interface{}) ([]*schema.ResourceData, error) {
+	// User-supplied import ID is a comma-separated list of IncludeID,ContractID,GroupID
+	// as the include endpoints require the contract and the group
+	parts := strings.Split(d.Id(), ",")
+	if len(parts) != 3 {
+		return nil, fmt.Errorf("invalid include identifier: %q, the import ID must be in the form include_id,contract_id,group_id", d.Id())
+	}
+
+	attrs := map[string]interface{}{
+		"contract_id": tools.AddPrefix(parts[1], "ctr_"),
+		"group_id":    tools.AddPrefix(parts[2], "grp_"),
+	}
+	if err := tools.SetAttrs(d, attrs); err != nil {
+		return nil, fmt.Errorf("%w: %s", tools.ErrValueSet, err.Error())
+	}
+
+	d.SetId(tools.AddPrefix(parts[0], "inc_"))
+	return []*schema.ResourceData{d}, nil
+}
+
+// getIncludeRequest returns the request identifying the include of the resource
+func getIncludeRequest(d *schema.ResourceData) (includeRequest, error) {
+	contractID, err := tools.GetStringValue("contract_id", d)
+	if err != nil {
+		return includeRequest{}, err
+	}
+	groupID, err := tools.GetStringValue("group_id", d)
+	if err != nil {
+		return includeRequest{}, err
+	}
+	return includeRequest{
+		IncludeID:  d.Id(),
+		ContractID: tools.AddPrefix(contractID, "ctr_"),
+		GroupID:    tools.AddPrefix(groupID, "grp_"),
+	}, nil
+}
+
+// updateIncludeRules replaces the rules of the include version, in the given rule format
+func updateIncludeRules(ctx context.Context, client includes, request includeVersionRequest, rulesJSON, ruleFormat string) error {
+	var rules papi.RulesUpdate
+	if err := json.Unmarshal([]byte(rulesJSON), &rules); err != nil {
+		return fmt.Errorf("rules are not valid JSON: %s", err)
+	}
+
+	h := http.Header{
+		"Content-Type": []string{fmt.Sprintf("application/vnd.akamai.papirules.%s+json", ruleFormat)},
+	}
+	ctx = session.ContextWithOptions(ctx, session.WithContextHeaders(h))
+
+	_, err := client.UpdateIncludeRuleTree(ctx, updateIncludeRuleTreeRequest{
+		includeVersionRequest: request,
+		Rules:                 rules,
+	})
+	return err
+}
@@ -0,0 +1,372 @@
+package property
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/spf13/cast"
+
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v2/pkg/papi"
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v2/pkg/session"
+	"github.com/akamai/terraform-provider-akamai/v2/pkg/akamai"
+	"github.com/akamai/terraform-provider-akamai/v2/pkg/tools"
+)
+
+func resourcePropertyIncludeActivation() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourcePropertyIncludeActivationCreate,
+		ReadContext:   resourcePropertyIncludeActivationRead,
+		UpdateContext: resourcePropertyIncludeActivationUpdate,
+		DeleteContext: resourcePropertyIncludeActivationDelete,
+		Schema:        akamaiPropertyIncludeActivationSchema,
+		Timeouts: &schema.ResourceTimeout{
+			Default: &PropertyResourceTimeout,
+		},
+	}
+}
+
+var akamaiPropertyIncludeActivationSchema = map[string]*schema.Schema{
+	"include_id": {
+		Type:             schema.TypeString,
+		Required:         true,
+		ForceNew:         true,
+		StateFunc:        addPrefixToState("inc_"),
+		ValidateDiagFunc: tools.IsNotBlank,
+	},
+	"contract_id": {
+		Type:        schema.TypeString,
+		Optional:    true,
+		Computed:    true,
+		ForceNew:    true,
+		StateFunc:   addPrefixToState("ctr_"),
+		Description: "Contract ID of the include, the provider default_contract_id is used if not set",
+	},
+	"group_id": {
+		Type:        schema.TypeString,
+		Optional:    true,
+		Computed:    true,
+		ForceNew:    true,
+		StateFunc:   addPrefixToState("grp_"),
+		Description: "Group ID of the include, the provider default_group_id is used if not set",
+	},
+	"version": {
+		Type:     schema.TypeInt,
+		Required: true,
+	},
+	"network": {
+		Type:             schema.TypeString,
+		Optional:         true,
+		ForceNew:         true,
+		Default:          papi.ActivationNetworkStaging,
+		ValidateDiagFunc: tools.ValidateNetwork,
+	},
+	"notify_emails": {
+		Type:     schema.TypeSet,
+		Required: true,
+		Elem:     &schema.Schema{Type: schema.TypeString},
+	},
+	"note": {
+		Type:        schema.TypeString,
+		Optional:    true,
+		Description: "assigns a log message to the activation request",
+	},
+	"auto_acknowledge_rule_warnings": {
+		Type:        schema.TypeBool,
+		Optional:    true,
+		Default:     true,
+		Description: "automatically acknowledge all rule warnings for activation to continue. default is true",
+	},
+	"activation_id": {
+		Type:     schema.TypeString,
+		Computed: true,
+	},
+	"status": {
+		Type:     schema.TypeString,
+		Computed: true,
+	},
+	akamai.PollIntervalField: akamai.PollIntervalSchema(),
+}
+
+func resourcePropertyIncludeActivationCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	meta := akamai.Meta(m)
+	logger := meta.Log("PAPI", "resourcePropertyIncludeActivationCreate")
+
+	logger.Debug("resourcePropertyIncludeActivationCreate call")
+	// create a context with logging for api calls
+	ctx = session.ContextWithOptions(
+		ctx,
+		session.WithContextLog(logger),
+	)
+
+	includeID, err := tools.GetStringValue("include_id", d)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	contractID, err := akamai.GetContractID(d, m, "contract_id")
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	groupID, err := akamai.GetGroupID(d, m, "group_id")
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	network, err := networkAlias(d)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	request := includeRequest{
+		IncludeID:  tools.AddPrefix(includeID, "inc_"),
+		ContractID: contractID,
+		GroupID:    groupID,
+	}
+	d.SetId(fmt.Sprintf("%s:%s", request.IncludeID, network))
+	attrs := map[string]interface{}{
+		"include_id":  request.IncludeID,
+		"contract_id": contractID,
+		"group_id":    groupID,
+		"network":     string(network),
+	}
+	if err := tools.SetAttrs(d, attrs); err != nil {
+		return diag.FromErr(fmt.Errorf("%w: %s", tools.ErrValueSet, err.Error()))
+	}
+
+	if diags := activateInclude(ctx, d, m, request, papi.ActivationTypeActivate); diags != nil {
+		return diags
+	}
+	return resourcePropertyIncludeActivationRead(ctx, d, m)
+}
+
+func resourcePropertyIncludeActivationRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	meta := akamai.Meta(m)
+	logger := meta.Log("PAPI", "resourcePropertyIncludeActivationRead")
+	client := inst.IncludesClient(meta)
+
+	logger.Debug("resourcePropertyIncludeActivationRead call")
+	// create a context with logging for api calls
+	ctx = session.ContextWithOptions(
+		ctx,
+		session.WithContextLog(logger),
+	)
+
+	request, err := getIncludeActivationRequest(d)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	network, err := networkAlias(d)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	activations, err := client.ListIncludeActivations(ctx, request)
+	if isNotFoundError(err) {
+		logger.Warnf("include %s was not found, removing its activation from the state", request.IncludeID)
+		d.SetId("")
+		return nil
+	}
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	activation, err := latestIncludeActivation(activations, network)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	if activation == nil || activation.ActivationType == papi.ActivationTypeDeactivate {
+		logger.Warnf("include %s is not active on %s, removing it from the state", request.IncludeID, network)
+		d.SetId("")
+		return nil
+	}
+
+	attrs := map[string]interface{}{
+		"activation_id": activation.ActivationID,
+		"version":       activation.IncludeVersion,
+		"status":        string(activation.Status),
+	}
+	if err := tools.SetAttrs(d, attrs); err != nil {
+		return diag.FromErr(fmt.Errorf("%w: %s", tools.ErrValueSet, err.Error()))
+	}
+	return nil
+}
+
+func resourcePropertyIncludeActivationUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	meta := akamai.Meta(m)
+	logger := meta.Log("PAPI", "resourcePropertyIncludeActivationUpdate")
+
+	logger.Debug("resourcePropertyIncludeActivationUpdate call")
+	// create a context with logging for api calls
+	ctx = session.ContextWithOptions(
+		ctx,
+		session.WithContextLog(logger),
+	)
+
+	// notify_emails, note, auto_acknowledge_rule_warnings and poll_interval are only used when an activation is submitted
+	if !d.HasChange("version") {
+		return resourcePropertyIncludeActivationRead(ctx, d, m)
+	}
+
+	request, err := getIncludeActivationRequest(d)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	if diags := activateInclude(ctx, d, m, request, papi.ActivationTypeActivate); diags != nil {
+		return diags
+	}
+	return resourcePropertyIncludeActivationRead(ctx, d, m)
+}
+
+func resourcePropertyIncludeActivationDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	meta := akamai.Meta(m)
+	logger := meta.Log("PAPI", "resourcePropertyIncludeActivationDelete")
+
+	logger.Debug("resourcePropertyIncludeActivationDelete call")
+	// create a context with logging for api calls
+	ctx = session.ContextWithOptions(
+		ctx,
+		session.WithContextLog(logger),
+	)
+
+	request, err := getIncludeActivationRequest(d)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	if diags := activateInclude(ctx, d, m, request, papi.ActivationTypeDeactivate); diags != nil {
+		return diags
+	}
+
+	d.SetId("")
+	return nil
+}
+
+// activateInclude submits the activation or deactivation of the include version and waits until it completes
+func activateInclude(ctx context.Context, d *schema.ResourceData, m interface{}, request includeRequest, activationType papi.ActivationType) diag.Diagnostics {
+	meta := akamai.Meta(m)
+	logger := meta.Log("PAPI", "activateInclude")
+	client := inst.IncludesClient(meta)
+
+	network, err := networkAlias(d)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	version, err := tools.GetIntValue("version", d)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	notifySet, err := tools.GetSetValue("notify_emails", d)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	var notify []string
+	for _, email := range notifySet.List() {
+		notify = append(notify, cast.ToString(email))
+	}
+	note, err := tools.GetStringValue("note", d)
+	if err != nil && !errors.Is(err, tools.ErrNotFound) {
+		return diag.FromErr(err)
+	}
+	// Schema guarantees these types
+	acknowledgeRuleWarnings := d.Get("auto_acknowledge_rule_warnings").(bool)
+
+	activationID, err := client.CreateIncludeActivation(ctx, createIncludeActivationRequest{
+		includeRequest: request,
+		Activation: includeActivation{
+			ActivationType:         activationType,
+			IncludeVersion:         version,
+			Network:                network,
+			Note:                   note,
+			NotifyEmails:           notify,
+			AcknowledgeAllWarnings: acknowledgeRuleWarnings,
+		},
+	})
+	if err != nil {
+		return diag.FromErr(fmt.Errorf("create include %s failed: %w", activationTypeName(activationType), err))
+	}
+	if err := d.Set("activation_id", activationID); err != nil {
+		return diag.FromErr(fmt.Errorf("%w: %s", tools.ErrValueSet, err.Error()))
+	}
+
+	activationRequest := includeActivationRequest{includeRequest: request, ActivationID: activationID}
+	activation, err := client.GetIncludeActivation(ctx, activationRequest)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	name := fmt.Sprintf("include %s %s on %s", request.IncludeID, activationTypeName(activationType), network)
+	poller, err := akamai.NewActivationPoller(d, ActivationPollInterval, logger, name)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	err = waitForActivation(ctx, poller, "include "+activationTypeName(activationType), activation.Status, func() (papi.ActivationStatus, error) {
+		polled, err := client.GetIncludeActivation(ctx, activationRequest)
+		if err != nil {
+			return "", err
+		}
+		activation = polled
+		return activation.Status, nil
+	})
+	if err != nil {
+		return activationWaitDiags(err)
+	}
+
+	if err := d.Set("status", string(activation.Status)); err != nil {
+		return diag.FromErr(fmt.Errorf("%w: %s", tools.ErrValueSet, err.Error()))
+	}
+	return nil
+}
+
+// getIncludeActivationRequest returns the request identifying the include of the activation resource
+func getIncludeActivationRequest(d *schema.ResourceData) (includeRequest, error) {
+	includeID, err := tools.GetStringValue("include_id", d)
+	if err != nil {
+		return includeRequest{}, err
+	}
+	request, err := getIncludeRequest(d)
+	if err != nil {
+		return includeRequest{}, err
+	}
+	request.IncludeID = tools.AddPrefix(includeID, "inc_")
+	return request, nil
+}
+
+// latestIncludeActivation returns the most recently submitted activation or deactivation on the network,
+// or nil if the include was never activated on it
+func latestIncludeActivation(activations []includeActivation, network papi.ActivationNetwork) (*includeActivation, error) {
+	var items []includeActivation
+	for _, activation := range activations {
+		if activation.Network == network {
+			items = append(items, activation)
+		}
+	}
+	if len(items) == 0 {
+		return nil, nil
+	}
+
+	var sortErr error
+	sort.SliceStable(items, func(i, j int) bool {
+		left, err := tools.ParseDate(tools.DateTimeFormat, items[i].SubmitDate)
+		if err != nil {
+			sortErr = err
+			return false
+		}
+		right, err := tools.ParseDate(tools.DateTimeFormat, items[j].SubmitDate)
+		if err != nil {
+			sortErr = err
+			return false
+		}
+		return left.After(right)
+	})
+	if sortErr != nil {
+		return nil, sortErr
+	}
+	return &items[0], nil
+}
+
+func activationTypeName(activationType papi.ActivationType) string {
+	if activationType == papi.ActivationTypeDeactivate {
+		return "deactivation"
+	}
+	return "activation"
+}
@@ -0,0 +1,109 @@
+package property
+
+import (
+	"testing"
+
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v2/pkg/papi"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/tj/assert"
+)
+
+func TestResourcePropertyIncludeActivation(t *testing.T) {
+	request := includeRequest{IncludeID: "inc_1", ContractID: "ctr_1", GroupID: "grp_1"}
+	activation := includeActivation{
+		ActivationType:         papi.ActivationTypeActivate,
+		IncludeVersion:         1,
+		Network:                papi.ActivationNetworkStaging,
+		Note:                   "shared settings release",
+		NotifyEmails:           []string{"user@example.com"},
+		AcknowledgeAllWarnings: true,
+	}
+	deactivation := activation
+	deactivation.ActivationType = papi.ActivationTypeDeactivate
+
+	client := &mockincludes{}
+	client.On("CreateIncludeActivation", mock.Anything, createIncludeActivationRequest{
+		includeRequest: request,
+		Activation:     activation,
+	}).Return("atv_1", nil).Once()
+	client.On("GetIncludeActivation", mock.Anything, includeActivationRequest{includeRequest: request, ActivationID: "atv_1"}).
+		Return(&includeActivation{ActivationID: "atv_1", Status: papi.ActivationStatusActive}, nil).Once()
+	client.On("ListIncludeActivations", mock.Anything, request).Return([]includeActivation{{
+		ActivationID:   "atv_1",
+		ActivationType: papi.ActivationTypeActivate,
+		IncludeVersion: 1,
+		Network:        papi.ActivationNetworkStaging,
+		Status:         papi.ActivationStatusActive,
+		SubmitDate:     "2021-06-01T10:00:00Z",
+	}}, nil)
+	client.On("CreateIncludeActivation", mock.Anything, createIncludeActivationRequest{
+		includeRequest: request,
+		Activation:     deactivation,
+	}).Return("atv_2", nil).Once()
+	client.On("GetIncludeActivation", mock.Anything, includeActivationRequest{includeRequest: request, ActivationID: "atv_2"}).
+		Return(&includeActivation{ActivationID: "atv_2", Status: papi.ActivationStatusActive}, nil).Once()
+
+	useIncludesClient(client, func() {
+		resource.UnitTest(t, resource.TestCase{
+			Providers: testAccProviders,
+			Steps: []resource.TestStep{{
+				Config: loadFixtureString("testdata/TestResPropertyIncludeActivation/property_include_activation.tf"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("akamai_property_include_activation.test", "id", "inc_1:STAGING"),
+					resource.TestCheckResourceAttr("akamai_property_include_activation.test", "activation_id", "atv_1"),
+					resource.TestCheckResourceAttr("akamai_property_include_activation.test", "version", "1"),
+					resource.TestCheckResourceAttr("akamai_property_include_activation.test", "status", "ACTIVE"),
+				),
+			}},
+		})
+	})
+
+	client.AssertExpectations(t)
+}
+
+func TestLatestIncludeActivation(t *testing.T) {
+	activations := []includeActivation{
+		{ActivationID: "atv_1", Network: papi.ActivationNetworkStaging, SubmitDate: "2021-06-01T10:00:00Z"},
+		{ActivationID: "atv_3", Network: papi.ActivationNetworkStaging, SubmitDate: "2021-06-03T10:00:00Z"},
+		{ActivationID: "atv_2", Network: papi.ActivationNetworkStaging, SubmitDate: "2021-06-02T10:00:00Z"},
+		{ActivationID: "atv_4", Network: papi.ActivationNetworkProduction, SubmitDate: "2021-06-04T10:00:00Z"},
+	}
+
+	tests := map[string]struct {
+		network    papi.ActivationNetwork
+		expectedID string
+	}{
+		"staging": {
+			network:    papi.ActivationNetworkStaging,
+			expectedID: "atv_3",
+		},
+		"production": {
+			network:    papi.ActivationNetworkProduction,
+			expectedID: "atv_4",
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			activation, err := latestIncludeActivation(activations, test.network)
+			require.NoError(t, err)
+			assert.Equal(t, test.expectedID, activation.ActivationID)
+		})
+	}
+
+	t.Run("never activated", func(t *testing.T) {
+		activation, err := latestIncludeActivation(activations[:3], papi.ActivationNetworkProduction)
+		require.NoError(t, err)
+		assert.Nil(t, activation)
+	})
+
+	t.Run("invalid submit date", func(t *testing.T) {
+		_, err := latestIncludeActivation([]includeActivation{
+			{ActivationID: "atv_1", Network: papi.ActivationNetworkStaging, SubmitDate: "yesterday"},
+			{ActivationID: "atv_2", Network: papi.ActivationNetworkStaging, SubmitDate: "2021-06-02T10:00:00Z"},
+		}, papi.ActivationNetworkStaging)
+		assert.Error(t, err)
+	})
+}
@@ -0,0 +1,146 @@
+package property
+
+import (
+	"context"
+	"net/http"
+	"regexp"
+	"testing"
+
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v2/pkg/papi"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/tj/assert"
+)
+
+func TestResourcePropertyInclude(t *testing.T) {
+	request := includeRequest{IncludeID: "inc_1", ContractID: "ctr_1", GroupID: "grp_1"}
+	versionRequest := includeVersionRequest{includeRequest: request, Version: 1}
+
+	client := &mockincludes{}
+	client.On("CreateInclude", mock.Anything, createIncludeRequest{
+		ContractID:  "ctr_1",
+		GroupID:     "grp_1",
+		IncludeName: "shared",
+		IncludeType: IncludeTypeMicroservices,
+		ProductID:   "prd_Web_App_Accel",
+		RuleFormat:  "v2021-05-05",
+	}).Return("inc_1", nil).Once()
+	client.On("UpdateIncludeRuleTree", mock.Anything, mock.MatchedBy(func(r updateIncludeRuleTreeRequest) bool {
+		return r.includeVersionRequest == versionRequest && r.Rules.Rules.Name == "default" && len(r.Rules.Rules.Behaviors) == 1
+	})).Return(&includeRuleTree{IncludeID: "inc_1", IncludeVersion: 1}, nil).Once()
+	client.On("GetInclude", mock.Anything, request).Return(&include{
+		IncludeID:     "inc_1",
+		IncludeName:   "shared",
+		IncludeType:   IncludeTypeMicroservices,
+		ContractID:    "ctr_1",
+		GroupID:       "grp_1",
+		LatestVersion: 1,
+	}, nil)
+	client.On("GetIncludeVersion", mock.Anything, versionRequest).Return(&includeVersion{
+		IncludeVersion:   1,
+		ProductID:        "prd_Web_App_Accel",
+		StagingStatus:    papi.VersionStatusInactive,
+		ProductionStatus: papi.VersionStatusInactive,
+	}, nil)
+	client.On("GetIncludeRuleTree", mock.Anything, versionRequest).Return(&includeRuleTree{
+		IncludeID:      "inc_1",
+		IncludeName:    "shared",
+		IncludeType:    IncludeTypeMicroservices,
+		IncludeVersion: 1,
+		RuleFormat:     "v2021-05-05",
+		Rules: papi.Rules{
+			Name: "default",
+			Behaviors: []papi.RuleBehavior{{
+				Name:    "caching",
+				Options: papi.RuleOptionsMap{"behavior": "MAX_AGE", "ttl": "1d"},
+			}},
+		},
+		Warnings: []*papi.Error{{Type: "warning", Title: "Missing origin", ErrorLocation: "#/rules"}},
+	}, nil)
+	client.On("DeleteInclude", mock.Anything, request).Return(nil).Once()
+
+	useIncludesClient(client, func() {
+		resource.UnitTest(t, resource.TestCase{
+			Providers: testAccProviders,
+			Steps: []resource.TestStep{{
+				Config: loadFixtureString("testdata/TestResPropertyInclude/property_include.tf"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("akamai_property_include.test", "id", "inc_1"),
+					resource.TestCheckResourceAttr("akamai_property_include.test", "latest_version", "1"),
+					resource.TestCheckResourceAttr("akamai_property_include.test", "staging_version", "0"),
+					resource.TestCheckResourceAttr("akamai_property_include.test", "rule_format", "v2021-05-05"),
+					resource.TestCheckResourceAttr("akamai_property_include.test", "rule_errors.#", "0"),
+					resource.TestCheckResourceAttr("akamai_property_include.test", "rule_warnings.#", "1"),
+					resource.TestCheckResourceAttr("akamai_property_include.test", "rule_warnings.0.title", "Missing origin"),
+				),
+			}, {
+				ImportState:       true,
+				ImportStateId:     "inc_1,ctr_1,grp_1",
+				ResourceName:      "akamai_property_include.test",
+				ImportStateVerify: true,
+			}},
+		})
+	})
+
+	client.AssertExpectations(t)
+}
+
+func TestResourcePropertyIncludeNotFound(t *testing.T) {
+	client := &mockincludes{}
+	client.On("GetInclude", mock.Anything, includeRequest{IncludeID: "inc_1", ContractID: "ctr_1", GroupID: "grp_1"}).
+		Return(nil, &papi.Error{StatusCode: http.StatusNotFound})
+
+	useIncludesClient(client, func() {
+		resource.UnitTest(t, resource.TestCase{
+			Providers: testAccProviders,
+			Steps: []resource.TestStep{{
+				Config:        loadFixtureString("testdata/TestResPropertyInclude/property_include.tf"),
+				ImportState:   true,
+				ImportStateId: "inc_1,ctr_1,grp_1",
+				ResourceName:  "akamai_property_include.test",
+				ExpectError:   regexp.MustCompile("Cannot import non-existent remote object"),
+			}},
+		})
+	})
+
+	client.AssertExpectations(t)
+}
+
+func TestResourcePropertyIncludeImport(t *testing.T) {
+	tests := map[string]struct {
+		importID  string
+		expected  map[string]string
+		withError bool
+	}{
+		"with prefixes": {
+			importID: "inc_1,ctr_1,grp_1",
+			expected: map[string]string{"id": "inc_1", "contract_id": "ctr_1", "group_id": "grp_1"},
+		},
+		"without prefixes": {
+			importID: "1,1,1",
+			expected: map[string]string{"id": "inc_1", "contract_id": "ctr_1", "group_id": "grp_1"},
+		},
+		"missing group": {
+			importID:  "inc_1,ctr_1",
+			withError: true,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			d := schema.TestResourceDataRaw(t, akamaiPropertyIncludeSchema, map[string]interface{}{})
+			d.SetId(test.importID)
+			_, err := resourcePropertyIncludeImport(context.Background(), d, nil)
+			if test.withError {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, test.expected["id"], d.Id())
+			assert.Equal(t, test.expected["contract_id"], d.Get("contract_id"))
+			assert.Equal(t, test.expected["group_id"], d.Get("group_id"))
+		})
+	}
+}
@@ -0,0 +1,447 @@
+package property
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+
+	"github.com/apex/log"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v2/pkg/papi"
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v2/pkg/session"
+	"github.com/akamai/terraform-provider-akamai/v2/pkg/akamai"
+	"github.com/akamai/terraform-provider-akamai/v2/pkg/tools"
+)
+
+func resourcePropertyRule() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourcePropertyRuleCreate,
+		ReadContext:   resourcePropertyRuleRead,
+		UpdateContext: resourcePropertyRuleUpdate,
+		DeleteContext: resourcePropertyRuleDelete,
+		CustomizeDiff: propertyRuleNameCustomDiff,
+		Importer: &schema.ResourceImporter{
+			StateContext: resourcePropertyRuleImport,
+		},
+		Schema: map[string]*schema.Schema{
+			"property_id": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				StateFunc:   addPrefixToState("prp_"),
+				Description: "ID of the property the rule belongs to",
+			},
+			"contract_id": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Computed:    true,
+				ForceNew:    true,
+				StateFunc:   addPrefixToState("ctr_"),
+				Description: "Contract ID of the property, looked up from the property if not set",
+			},
+			"group_id": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Computed:    true,
+				ForceNew:    true,
+				StateFunc:   addPrefixToState("grp_"),
+				Description: "Group ID of the property, looked up from the property if not set",
+			},
+			"parent_path": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				ForceNew:    true,
+				Description: "Path of the parent rule, as the names of the rules below the default rule separated by \"/\", the rule is a child of the default rule if not set",
+			},
+			"rules": {
+				Type:             schema.TypeString,
+				Required:         true,
+				ValidateDiagFunc: tools.ValidateJSON,
+				DiffSuppressFunc: suppressPropertyRule,
+				StateFunc: func(v interface{}) string {
+					if json.Valid([]byte(v.(string))) {
+						return compactJSON([]byte(v.(string)))
+					}
+					return v.(string)
+				},
+				Description: "The rule as JSON, either a single rule or the JSON of akamai_property_rules_builder, the rule is identified by its name",
+			},
+			"name": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Name of the rule",
+			},
+			"version": {
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "Property version the rule was last saved to",
+			},
+		},
+	}
+}
+
+// propertyRuleLocks serializes the changes of the rules of a property, as each akamai_property_rule updates
+// the whole rule tree of the latest version and Terraform applies resources in parallel
+var propertyRuleLocks = struct {
+	sync.Mutex
+	locks map[string]*sync.Mutex
+}{locks: map[string]*sync.Mutex{}}
+
+func lockPropertyRules(propertyID string) func() {
+	propertyRuleLocks.Lock()
+	lock, ok := propertyRuleLocks.locks[propertyID]
+	if !ok {
+		lock = &sync.Mutex{}
+		propertyRuleLocks.locks[propertyID] = lock
+	}
+	propertyRuleLocks.Unlock()
+
+	lock.Lock()
+	return lock.Unlock
+}
+
+func suppressPropertyRule(_, old, new string, _ *schema.ResourceData) bool {
+	if old == "" || new == "" {
+		return old == new
+	}
+	oldRule, err := parseChildRule(old)
+	if err != nil {
+		return false
+	}
+	newRule, err := parseChildRule(new)
+	if err != nil {
+		return false
+	}
+	return compareRules(&oldRule, &newRule)
+}
+
+// propertyRuleNameCustomDiff sets the name of the rule from the planned rules, so that renaming the rule is shown in the plan
+func propertyRuleNameCustomDiff(_ context.Context, d *schema.ResourceDiff, _ interface{}) error {
+	if !d.NewValueKnown("rules") {
+		return d.SetNewComputed("name")
+	}
+	rule, err := parseChildRule(d.Get("rules").(string))
+	if err != nil {
+		return err
+	}
+	if rule.Name != d.Get("name").(string) {
+		if err := d.SetNew("name", rule.Name); err != nil {
+			return fmt.Errorf("%w: %s", tools.ErrValueSet, err.Error())
+		}
+	}
+	return nil
+}
+
+func resourcePropertyRuleCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	ctx = log.NewContext(ctx, akamai.Meta(m).Log("PAPI", "resourcePropertyRuleCreate"))
+
+	rule, err := parseChildRule(d.Get("rules").(string))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	parentPath := d.Get("parent_path").(string)
+
+	err = savePropertyRule(ctx, d, m, func(parent *papi.Rules) (bool, error) {
+		if index, _ := findChildRule(parent, rule.Name); index >= 0 {
+			return false, fmt.Errorf("rule %q already exists in %q, import it to manage it", rule.Name, ruleDisplayPath(parentPath))
+		}
+		parent.Children = append(parent.Children, rule)
+		return true, nil
+	})
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId(propertyRuleID(d.Get("property_id").(string), parentPath, rule.Name))
+	return resourcePropertyRuleRead(ctx, d, m)
+}
+
+func resourcePropertyRuleRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	ctx = log.NewContext(ctx, akamai.Meta(m).Log("PAPI", "resourcePropertyRuleRead"))
+	logger := log.FromContext(ctx)
+	client := inst.Client(akamai.Meta(m))
+
+	PropertyID := tools.AddPrefix(d.Get("property_id").(string), "prp_")
+	Property, err := fetchLatestProperty(ctx, client, PropertyID, d.Get("group_id").(string), d.Get("contract_id").(string))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	Rules, _, _, _, err := fetchPropertyVersionRules(ctx, client, *Property, Property.LatestVersion)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	parentPath := d.Get("parent_path").(string)
+	name := d.Get("name").(string)
+	rule, err := findRulePath(&Rules.Rules, append(splitRulePath(parentPath), name))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	if rule == nil {
+		logger.Warnf("Rule %q was removed from version %d of the property", ruleDisplayPath(parentPath+"/"+name), Property.LatestVersion)
+		d.SetId("")
+		return nil
+	}
+
+	ruleJSON, err := json.Marshal(rule)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	attrs := map[string]interface{}{
+		"property_id": Property.PropertyID,
+		"contract_id": Property.ContractID,
+		"group_id":    Property.GroupID,
+		"rules":       string(ruleJSON),
+		"name":        rule.Name,
+		"version":     Property.LatestVersion,
+	}
+	if err := rdSetAttrs(ctx, d, attrs); err != nil {
+		return diag.FromErr(err)
+	}
+	return nil
+}
+
+func resourcePropertyRuleUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	ctx = log.NewContext(ctx, akamai.Meta(m).Log("PAPI", "resourcePropertyRuleUpdate"))
+
+	rule, err := parseChildRule(d.Get("rules").(string))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	parentPath := d.Get("parent_path").(string)
+	oldName, _ := d.GetChange("name")
+
+	err = savePropertyRule(ctx, d, m, func(parent *papi.Rules) (bool, error) {
+		if err := replaceChildRule(parent, parentPath, oldName.(string), rule); err != nil {
+			return false, err
+		}
+		return true, nil
+	})
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId(propertyRuleID(d.Get("property_id").(string), parentPath, rule.Name))
+	return resourcePropertyRuleRead(ctx, d, m)
+}
+
+// replaceChildRule replaces the child rule with the old name by the rule, or adds the rule if there is no such child.
+// A rename must not give the rule the name of one of its siblings, as its path would become ambiguous.
+func replaceChildRule(parent *papi.Rules, parentPath, oldName string, rule papi.Rules) error {
+	index, err := findChildRule(parent, oldName)
+	if err != nil {
+		return fmt.Errorf("%w: %q", err, ruleDisplayPath(parentPath+"/"+oldName))
+	}
+	if rule.Name != oldName {
+		if sibling, _ := findChildRule(parent, rule.Name); sibling >= 0 {
+			return fmt.Errorf("cannot rename rule %q, rule %q already exists in %q", oldName, rule.Name, ruleDisplayPath(parentPath))
+		}
+	}
+	if index < 0 {
+		parent.Children = append(parent.Children, rule)
+		return nil
+	}
+	parent.Children[index] = rule
+	return nil
+}
+
+func resourcePropertyRuleDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	ctx = log.NewContext(ctx, akamai.Meta(m).Log("PAPI", "resourcePropertyRuleDelete"))
+
+	name := d.Get("name").(string)
+	parentPath := d.Get("parent_path").(string)
+	err := savePropertyRule(ctx, d, m, func(parent *papi.Rules) (bool, error) {
+		index, err := findChildRule(parent, name)
+		if err != nil {
+			return false, fmt.Errorf("%w: %q", err, ruleDisplayPath(parentPath+"/"+name))
+		}
+		if index < 0 {
+			return false, nil
+		}
+		parent.Children = append(parent.Children[:index], parent.Children[index+1:]...)
+		return true, nil
+	})
+	if err != nil && !errors.Is(err, ErrParentRuleNotFound) {
+		return diag.FromErr(err)
+	}
+	return nil
+}
+
+func resourcePropertyRuleImport(ctx context.Context, d *schema.ResourceData, _ interface{}) ([]*schema.ResourceData, error) {
+	// the import ID is the property ID and the path of the rule below the default rule, e.g. prp_1:Performance/Caching
+	parts := strings.SplitN(d.Id(), ":", 2)
+	if len(parts) != 2 {
+		return nil, fmt.Errorf("invalid property rule identifier %q, expected property_id:path", d.Id())
+	}
+	path := splitRulePath(parts[1])
+	if len(path) == 0 {
+		return nil, fmt.Errorf("invalid property rule identifier %q, the path has no rule name", d.Id())
+	}
+
+	PropertyID := tools.AddPrefix(parts[0], "prp_")
+	parentPath := strings.Join(path[:len(path)-1], "/")
+	attrs := map[string]interface{}{
+		"property_id": PropertyID,
+		"parent_path": parentPath,
+		"name":        path[len(path)-1],
+	}
+	if err := rdSetAttrs(ctx, d, attrs); err != nil {
+		return nil, err
+	}
+	d.SetId(propertyRuleID(PropertyID, parentPath, path[len(path)-1]))
+	return []*schema.ResourceData{d}, nil
+}
+
+// savePropertyRule applies the change to the parent rule of the resource in the latest property version, and saves
+// the rule tree if the change reports it changed the rules. A new version is created first if the latest version
+// is active on staging or production.
+func savePropertyRule(ctx context.Context, d *schema.ResourceData, m interface{}, change func(parent *papi.Rules) (bool, error)) error {
+	logger := log.FromContext(ctx)
+	client := inst.Client(akamai.Meta(m))
+
+	PropertyID := tools.AddPrefix(d.Get("property_id").(string), "prp_")
+	unlock := lockPropertyRules(PropertyID)
+	defer unlock()
+
+	Property, err := fetchLatestProperty(ctx, client, PropertyID, d.Get("group_id").(string), d.Get("contract_id").(string))
+	if err != nil {
+		return err
+	}
+	Rules, RuleFormat, _, _, err := fetchPropertyVersionRules(ctx, client, *Property, Property.LatestVersion)
+	if err != nil {
+		return err
+	}
+	parentPath := d.Get("parent_path").(string)
+	parent, err := findRulePath(&Rules.Rules, splitRulePath(parentPath))
+	if err != nil {
+		return err
+	}
+	if parent == nil {
+		return fmt.Errorf("%w: %q in version %d of property %s", ErrParentRuleNotFound, ruleDisplayPath(parentPath), Property.LatestVersion, PropertyID)
+	}
+	changed, err := change(parent)
+	if err != nil || !changed {
+		return err
+	}
+
+	// a new version starts with the rules of the latest version, so that the changed rules apply to it too
+	if isActiveVersion(Property.LatestVersion, Property.StagingVersion) || isActiveVersion(Property.LatestVersion, Property.ProductionVersion) {
+		logger.Debugf("Version %d of the property is active, creating a new version", Property.LatestVersion)
+		Property.LatestVersion, err = createPropertyVersion(ctx, client, *Property)
+		if err != nil {
+			return err
+		}
+	}
+
+	// the rule tree is saved in its own rule format, which PAPI would otherwise convert to the latest one
+	if RuleFormat != "" {
+		h := http.Header{"Content-Type": []string{fmt.Sprintf("application/vnd.akamai.papirules.%s+json", RuleFormat)}}
+		ctx = session.ContextWithOptions(ctx, session.WithContextHeaders(h))
+	}
+	return updatePropertyRules(ctx, client, *Property, Rules)
+}
+
+func isActiveVersion(version int, activeVersion *int) bool {
+	return activeVersion != nil && *activeVersion == version
+}
+
+func propertyRuleID(propertyID, parentPath, name string) string {
+	return fmt.Sprintf("%s:%s", tools.AddPrefix(propertyID, "prp_"), strings.Join(append(splitRulePath(parentPath), name), "/"))
+}
+
+// splitRulePath returns the rule names of a path, such as "Performance/Caching"
+func splitRulePath(path string) []string {
+	var names []string
+	for _, name := range strings.Split(path, "/") {
+		if name = strings.TrimSpace(name); name != "" {
+			names = append(names, name)
+		}
+	}
+	return names
+}
+
+// findRulePath returns the rule at the path of child rule names below the given rule, or nil if there is no such rule.
+// PAPI allows sibling rules with the same name, a path through one of them is ambiguous and returns an error.
+func findRulePath(rules *papi.Rules, path []string) (*papi.Rules, error) {
+	for i, name := range path {
+		index, err := findChildRule(rules, name)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %q", err, ruleDisplayPath(strings.Join(path[:i+1], "/")))
+		}
+		if index < 0 {
+			return nil, nil
+		}
+		rules = &rules.Children[index]
+	}
+	return rules, nil
+}
+
+// findChildRule returns the index of the child rule with the name, or -1 if there is no such rule.
+// If several child rules have the name, the index of the first one is returned with ErrAmbiguousRulePath.
+func findChildRule(rules *papi.Rules, name string) (int, error) {
+	index := -1
+	for i := range rules.Children {
+		if rules.Children[i].Name != name {
+			continue
+		}
+		if index >= 0 {
+			return index, fmt.Errorf("%w, several rules are named %q", ErrAmbiguousRulePath, name)
+		}
+		index = i
+	}
+	return index, nil
+}
+
+func ruleDisplayPath(path string) string {
+	return strings.Join(append([]string{"default"}, splitRulePath(path)...), "/")
+}
+
+// copyRulePaths replaces the child rules at the paths in dst with the ones in src, so that the rules owned by
+// akamai_property_rule resources are kept when akamai_property updates the rule tree.
+// A path which is ambiguous in dst or src returns an error, rather than replacing the wrong rule.
+func copyRulePaths(dst, src *papi.Rules, paths []string) error {
+	if err := checkRulePaths(dst, paths); err != nil {
+		return err
+	}
+	if err := checkRulePaths(src, paths); err != nil {
+		return err
+	}
+	for _, p := range paths {
+		path := splitRulePath(p)
+		if len(path) == 0 {
+			continue
+		}
+		parent, _ := findRulePath(dst, path[:len(path)-1])
+		if parent == nil {
+			continue
+		}
+		index, _ := findChildRule(parent, path[len(path)-1])
+		rule, _ := findRulePath(src, path)
+
+		switch {
+		case index >= 0 && rule != nil:
+			parent.Children[index] = *rule
+		case index >= 0:
+			parent.Children = append(parent.Children[:index], parent.Children[index+1:]...)
+		case rule != nil:
+			parent.Children = append(parent.Children, *rule)
+		}
+	}
+	return nil
+}
+
+// checkRulePaths returns an error if one of the paths is ambiguous in the rules
+func checkRulePaths(rules *papi.Rules, paths []string) error {
+	for _, p := range paths {
+		if _, err := findRulePath(rules, splitRulePath(p)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
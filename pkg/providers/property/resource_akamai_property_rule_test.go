@@ -0,0 +1,304 @@
+package property
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v2/pkg/papi"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/tj/assert"
+)
+
+func TestResourcePropertyRule(t *testing.T) {
+	caching := papi.Rules{
+		Name: "Caching",
+		Behaviors: []papi.RuleBehavior{{
+			Name:    "caching",
+			Options: papi.RuleOptionsMap{"behavior": "MAX_AGE", "mustRevalidate": false, "ttl": "1d"},
+		}},
+	}
+	ruleTree := func(children ...papi.Rules) papi.Rules {
+		return papi.Rules{
+			Name:     "default",
+			Children: []papi.Rules{{Name: "Performance", Children: children}},
+		}
+	}
+	hasPerformanceChildren := func(names ...string) func(papi.UpdateRulesRequest) bool {
+		return func(r papi.UpdateRulesRequest) bool {
+			children := r.Rules.Rules.Children[0].Children
+			if len(children) != len(names) {
+				return false
+			}
+			for i, name := range names {
+				if children[i].Name != name {
+					return false
+				}
+			}
+			return true
+		}
+	}
+	property := &papi.Property{PropertyID: "prp_1", ContractID: "ctr_1", GroupID: "grp_1", LatestVersion: 1}
+
+	client := &mockpapi{}
+	client.On("GetProperty", AnyCTX, papi.GetPropertyRequest{PropertyID: "prp_1"}).
+		Return(&papi.GetPropertyResponse{Property: property}, nil)
+	client.On("GetProperty", AnyCTX, papi.GetPropertyRequest{PropertyID: "prp_1", ContractID: "ctr_1", GroupID: "grp_1"}).
+		Return(&papi.GetPropertyResponse{Property: property}, nil)
+
+	ruleTreeRequest := papi.GetRuleTreeRequest{
+		PropertyID:      "prp_1",
+		ContractID:      "ctr_1",
+		GroupID:         "grp_1",
+		PropertyVersion: 1,
+		ValidateMode:    papi.RuleValidateModeFull,
+		ValidateRules:   true,
+	}
+	client.On("GetRuleTree", AnyCTX, ruleTreeRequest).
+		Return(&papi.GetRuleTreeResponse{RuleFormat: "v2021-05-05", Rules: ruleTree(papi.Rules{Name: "Origin"})}, nil).Once()
+	client.On("UpdateRuleTree", AnyCTX, mock.MatchedBy(hasPerformanceChildren("Origin", "Caching"))).
+		Return(&papi.UpdateRulesResponse{}, nil).Once()
+	client.On("GetRuleTree", AnyCTX, ruleTreeRequest).
+		Return(&papi.GetRuleTreeResponse{RuleFormat: "v2021-05-05", Rules: ruleTree(papi.Rules{Name: "Origin"}, caching)}, nil)
+	client.On("UpdateRuleTree", AnyCTX, mock.MatchedBy(hasPerformanceChildren("Origin"))).
+		Return(&papi.UpdateRulesResponse{}, nil).Once()
+
+	useClient(client, func() {
+		resource.UnitTest(t, resource.TestCase{
+			Providers: testAccProviders,
+			Steps: []resource.TestStep{{
+				Config: loadFixtureString("testdata/TestResPropertyRule/property_rule.tf"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("akamai_property_rule.test", "id", "prp_1:Performance/Caching"),
+					resource.TestCheckResourceAttr("akamai_property_rule.test", "name", "Caching"),
+					resource.TestCheckResourceAttr("akamai_property_rule.test", "contract_id", "ctr_1"),
+					resource.TestCheckResourceAttr("akamai_property_rule.test", "version", "1"),
+				),
+			}, {
+				ImportState:       true,
+				ImportStateId:     "prp_1:Performance/Caching",
+				ResourceName:      "akamai_property_rule.test",
+				ImportStateVerify: true,
+			}},
+		})
+	})
+
+	client.AssertExpectations(t)
+}
+
+func TestResourcePropertyRuleImport(t *testing.T) {
+	tests := map[string]struct {
+		importID   string
+		parentPath string
+		name       string
+		withError  bool
+	}{
+		"child of the default rule": {
+			importID: "prp_1:Caching",
+			name:     "Caching",
+		},
+		"nested rule without prefix": {
+			importID:   "1:Performance/Static/Caching",
+			parentPath: "Performance/Static",
+			name:       "Caching",
+		},
+		"missing path": {
+			importID:  "prp_1",
+			withError: true,
+		},
+		"empty path": {
+			importID:  "prp_1:/",
+			withError: true,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			d := schema.TestResourceDataRaw(t, resourcePropertyRule().Schema, map[string]interface{}{})
+			d.SetId(test.importID)
+			_, err := resourcePropertyRuleImport(context.Background(), d, nil)
+			if test.withError {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, "prp_1", d.Get("property_id"))
+			assert.Equal(t, test.parentPath, d.Get("parent_path"))
+			assert.Equal(t, test.name, d.Get("name"))
+		})
+	}
+}
+
+func TestFindRulePath(t *testing.T) {
+	rules := papi.Rules{Name: "default", Children: []papi.Rules{
+		{Name: "Performance", Children: []papi.Rules{{Name: "Caching"}}},
+		{Name: "Security"},
+	}}
+
+	rule, err := findRulePath(&rules, nil)
+	require.NoError(t, err)
+	assert.Equal(t, &rules, rule)
+	rule, err = findRulePath(&rules, splitRulePath("Performance/Caching"))
+	require.NoError(t, err)
+	assert.Equal(t, "Caching", rule.Name)
+	rule, err = findRulePath(&rules, splitRulePath(" /Security/ "))
+	require.NoError(t, err)
+	assert.Equal(t, "Security", rule.Name)
+	rule, err = findRulePath(&rules, splitRulePath("Performance/Static"))
+	require.NoError(t, err)
+	assert.Nil(t, rule)
+
+	t.Run("ambiguous path", func(t *testing.T) {
+		rules := papi.Rules{Name: "default", Children: []papi.Rules{
+			{Name: "Performance", Children: []papi.Rules{{Name: "Caching"}}},
+			{Name: "Performance"},
+		}}
+		_, err := findRulePath(&rules, splitRulePath("Performance/Caching"))
+		assert.True(t, errors.Is(err, ErrAmbiguousRulePath), "want: %s; got: %s", ErrAmbiguousRulePath, err)
+		rule, err := findRulePath(&rules, splitRulePath("Security"))
+		require.NoError(t, err)
+		assert.Nil(t, rule)
+	})
+}
+
+func TestReplaceChildRule(t *testing.T) {
+	tests := map[string]struct {
+		children  []papi.Rules
+		oldName   string
+		rule      papi.Rules
+		expected  []papi.Rules
+		withError bool
+	}{
+		"replaces rule": {
+			children: []papi.Rules{{Name: "Origin"}, {Name: "Caching"}},
+			oldName:  "Caching",
+			rule:     papi.Rules{Name: "Caching", Comments: "updated"},
+			expected: []papi.Rules{{Name: "Origin"}, {Name: "Caching", Comments: "updated"}},
+		},
+		"renames rule": {
+			children: []papi.Rules{{Name: "Origin"}, {Name: "Caching"}},
+			oldName:  "Caching",
+			rule:     papi.Rules{Name: "Static"},
+			expected: []papi.Rules{{Name: "Origin"}, {Name: "Static"}},
+		},
+		"adds removed rule": {
+			children: []papi.Rules{{Name: "Origin"}},
+			oldName:  "Caching",
+			rule:     papi.Rules{Name: "Caching"},
+			expected: []papi.Rules{{Name: "Origin"}, {Name: "Caching"}},
+		},
+		"rename to the name of a sibling": {
+			children:  []papi.Rules{{Name: "Origin"}, {Name: "Caching"}},
+			oldName:   "Caching",
+			rule:      papi.Rules{Name: "Origin"},
+			withError: true,
+		},
+		"ambiguous rule": {
+			children:  []papi.Rules{{Name: "Caching"}, {Name: "Caching"}},
+			oldName:   "Caching",
+			rule:      papi.Rules{Name: "Caching"},
+			withError: true,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			parent := papi.Rules{Name: "Performance", Children: test.children}
+			err := replaceChildRule(&parent, "Performance", test.oldName, test.rule)
+			if test.withError {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, test.expected, parent.Children)
+		})
+	}
+}
+
+func TestCopyRulePaths(t *testing.T) {
+	src := papi.Rules{Name: "default", Children: []papi.Rules{
+		{Name: "Performance", Children: []papi.Rules{{Name: "Caching", Comments: "managed"}}},
+		{Name: "Security", Comments: "managed"},
+	}}
+
+	tests := map[string]struct {
+		dst       papi.Rules
+		paths     []string
+		expected  papi.Rules
+		withError bool
+	}{
+		"replaces rule in place": {
+			dst: papi.Rules{Name: "default", Children: []papi.Rules{
+				{Name: "Security"},
+				{Name: "Origin"},
+			}},
+			paths: []string{"Security"},
+			expected: papi.Rules{Name: "default", Children: []papi.Rules{
+				{Name: "Security", Comments: "managed"},
+				{Name: "Origin"},
+			}},
+		},
+		"appends missing rule": {
+			dst: papi.Rules{Name: "default", Children: []papi.Rules{
+				{Name: "Performance"},
+			}},
+			paths: []string{"Performance/Caching"},
+			expected: papi.Rules{Name: "default", Children: []papi.Rules{
+				{Name: "Performance", Children: []papi.Rules{{Name: "Caching", Comments: "managed"}}},
+			}},
+		},
+		"removes rule missing from source": {
+			dst: papi.Rules{Name: "default", Children: []papi.Rules{
+				{Name: "Origin"},
+				{Name: "Static"},
+			}},
+			paths: []string{"Static"},
+			expected: papi.Rules{Name: "default", Children: []papi.Rules{
+				{Name: "Origin"},
+			}},
+		},
+		"ignores missing parent": {
+			dst:      papi.Rules{Name: "default"},
+			paths:    []string{"Performance/Caching"},
+			expected: papi.Rules{Name: "default"},
+		},
+		"ambiguous rule": {
+			dst: papi.Rules{Name: "default", Children: []papi.Rules{
+				{Name: "Security"},
+				{Name: "Security"},
+			}},
+			paths:     []string{"Security"},
+			withError: true,
+		},
+		"ambiguous parent": {
+			dst: papi.Rules{Name: "default", Children: []papi.Rules{
+				{Name: "Performance"},
+				{Name: "Performance"},
+			}},
+			paths:     []string{"Performance/Caching"},
+			withError: true,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			err := copyRulePaths(&test.dst, &src, test.paths)
+			if test.withError {
+				assert.True(t, errors.Is(err, ErrAmbiguousRulePath), "want: %s; got: %s", ErrAmbiguousRulePath, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, test.expected, test.dst)
+		})
+	}
+
+	t.Run("ambiguous source rule", func(t *testing.T) {
+		src := papi.Rules{Name: "default", Children: []papi.Rules{{Name: "Security"}, {Name: "Security"}}}
+		dst := papi.Rules{Name: "default", Children: []papi.Rules{{Name: "Security"}}}
+		err := copyRulePaths(&dst, &src, []string{"Security"})
+		assert.True(t, errors.Is(err, ErrAmbiguousRulePath), "want: %s; got: %s", ErrAmbiguousRulePath, err)
+	})
+}
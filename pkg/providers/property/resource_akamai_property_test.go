@@ -1,16 +1,23 @@
 package property
 
 import (
+	"context"
 	"fmt"
 	"regexp"
+	"strconv"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v2/pkg/papi"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/akamai/terraform-provider-akamai/v2/pkg/akamai"
 )
 
 func TestResProperty(t *testing.T) {
@@ -742,10 +749,11 @@ func TestResProperty(t *testing.T) {
 		// Test Schema Configuration
 
 		t.Run("Schema Configuration Error: name not given", AssertConfigError(t, "name not given", `"name" is required`))
-		t.Run("Schema Configuration Error: neither contract nor contract_id given", AssertConfigError(t, "neither contract nor contract_id given", `one of .contract,contract_id. must be specified`))
-		t.Run("Schema Configuration Error: both contract and contract_id given", AssertConfigError(t, "both contract and contract_id given", `only one of .contract,contract_id. can be specified`))
-		t.Run("Schema Configuration Error: neither group nor group_id given", AssertConfigError(t, "neither group nor group_id given", `one of .group,group_id. must be specified`))
-		t.Run("Schema Configuration Error: both group and group_id given", AssertConfigError(t, "both group and group_id given", `only one of .group,group_id. can be specified`))
+		// without a provider default, a missing contract or group fails at apply
+		t.Run("Schema Configuration Error: neither contract nor contract_id given", AssertConfigError(t, "neither contract nor contract_id given", regexp.QuoteMeta(akamai.ErrNoContractID.Error())))
+		t.Run("Schema Configuration Error: both contract and contract_id given", AssertConfigError(t, "both contract and contract_id given", `"contract_id": conflicts with contract`))
+		t.Run("Schema Configuration Error: neither group nor group_id given", AssertConfigError(t, "neither group nor group_id given", regexp.QuoteMeta(akamai.ErrNoGroupID.Error())))
+		t.Run("Schema Configuration Error: both group and group_id given", AssertConfigError(t, "both group and group_id given", `"group_id": conflicts with group`))
 		t.Run("Schema Configuration Error: neither product nor product_id given", AssertConfigError(t, "neither product nor product_id given", `one of .product,product_id. must be specified`))
 		t.Run("Schema Configuration Error: both product and product_id given", AssertConfigError(t, "both product and product_id given", `only one of .product,product_id. can be specified`))
 		t.Run("Schema Configuration Error: invalid json rules", AssertConfigError(t, "invalid json rules", `rules are not valid JSON`))
@@ -1138,9 +1146,276 @@ func TestResProperty(t *testing.T) {
 			client.AssertExpectations(t)
 		})
 
+		t.Run("property is cloned from another property version", func(t *testing.T) {
+			client := &mockpapi{}
+			client.Test(T{t})
+
+			req := papi.CreatePropertyRequest{
+				ContractID: "ctr_0",
+				GroupID:    "grp_0",
+				Property: papi.PropertyCreate{
+					PropertyName: "test_property",
+					ProductID:    "prd_0",
+					CloneFrom: &papi.PropertyCloneFrom{
+						PropertyID:           "prp_1",
+						Version:              3,
+						CloneFromVersionEtag: "a9dfe78cf93090516bde891d009eaf57",
+						CopyHostnames:        true,
+					},
+				},
+			}
+
+			client.On("CreateProperty", AnyCTX, req).Return(nil, fmt.Errorf("the etag of the version does not match"))
+			useClient(client, func() {
+				resource.UnitTest(t, resource.TestCase{
+					Providers: testAccProviders,
+					Steps: []resource.TestStep{
+						{
+							Config:      loadFixtureString("testdata/%s.tf", t.Name()),
+							ExpectError: regexp.MustCompile(`the etag of the version does not match`),
+						},
+					},
+				})
+			})
+
+			client.AssertExpectations(t)
+		})
+
+		t.Run("property keeps the hostnames and rules copied from the cloned version", func(t *testing.T) {
+			client := &mockpapi{}
+			client.Test(T{t})
+
+			req := papi.CreatePropertyRequest{
+				ContractID: "ctr_0",
+				GroupID:    "grp_0",
+				Property: papi.PropertyCreate{
+					PropertyName: "test_property",
+					ProductID:    "prd_0",
+					CloneFrom: &papi.PropertyCloneFrom{
+						PropertyID:           "prp_1",
+						Version:              3,
+						CloneFromVersionEtag: "a9dfe78cf93090516bde891d009eaf57",
+						CopyHostnames:        true,
+					},
+				},
+			}
+			client.On("CreateProperty", AnyCTX, req).Return(&papi.CreatePropertyResponse{PropertyID: "prp_0"}, nil).Once()
+
+			ExpectGetProperty(
+				client, "prp_0", "grp_0", "ctr_0",
+				&papi.Property{
+					PropertyID: "prp_0", GroupID: "grp_0", ContractID: "ctr_0", LatestVersion: 1,
+					PropertyName: "test_property",
+				},
+			)
+			ExpectGetPropertyVersionHostnames(
+				client, "prp_0", "grp_0", "ctr_0", 1,
+				&[]papi.Hostname{{
+					CnameType:            "EDGE_HOSTNAME",
+					CnameFrom:            "from.test.domain",
+					CnameTo:              "to.test.domain",
+					CertProvisioningType: "DEFAULT",
+				}},
+			)
+			ruleFormat := ""
+			ExpectGetRuleTree(
+				client, "prp_0", "grp_0", "ctr_0", 1,
+				&papi.RulesUpdate{Rules: papi.Rules{
+					Name:      "default",
+					Behaviors: []papi.RuleBehavior{{Name: "origin", Options: papi.RuleOptionsMap{"hostname": "origin.test.domain"}}},
+				}},
+				&ruleFormat,
+			)
+			ExpectGetPropertyVersion(client, "prp_0", "grp_0", "ctr_0", 1, papi.VersionStatusInactive, papi.VersionStatusInactive)
+			ExpectRemoveProperty(client, "prp_0", "ctr_0", "grp_0")
+
+			useClient(client, func() {
+				resource.UnitTest(t, resource.TestCase{
+					Providers: testAccProviders,
+					Steps: []resource.TestStep{
+						{
+							Config: loadFixtureString("testdata/TestResProperty/property_is_cloned_from_another_property_version.tf"),
+							Check: resource.ComposeAggregateTestCheckFunc(
+								resource.TestCheckResourceAttr("akamai_property.test", "id", "prp_0"),
+								resource.TestCheckResourceAttr("akamai_property.test", "hostnames.#", "1"),
+								resource.TestCheckTypeSetElemNestedAttrs("akamai_property.test", "hostnames.*", map[string]string{
+									"cname_from": "from.test.domain",
+									"cname_to":   "to.test.domain",
+								}),
+								resource.TestMatchResourceAttr("akamai_property.test", "rules", regexp.MustCompile(`"name":"origin"`)),
+							),
+						},
+						{
+							Config:   loadFixtureString("testdata/TestResProperty/property_is_cloned_from_another_property_version.tf"),
+							PlanOnly: true,
+						},
+					},
+				})
+			})
+
+			client.AssertExpectations(t)
+		})
+
+		t.Run("error when hostnames are set along with copy_hostnames", func(t *testing.T) {
+			client := &mockpapi{}
+			client.Test(T{t})
+
+			useClient(client, func() {
+				resource.UnitTest(t, resource.TestCase{
+					Providers: testAccProviders,
+					Steps: []resource.TestStep{
+						{
+							Config:      loadFixtureString("testdata/TestResProperty/CloneFrom/copy_hostnames_with_hostnames.tf"),
+							PlanOnly:    true,
+							ExpectError: regexp.MustCompile(`'hostnames' cannot be set when 'clone_from.copy_hostnames' is true`),
+						},
+					},
+				})
+			})
+
+			client.AssertExpectations(t)
+		})
 	})
 }
 
+func TestGetPropertyCloneFrom(t *testing.T) {
+	tests := map[string]struct {
+		givenData map[string]interface{}
+		expected  *papi.PropertyCloneFrom
+	}{
+		"not cloned": {
+			givenData: map[string]interface{}{},
+		},
+		"cloned with unprefixed property ID": {
+			givenData: map[string]interface{}{
+				"clone_from": []interface{}{map[string]interface{}{
+					"property_id": "1",
+					"version":     2,
+				}},
+			},
+			expected: &papi.PropertyCloneFrom{PropertyID: "prp_1", Version: 2},
+		},
+		"cloned with hostnames": {
+			givenData: map[string]interface{}{
+				"clone_from": []interface{}{map[string]interface{}{
+					"property_id":    "prp_1",
+					"version":        2,
+					"etag":           "abc",
+					"copy_hostnames": true,
+				}},
+			},
+			expected: &papi.PropertyCloneFrom{PropertyID: "prp_1", Version: 2, CloneFromVersionEtag: "abc", CopyHostnames: true},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			d := schema.TestResourceDataRaw(t, resourceProperty().Schema, test.givenData)
+			cloneFrom, err := getPropertyCloneFrom(d)
+			require.NoError(t, err)
+			assert.Equal(t, test.expected, cloneFrom)
+		})
+	}
+}
+
+func TestSuppressCopiedHostnames(t *testing.T) {
+	copied := map[string]interface{}{
+		"cname_from":             "from.test.domain",
+		"cname_to":               "to.test.domain",
+		"cert_provisioning_type": "DEFAULT",
+	}
+	copiedHash := strconv.Itoa(resourceProperty().Schema["hostnames"].Set(copied))
+	configured := map[string]interface{}{
+		"cname_from":             "other.test.domain",
+		"cname_to":               "to.test.domain",
+		"cert_provisioning_type": "DEFAULT",
+	}
+	cloneFrom := func(copyHostnames bool) []interface{} {
+		return []interface{}{map[string]interface{}{"property_id": "prp_1", "version": 3, "copy_hostnames": copyHostnames}}
+	}
+
+	tests := map[string]struct {
+		givenData map[string]interface{}
+		id        string
+		key, new  string
+		expected  bool
+	}{
+		"copied hostnames without configured hostnames": {
+			givenData: map[string]interface{}{"clone_from": cloneFrom(true), "hostnames": []interface{}{copied}},
+			id:        "prp_0",
+			key:       fmt.Sprintf("hostnames.%s.cname_from", copiedHash),
+			expected:  true,
+		},
+		"copied hostnames count without configured hostnames": {
+			givenData: map[string]interface{}{"clone_from": cloneFrom(true), "hostnames": []interface{}{copied}},
+			id:        "prp_0",
+			key:       "hostnames.#",
+			new:       "0",
+			expected:  true,
+		},
+		"copied hostnames replaced by configured hostnames": {
+			givenData: map[string]interface{}{"clone_from": cloneFrom(true), "hostnames": []interface{}{configured}},
+			id:        "prp_0",
+			key:       fmt.Sprintf("hostnames.%s.cname_from", copiedHash),
+		},
+		"hostnames not copied": {
+			givenData: map[string]interface{}{"clone_from": cloneFrom(false), "hostnames": []interface{}{copied}},
+			id:        "prp_0",
+			key:       fmt.Sprintf("hostnames.%s.cname_from", copiedHash),
+		},
+		"property not created": {
+			givenData: map[string]interface{}{"clone_from": cloneFrom(true), "hostnames": []interface{}{copied}},
+			key:       fmt.Sprintf("hostnames.%s.cname_from", copiedHash),
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			d := schema.TestResourceDataRaw(t, resourceProperty().Schema, test.givenData)
+			d.SetId(test.id)
+			assert.Equal(t, test.expected, suppressCopiedHostnames(test.key, "", test.new, d))
+		})
+	}
+}
+
+func TestSavePropertyRules(t *testing.T) {
+	client := &mockpapi{}
+	client.Test(T{t})
+
+	Property := papi.Property{PropertyID: "prp_0", GroupID: "grp_0", ContractID: "ctr_0", LatestVersion: 1}
+	ruleFormat := "v2020-01-01"
+	ExpectGetRuleTree(client, "prp_0", "grp_0", "ctr_0", 1, &papi.RulesUpdate{Rules: papi.Rules{Name: "default", Children: []papi.Rules{
+		{Name: "Origin"},
+		{Name: "Security", Comments: "managed"},
+	}}}, &ruleFormat).Once()
+	ExpectUpdateRuleTree(client, "prp_0", "grp_0", "ctr_0", 1, &papi.RulesUpdate{Rules: papi.Rules{Name: "default", Children: []papi.Rules{
+		{Name: "Origin", Comments: "updated"},
+		{Name: "Security", Comments: "managed"},
+	}}}, ruleFormat, nil).Once()
+
+	// the rules are saved only once the akamai_property_rule resource holding the lock is done
+	unlock := lockPropertyRules("prp_0")
+	done := make(chan error)
+	go func() {
+		_, err := savePropertyRules(context.Background(), client, Property, papi.RulesUpdate{Rules: papi.Rules{Name: "default", Children: []papi.Rules{
+			{Name: "Origin", Comments: "updated"},
+			{Name: "Security"},
+		}}}, ruleFormat, "", []string{"Security"})
+		done <- err
+	}()
+
+	select {
+	case err := <-done:
+		t.Fatalf("rules saved while locked: %v", err)
+	case <-time.After(50 * time.Millisecond):
+	}
+	client.AssertNotCalled(t, "GetRuleTree", mock.Anything, mock.Anything)
+
+	unlock()
+	require.NoError(t, <-done)
+	client.AssertExpectations(t)
+}
+
 func TestValidatePropertyName(t *testing.T) {
 	invalidNameCharacters := diag.Errorf("a name must only contain letters, numbers, and these characters: . _ -")
 	invalidNameLength := diag.Errorf("a name must be shorter than 86 characters")
@@ -1200,3 +1475,23 @@ func TestValidatePropertyName(t *testing.T) {
 		})
 	}
 }
+
+func TestRulesHash(t *testing.T) {
+	compact, err := rulesHash(`{"rules":{"name":"default","behaviors":[{"name":"cpCode","options":{"value":{"id":123}}}]}}`)
+	require.NoError(t, err)
+	indented, err := rulesHash(`{
+  "rules": {
+    "behaviors": [{"name": "cpCode", "options": {"value": {"id": 123}}}],
+    "name": "default"
+  }
+}`)
+	require.NoError(t, err)
+	assert.Equal(t, compact, indented)
+
+	changed, err := rulesHash(`{"rules":{"name":"default","behaviors":[{"name":"cpCode","options":{"value":{"id":456}}}]}}`)
+	require.NoError(t, err)
+	assert.NotEqual(t, compact, changed)
+
+	_, err = rulesHash(`{"rules":`)
+	assert.Error(t, err)
+}
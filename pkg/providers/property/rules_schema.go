@@ -0,0 +1,605 @@
+package property
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"math"
+	"net/http"
+	"net/url"
+	"path/filepath"
+	"reflect"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+	"unicode/utf8"
+
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v2/pkg/session"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+
+	"github.com/akamai/terraform-provider-akamai/v2/pkg/akamai"
+	"github.com/akamai/terraform-provider-akamai/v2/pkg/tools"
+)
+
+type (
+	// rulesSchema is the JSON schema of a rule format, which PAPI uses to validate the rule trees of a product.
+	// Only the keywords used by the PAPI schemas are supported, references to other documents are not resolved.
+	rulesSchema struct {
+		root interface{}
+	}
+
+	// rulesSchemaViolation is a value of the rules which does not match the schema, located by its JSON pointer
+	rulesSchemaViolation struct {
+		Pointer string
+		Message string
+	}
+
+	// rulesSchemaEntry holds the schema of a product and rule format once it is loaded
+	rulesSchemaEntry struct {
+		mu     sync.Mutex
+		schema *rulesSchema
+	}
+)
+
+var (
+	// ErrRulesSchema is returned when the rules do not match the schema of their rule format
+	ErrRulesSchema = errors.New("rules do not match the rule format schema")
+
+	// patterns of the schemas are compiled once, patterns which are not supported by regexp are ignored
+	rulesSchemaPatterns   = map[string]*regexp.Regexp{}
+	rulesSchemaPatternsMu sync.Mutex
+
+	// schemas loaded during the run by product and rule format, so that each one is fetched and parsed once
+	// even when the provider cache is disabled
+	rulesSchemas   = map[string]*rulesSchemaEntry{}
+	rulesSchemasMu sync.Mutex
+)
+
+// maxRulesSchemaRefs limits the number of references followed without validating a nested value, to stop reference loops
+const maxRulesSchemaRefs = 32
+
+// rulesSchemaCustomDiff validates the planned rules against the schema of their product and rule format.
+// The schema cannot be fetched while the product or rule format are unknown, and rules are not validated
+// if the schema cannot be fetched, PAPI still validates them when they are saved.
+func rulesSchemaCustomDiff(ctx context.Context, diff *schema.ResourceDiff, m interface{}) error {
+	if !diff.HasChange("rules") && !diff.HasChange("rule_format") {
+		return nil
+	}
+	for _, key := range []string{"rules", "rule_format", "product_id"} {
+		if !diff.NewValueKnown(key) {
+			return nil
+		}
+	}
+	rules, _ := diff.Get("rules").(string)
+	productID, _ := diff.Get("product_id").(string)
+	if productID == "" {
+		// the deprecated product attribute of akamai_property
+		productID, _ = diff.Get("product").(string)
+	}
+	if rules == "" || productID == "" {
+		return nil
+	}
+	ruleFormat, _ := diff.Get("rule_format").(string)
+	if ruleFormat == "" {
+		ruleFormat = "latest"
+	}
+
+	meta := akamai.Meta(m)
+	logger := meta.Log("PAPI", "rulesSchemaCustomDiff")
+
+	rs, err := getRulesSchema(ctx, meta, productID, ruleFormat)
+	if err != nil {
+		logger.Warnf("Rules are not validated, the schema of %s for rule format %s is not available: %s", productID, ruleFormat, err)
+		return nil
+	}
+
+	var value interface{}
+	if err := json.Unmarshal([]byte(rules), &value); err != nil {
+		return fmt.Errorf("rules are not valid JSON: %s", err)
+	}
+	return rulesSchemaError(ruleFormat, rs.validate(value))
+}
+
+// rulesSchemaError returns an error listing the violations of the schema of the rule format, one line per JSON pointer,
+// or nil if there are none. CustomizeDiff can only fail with a single error, see rulesSchemaDiags for data sources.
+func rulesSchemaError(ruleFormat string, violations []rulesSchemaViolation) error {
+	if len(violations) == 0 {
+		return nil
+	}
+
+	pointers, messages := groupViolations(violations)
+	lines := make([]string, 0, len(pointers))
+	for _, pointer := range pointers {
+		lines = append(lines, fmt.Sprintf("  %s: %s", pointer, strings.Join(messages[pointer], "; ")))
+	}
+	return fmt.Errorf("%w %s:\n%s", ErrRulesSchema, ruleFormat, strings.Join(lines, "\n"))
+}
+
+// rulesSchemaDiags returns an error diagnostic for each JSON pointer of the rules which does not match the schema
+// of the rule format
+func rulesSchemaDiags(ruleFormat string, violations []rulesSchemaViolation) diag.Diagnostics {
+	var diags diag.Diagnostics
+	pointers, messages := groupViolations(violations)
+	for _, pointer := range pointers {
+		diags = append(diags, diag.Diagnostic{
+			Severity: diag.Error,
+			Summary:  fmt.Sprintf("%s %s at %s", ErrRulesSchema, ruleFormat, pointer),
+			Detail:   strings.Join(messages[pointer], "\n"),
+		})
+	}
+	return diags
+}
+
+// groupViolations returns the JSON pointers of the violations, in the order of the rules, and their messages
+func groupViolations(violations []rulesSchemaViolation) ([]string, map[string][]string) {
+	var pointers []string
+	messages := make(map[string][]string)
+	for _, v := range violations {
+		if _, ok := messages[v.Pointer]; !ok {
+			pointers = append(pointers, v.Pointer)
+		}
+		messages[v.Pointer] = append(messages[v.Pointer], v.Message)
+	}
+	return pointers, messages
+}
+
+// getRulesSchema returns the schema of a product and rule format. The schema is only read from the rules_schema_dir
+// of the provider if it is set, for runs without access to the API, otherwise it is fetched once and kept in the cache.
+// Schemas are also kept for the rest of the run, as the cache can be disabled.
+func getRulesSchema(ctx context.Context, meta akamai.OperationMeta, productID, ruleFormat string) (*rulesSchema, error) {
+	productID = tools.AddPrefix(productID, "prd_")
+
+	key := fmt.Sprintf("rules_schema:%s:%s", productID, ruleFormat)
+	rulesSchemasMu.Lock()
+	entry, ok := rulesSchemas[key]
+	if !ok {
+		entry = &rulesSchemaEntry{}
+		rulesSchemas[key] = entry
+	}
+	rulesSchemasMu.Unlock()
+
+	// concurrent plans of resources with the same product and rule format wait for the first load
+	entry.mu.Lock()
+	defer entry.mu.Unlock()
+	if entry.schema != nil {
+		return entry.schema, nil
+	}
+	rs, err := loadRulesSchema(ctx, meta, key, productID, ruleFormat)
+	if err != nil {
+		return nil, err
+	}
+	entry.schema = rs
+	return rs, nil
+}
+
+// loadRulesSchema reads the schema from the rules_schema_dir, or from the cache and the API
+func loadRulesSchema(ctx context.Context, meta akamai.OperationMeta, key, productID, ruleFormat string) (*rulesSchema, error) {
+	if inst.rulesSchemaDir != "" {
+		data, err := ioutil.ReadFile(filepath.Join(inst.rulesSchemaDir, productID, ruleFormat+".json"))
+		if err != nil {
+			return nil, err
+		}
+		return newRulesSchema(data)
+	}
+
+	var data json.RawMessage
+	if err := meta.CacheGet(inst, key, &data); err != nil {
+		if !akamai.IsNotFoundError(err) && !errors.Is(err, akamai.ErrCacheDisabled) {
+			return nil, err
+		}
+		data, err = fetchRulesSchema(ctx, meta.SubproviderSession(inst), productID, ruleFormat)
+		if err != nil {
+			return nil, err
+		}
+		if err := meta.CacheSet(inst, key, data); err != nil {
+			if !errors.Is(err, akamai.ErrCacheDisabled) {
+				return nil, err
+			}
+		}
+	}
+
+	return newRulesSchema(data)
+}
+
+// fetchRulesSchema fetches the schema of a product and rule format, which the PAPI client in use does not support
+func fetchRulesSchema(ctx context.Context, sess session.Session, productID, ruleFormat string) (json.RawMessage, error) {
+	uri := fmt.Sprintf("/papi/v1/schemas/products/%s/%s", url.PathEscape(productID), url.PathEscape(ruleFormat))
+	var data json.RawMessage
+	if err := execAPI(ctx, sess, http.MethodGet, uri, papiHeader, http.StatusOK, &data); err != nil {
+		return nil, err
+	}
+	return data, nil
+}
+
+func newRulesSchema(data []byte) (*rulesSchema, error) {
+	var root interface{}
+	if err := json.Unmarshal(data, &root); err != nil {
+		return nil, fmt.Errorf("rule format schema is not valid JSON: %s", err)
+	}
+	if _, ok := root.(map[string]interface{}); !ok {
+		return nil, fmt.Errorf("rule format schema is not a JSON object")
+	}
+	return &rulesSchema{root: root}, nil
+}
+
+// validate returns the values of the rules which do not match the schema, in the order of the rules
+func (s *rulesSchema) validate(value interface{}) []rulesSchemaViolation {
+	return s.validateValue(value, s.root, "#")
+}
+
+func (s *rulesSchema) validateValue(value, node interface{}, pointer string) []rulesSchemaViolation {
+	for i := 0; ; i++ {
+		n, ok := node.(map[string]interface{})
+		if !ok {
+			// draft 6 boolean schemas
+			if allowed, ok := node.(bool); ok && !allowed {
+				return violation(pointer, "value is not allowed")
+			}
+			return nil
+		}
+		ref, ok := n["$ref"].(string)
+		if !ok {
+			break
+		}
+		if i == maxRulesSchemaRefs {
+			return nil
+		}
+		if node, ok = s.resolve(ref); !ok {
+			return nil
+		}
+	}
+	n := node.(map[string]interface{})
+
+	if types, ok := n["type"]; ok && !matchesType(value, types) {
+		return violation(pointer, fmt.Sprintf("expected %s, got %s", typeNames(types), jsonType(value)))
+	}
+
+	var violations []rulesSchemaViolation
+	if enum, ok := n["enum"].([]interface{}); ok && !containsValue(enum, value) {
+		violations = append(violations, violation(pointer, enumMessage(value, enum))...)
+	}
+	if c, ok := n["const"]; ok && !reflect.DeepEqual(c, value) {
+		violations = append(violations, violation(pointer, fmt.Sprintf("%s is not allowed, the value must be %s", schemaValue(value), schemaValue(c)))...)
+	}
+
+	switch v := value.(type) {
+	case float64:
+		violations = append(violations, validateNumber(v, n, pointer)...)
+	case string:
+		violations = append(violations, validateString(v, n, pointer)...)
+	case []interface{}:
+		violations = append(violations, s.validateArray(v, n, pointer)...)
+	case map[string]interface{}:
+		violations = append(violations, s.validateObject(v, n, pointer)...)
+	}
+
+	if allOf, ok := n["allOf"].([]interface{}); ok {
+		for _, sub := range allOf {
+			violations = append(violations, s.validateValue(value, sub, pointer)...)
+		}
+	}
+	if anyOf, ok := n["anyOf"].([]interface{}); ok {
+		if matches, best := s.matchSchemas(value, anyOf, pointer); matches == 0 {
+			violations = append(violations, best...)
+		}
+	}
+	if oneOf, ok := n["oneOf"].([]interface{}); ok {
+		matches, best := s.matchSchemas(value, oneOf, pointer)
+		switch {
+		case matches == 0:
+			violations = append(violations, best...)
+		case matches > 1:
+			violations = append(violations, violation(pointer, "value matches more than one of the allowed schemas")...)
+		}
+	}
+	if not, ok := n["not"]; ok && len(s.validateValue(value, not, pointer)) == 0 {
+		violations = append(violations, violation(pointer, "value is not allowed")...)
+	}
+	if cond, ok := n["if"]; ok {
+		if len(s.validateValue(value, cond, pointer)) == 0 {
+			if then, ok := n["then"]; ok {
+				violations = append(violations, s.validateValue(value, then, pointer)...)
+			}
+		} else if els, ok := n["else"]; ok {
+			violations = append(violations, s.validateValue(value, els, pointer)...)
+		}
+	}
+
+	return violations
+}
+
+// matchSchemas returns how many of the schemas the value matches, and the violations of the schema the value is closest to.
+// The closest schema is the one whose shallowest violation is the deepest in the value, as PAPI schemas select behaviors
+// and criteria by their name, so that only the schema of the behavior with the same name does not fail at the name.
+func (s *rulesSchema) matchSchemas(value interface{}, schemas []interface{}, pointer string) (int, []rulesSchemaViolation) {
+	var matches int
+	var best []rulesSchemaViolation
+	bestDepth, ties := -1, 0
+	for _, sub := range schemas {
+		violations := s.validateValue(value, sub, pointer)
+		if len(violations) == 0 {
+			matches++
+			continue
+		}
+		depth := violationsDepth(violations)
+		switch {
+		case depth > bestDepth:
+			best, bestDepth, ties = violations, depth, 1
+		case depth == bestDepth:
+			ties++
+		}
+	}
+	if matches == 0 && ties > 1 {
+		best = violation(pointer, "value does not match any of the allowed schemas")
+	}
+	return matches, best
+}
+
+func (s *rulesSchema) validateArray(value []interface{}, n map[string]interface{}, pointer string) []rulesSchemaViolation {
+	var violations []rulesSchemaViolation
+	if min, ok := n["minItems"].(float64); ok && float64(len(value)) < min {
+		violations = append(violations, violation(pointer, fmt.Sprintf("expected at least %v items, got %d", min, len(value)))...)
+	}
+	if max, ok := n["maxItems"].(float64); ok && float64(len(value)) > max {
+		violations = append(violations, violation(pointer, fmt.Sprintf("expected at most %v items, got %d", max, len(value)))...)
+	}
+	if unique, ok := n["uniqueItems"].(bool); ok && unique {
+		for i := range value {
+			for j := 0; j < i; j++ {
+				if reflect.DeepEqual(value[i], value[j]) {
+					violations = append(violations, violation(itemPointer(pointer, i), fmt.Sprintf("duplicate of item %d", j))...)
+				}
+			}
+		}
+	}
+
+	switch items := n["items"].(type) {
+	case []interface{}:
+		for i, item := range value {
+			if i < len(items) {
+				violations = append(violations, s.validateValue(item, items[i], itemPointer(pointer, i))...)
+				continue
+			}
+			if additional, ok := n["additionalItems"]; ok {
+				violations = append(violations, s.validateValue(item, additional, itemPointer(pointer, i))...)
+			}
+		}
+	case map[string]interface{}, bool:
+		for i, item := range value {
+			violations = append(violations, s.validateValue(item, items, itemPointer(pointer, i))...)
+		}
+	}
+	return violations
+}
+
+func (s *rulesSchema) validateObject(value map[string]interface{}, n map[string]interface{}, pointer string) []rulesSchemaViolation {
+	var violations []rulesSchemaViolation
+	if required, ok := n["required"].([]interface{}); ok {
+		for _, r := range required {
+			name, _ := r.(string)
+			if _, ok := value[name]; !ok {
+				violations = append(violations, violation(pointer, fmt.Sprintf("missing required property %q", name))...)
+			}
+		}
+	}
+
+	properties, _ := n["properties"].(map[string]interface{})
+	patternProperties, _ := n["patternProperties"].(map[string]interface{})
+	additional, hasAdditional := n["additionalProperties"]
+
+	keys := make([]string, 0, len(value))
+	for key := range value {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		propertyPointer := pointer + "/" + escapePointer(key)
+		matched := false
+		if sub, ok := properties[key]; ok {
+			matched = true
+			violations = append(violations, s.validateValue(value[key], sub, propertyPointer)...)
+		}
+		for pattern, sub := range patternProperties {
+			if re := compileSchemaPattern(pattern); re != nil && re.MatchString(key) {
+				matched = true
+				violations = append(violations, s.validateValue(value[key], sub, propertyPointer)...)
+			}
+		}
+		if matched || !hasAdditional {
+			continue
+		}
+		if allowed, ok := additional.(bool); ok {
+			if !allowed {
+				violations = append(violations, violation(propertyPointer, fmt.Sprintf("property %q is not allowed", key))...)
+			}
+			continue
+		}
+		violations = append(violations, s.validateValue(value[key], additional, propertyPointer)...)
+	}
+	return violations
+}
+
+// resolve returns the schema of a reference within the schema, such as #/definitions/type-rule
+func (s *rulesSchema) resolve(ref string) (interface{}, bool) {
+	if !strings.HasPrefix(ref, "#") {
+		return nil, false
+	}
+	node := s.root
+	for _, token := range strings.Split(strings.TrimPrefix(ref[1:], "/"), "/") {
+		if token == "" {
+			continue
+		}
+		if unescaped, err := url.PathUnescape(token); err == nil {
+			token = unescaped
+		}
+		token = strings.NewReplacer("~1", "/", "~0", "~").Replace(token)
+		object, ok := node.(map[string]interface{})
+		if !ok {
+			return nil, false
+		}
+		if node, ok = object[token]; !ok {
+			return nil, false
+		}
+	}
+	return node, true
+}
+
+func validateNumber(value float64, n map[string]interface{}, pointer string) []rulesSchemaViolation {
+	var violations []rulesSchemaViolation
+	if min, ok := n["minimum"].(float64); ok {
+		if exclusive, _ := n["exclusiveMinimum"].(bool); exclusive && value <= min {
+			violations = append(violations, violation(pointer, fmt.Sprintf("%v must be greater than %v", value, min))...)
+		} else if value < min {
+			violations = append(violations, violation(pointer, fmt.Sprintf("%v must be at least %v", value, min))...)
+		}
+	}
+	if max, ok := n["maximum"].(float64); ok {
+		if exclusive, _ := n["exclusiveMaximum"].(bool); exclusive && value >= max {
+			violations = append(violations, violation(pointer, fmt.Sprintf("%v must be less than %v", value, max))...)
+		} else if value > max {
+			violations = append(violations, violation(pointer, fmt.Sprintf("%v must be at most %v", value, max))...)
+		}
+	}
+	return violations
+}
+
+func validateString(value string, n map[string]interface{}, pointer string) []rulesSchemaViolation {
+	var violations []rulesSchemaViolation
+	length := utf8.RuneCountInString(value)
+	if min, ok := n["minLength"].(float64); ok && float64(length) < min {
+		violations = append(violations, violation(pointer, fmt.Sprintf("expected at least %v characters, got %d", min, length))...)
+	}
+	if max, ok := n["maxLength"].(float64); ok && float64(length) > max {
+		violations = append(violations, violation(pointer, fmt.Sprintf("expected at most %v characters, got %d", max, length))...)
+	}
+	if pattern, ok := n["pattern"].(string); ok {
+		if re := compileSchemaPattern(pattern); re != nil && !re.MatchString(value) {
+			violations = append(violations, violation(pointer, fmt.Sprintf("%s does not match the pattern %s", schemaValue(value), pattern))...)
+		}
+	}
+	return violations
+}
+
+func compileSchemaPattern(pattern string) *regexp.Regexp {
+	rulesSchemaPatternsMu.Lock()
+	defer rulesSchemaPatternsMu.Unlock()
+
+	re, ok := rulesSchemaPatterns[pattern]
+	if !ok {
+		re, _ = regexp.Compile(pattern)
+		rulesSchemaPatterns[pattern] = re
+	}
+	return re
+}
+
+func matchesType(value, types interface{}) bool {
+	names, ok := types.([]interface{})
+	if !ok {
+		names = []interface{}{types}
+	}
+	actual := jsonType(value)
+	for _, name := range names {
+		switch name {
+		case actual:
+			return true
+		case "number":
+			if actual == "integer" {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func jsonType(value interface{}) string {
+	switch v := value.(type) {
+	case nil:
+		return "null"
+	case bool:
+		return "boolean"
+	case float64:
+		if v == math.Trunc(v) {
+			return "integer"
+		}
+		return "number"
+	case string:
+		return "string"
+	case []interface{}:
+		return "array"
+	default:
+		return "object"
+	}
+}
+
+func typeNames(types interface{}) string {
+	names, ok := types.([]interface{})
+	if !ok {
+		return fmt.Sprint(types)
+	}
+	values := make([]string, 0, len(names))
+	for _, name := range names {
+		values = append(values, fmt.Sprint(name))
+	}
+	return strings.Join(values, " or ")
+}
+
+func containsValue(values []interface{}, value interface{}) bool {
+	for _, v := range values {
+		if reflect.DeepEqual(v, value) {
+			return true
+		}
+	}
+	return false
+}
+
+// enumMessage lists the allowed values, unless there are too many of them, as for the names of behaviors
+func enumMessage(value interface{}, enum []interface{}) string {
+	if len(enum) > 10 {
+		return fmt.Sprintf("%s is not one of the %d allowed values", schemaValue(value), len(enum))
+	}
+	values := make([]string, 0, len(enum))
+	for _, v := range enum {
+		values = append(values, schemaValue(v))
+	}
+	return fmt.Sprintf("%s is not one of the allowed values %s", schemaValue(value), strings.Join(values, ", "))
+}
+
+func schemaValue(value interface{}) string {
+	data, err := json.Marshal(value)
+	if err != nil {
+		return fmt.Sprint(value)
+	}
+	if len(data) > 64 {
+		return string(data[:61]) + "..."
+	}
+	return string(data)
+}
+
+func violation(pointer, message string) []rulesSchemaViolation {
+	return []rulesSchemaViolation{{Pointer: pointer, Message: message}}
+}
+
+// violationsDepth returns the depth of the shallowest violation, which is where the value stopped matching the schema
+func violationsDepth(violations []rulesSchemaViolation) int {
+	depth := math.MaxInt32
+	for _, v := range violations {
+		if d := strings.Count(v.Pointer, "/"); d < depth {
+			depth = d
+		}
+	}
+	return depth
+}
+
+func itemPointer(pointer string, index int) string {
+	return fmt.Sprintf("%s/%d", pointer, index)
+}
+
+// escapePointer escapes a property name as a JSON pointer token
+func escapePointer(token string) string {
+	return strings.NewReplacer("~", "~0", "/", "~1").Replace(token)
+}
@@ -0,0 +1,280 @@
+package property
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v2/pkg/edgegrid"
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v2/pkg/papi"
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v2/pkg/session"
+	"github.com/apex/log"
+	"github.com/apex/log/handlers/discard"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/stretchr/testify/require"
+	"github.com/tj/assert"
+
+	"github.com/akamai/terraform-provider-akamai/v2/pkg/akamai"
+)
+
+func loadTestRulesSchema(t *testing.T) *rulesSchema {
+	rs, err := newRulesSchema([]byte(loadFixtureString("testdata/TestRulesSchema/prd_Web_App_Accel/v2021-05-05.json")))
+	require.NoError(t, err)
+	return rs
+}
+
+func TestRulesSchemaValidate(t *testing.T) {
+	rs := loadTestRulesSchema(t)
+
+	tests := map[string]struct {
+		rules    string
+		expected []rulesSchemaViolation
+	}{
+		"valid rules": {
+			rules: `{"rules": {"name": "default", "behaviors": [
+				{"name": "origin", "options": {"hostname": "origin.example.com", "httpPort": 80}},
+				{"name": "caching", "options": {"behavior": "MAX_AGE", "mustRevalidate": false, "ttl": "1d"}}
+			], "children": [{"name": "static", "criteria": [{"name": "fileExtension", "options": {"values": ["css"]}}]}],
+			"variables": [{"name": "PMUSER_ORIGIN", "value": "origin.example.com"}]}}`,
+		},
+		"missing rules": {
+			rules:    `{"comments": "empty"}`,
+			expected: []rulesSchemaViolation{{Pointer: "#", Message: `missing required property "rules"`}},
+		},
+		"unknown rule property": {
+			rules:    `{"rules": {"name": "default", "behaviours": []}}`,
+			expected: []rulesSchemaViolation{{Pointer: "#/rules/behaviours", Message: `property "behaviours" is not allowed`}},
+		},
+		"wrong option type": {
+			rules:    `{"rules": {"name": "default", "behaviors": [{"name": "origin", "options": {"hostname": "origin.example.com", "httpPort": "80"}}]}}`,
+			expected: []rulesSchemaViolation{{Pointer: "#/rules/behaviors/0/options/httpPort", Message: "expected integer, got string"}},
+		},
+		"option out of range": {
+			rules:    `{"rules": {"name": "default", "behaviors": [{"name": "origin", "options": {"hostname": "origin.example.com", "httpPort": 70000}}]}}`,
+			expected: []rulesSchemaViolation{{Pointer: "#/rules/behaviors/0/options/httpPort", Message: "70000 must be at most 65535"}},
+		},
+		"option not in enum": {
+			rules:    `{"rules": {"name": "default", "children": [{"name": "static", "behaviors": [{"name": "caching", "options": {"behavior": "FOREVER"}}]}]}}`,
+			expected: []rulesSchemaViolation{{Pointer: "#/rules/children/0/behaviors/0/options/behavior", Message: `"FOREVER" is not one of the allowed values "MAX_AGE", "NO_STORE", "BYPASS_CACHE"`}},
+		},
+		"option not matching pattern": {
+			rules:    `{"rules": {"name": "default", "behaviors": [{"name": "caching", "options": {"ttl": "1 day"}}]}}`,
+			expected: []rulesSchemaViolation{{Pointer: "#/rules/behaviors/0/options/ttl", Message: `"1 day" does not match the pattern ^[0-9]+[smhd]$`}},
+		},
+		"unknown behavior": {
+			rules:    `{"rules": {"name": "default", "behaviors": [{"name": "cachng", "options": {}}]}}`,
+			expected: []rulesSchemaViolation{{Pointer: "#/rules/behaviors/0", Message: "value does not match any of the allowed schemas"}},
+		},
+		"several violations": {
+			rules: `{"rules": {"name": "", "criteriaMustSatisfy": "none", "children": [{"name": "static", "criteria": [{"name": "fileExtension", "options": {"values": []}}]}]}}`,
+			expected: []rulesSchemaViolation{
+				{Pointer: "#/rules/children/0/criteria/0/options/values", Message: "expected at least 1 items, got 0"},
+				{Pointer: "#/rules/criteriaMustSatisfy", Message: `"none" is not one of the allowed values "all", "any"`},
+				{Pointer: "#/rules/name", Message: "expected at least 1 characters, got 0"},
+			},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			var value interface{}
+			require.NoError(t, json.Unmarshal([]byte(test.rules), &value))
+			assert.Equal(t, test.expected, rs.validate(value))
+		})
+	}
+}
+
+func TestRulesSchemaResolve(t *testing.T) {
+	rs, err := newRulesSchema([]byte(`{"definitions": {"a/b": {"type": "string"}, "loop": {"$ref": "#/definitions/loop"}},
+		"properties": {"escaped": {"$ref": "#/definitions/a~1b"}, "loop": {"$ref": "#/definitions/loop"}, "remote": {"$ref": "other.json#/a"}}}`))
+	require.NoError(t, err)
+
+	assert.Equal(t, []rulesSchemaViolation{{Pointer: "#/escaped", Message: "expected string, got integer"}},
+		rs.validate(map[string]interface{}{"escaped": float64(1)}))
+	assert.Empty(t, rs.validate(map[string]interface{}{"loop": "value", "remote": "value"}))
+}
+
+func TestNewRulesSchema(t *testing.T) {
+	_, err := newRulesSchema([]byte(`{"type":`))
+	assert.Error(t, err)
+	_, err = newRulesSchema([]byte(`[]`))
+	assert.Error(t, err)
+}
+
+func TestGetRulesSchemaFromDir(t *testing.T) {
+	orig := inst.rulesSchemaDir
+	inst.rulesSchemaDir = "testdata/TestRulesSchema"
+	defer func() {
+		inst.rulesSchemaDir = orig
+		resetRulesSchemas()
+	}()
+
+	rs, err := getRulesSchema(context.Background(), nil, "Web_App_Accel", "v2021-05-05")
+	require.NoError(t, err)
+	assert.Equal(t, loadTestRulesSchema(t), rs)
+
+	_, err = getRulesSchema(context.Background(), nil, "prd_Web_App_Accel", "v2015-08-17")
+	assert.Error(t, err)
+}
+
+func TestRulesSchemaCustomDiff(t *testing.T) {
+	orig := inst.rulesSchemaDir
+	inst.rulesSchemaDir = ""
+	defer func() {
+		inst.rulesSchemaDir = orig
+		resetRulesSchemas()
+	}()
+
+	var fetches int
+	mockServer := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/papi/v1/schemas/products/prd_Web_App_Accel/v2021-05-05", r.URL.String())
+		fetches++
+		w.WriteHeader(http.StatusOK)
+		_, err := w.Write([]byte(loadFixtureString("testdata/TestRulesSchema/prd_Web_App_Accel/v2021-05-05.json")))
+		assert.NoError(t, err)
+	}))
+	defer mockServer.Close()
+
+	serverURL, err := url.Parse(mockServer.URL)
+	require.NoError(t, err)
+	certPool := x509.NewCertPool()
+	certPool.AddCert(mockServer.Certificate())
+	httpClient := &http.Client{Transport: &http.Transport{TLSClientConfig: &tls.Config{RootCAs: certPool}}}
+	sess, err := session.New(session.WithClient(httpClient), session.WithSigner(&edgegrid.Config{Host: serverURL.Host}))
+	require.NoError(t, err)
+	meta := &noCacheMeta{sess: sess}
+
+	res := &schema.Resource{
+		Schema: map[string]*schema.Schema{
+			"product_id":  {Type: schema.TypeString, Optional: true},
+			"rule_format": {Type: schema.TypeString, Optional: true},
+			"rules":       {Type: schema.TypeString, Optional: true},
+		},
+		CustomizeDiff: rulesSchemaCustomDiff,
+	}
+	config := func(rules string) *terraform.ResourceConfig {
+		return terraform.NewResourceConfigRaw(map[string]interface{}{
+			"product_id":  "prd_Web_App_Accel",
+			"rule_format": "v2021-05-05",
+			"rules":       rules,
+		})
+	}
+
+	_, err = res.Diff(context.Background(), nil, config(`{"rules": {"name": "default", "behaviors": [
+		{"name": "origin", "options": {"hostname": "origin.example.com", "httpPort": 80}}
+	]}}`), meta)
+	require.NoError(t, err)
+
+	_, err = res.Diff(context.Background(), nil, config(`{"rules": {"name": "default", "behaviors": [
+		{"name": "caching", "options": {"behavior": "MAX_AGE", "maxAge": "1d", "ttl": "1d"}},
+		{"name": "origin", "options": {"hostname": "origin.example.com", "httpPort": "80"}}
+	]}}`), meta)
+	require.True(t, errors.Is(err, ErrRulesSchema), "want: %s; got: %s", ErrRulesSchema, err)
+	assert.Contains(t, err.Error(), `#/rules/behaviors/0/options/maxAge: property "maxAge" is not allowed`)
+
+	assert.Equal(t, 1, fetches)
+}
+
+func TestRulesSchemaDiags(t *testing.T) {
+	diags := rulesSchemaDiags("v2021-05-05", []rulesSchemaViolation{
+		{Pointer: "#/rules/behaviors/0/options/maxAge", Message: `property "maxAge" is not allowed`},
+		{Pointer: "#/rules/behaviors/1/options/httpPort", Message: "expected integer, got string"},
+		{Pointer: "#/rules/behaviors/0/options/maxAge", Message: "value is not allowed"},
+	})
+	require.Len(t, diags, 2)
+	assert.Equal(t, diag.Error, diags[0].Severity)
+	assert.Equal(t, "rules do not match the rule format schema v2021-05-05 at #/rules/behaviors/0/options/maxAge", diags[0].Summary)
+	assert.Equal(t, "property \"maxAge\" is not allowed\nvalue is not allowed", diags[0].Detail)
+	assert.Equal(t, "rules do not match the rule format schema v2021-05-05 at #/rules/behaviors/1/options/httpPort", diags[1].Summary)
+
+	assert.Empty(t, rulesSchemaDiags("v2021-05-05", nil))
+}
+
+func TestFetchRulesSchema(t *testing.T) {
+	tests := map[string]struct {
+		responseStatus   int
+		responseBody     string
+		withError        bool
+		expectedErrTitle string
+	}{
+		"200 OK": {
+			responseStatus: http.StatusOK,
+			responseBody:   `{"type": "object"}`,
+		},
+		"404 not found": {
+			responseStatus:   http.StatusNotFound,
+			responseBody:     `{"type": "not-found", "title": "Not Found", "status": 404}`,
+			withError:        true,
+			expectedErrTitle: "Not Found",
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			mockServer := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				assert.Equal(t, "/papi/v1/schemas/products/prd_Web_App_Accel/v2021-05-05", r.URL.String())
+				assert.Equal(t, http.MethodGet, r.Method)
+				assert.Equal(t, "true", r.Header.Get("PAPI-Use-Prefixes"))
+				w.WriteHeader(test.responseStatus)
+				_, err := w.Write([]byte(test.responseBody))
+				assert.NoError(t, err)
+			}))
+			defer mockServer.Close()
+
+			serverURL, err := url.Parse(mockServer.URL)
+			require.NoError(t, err)
+			certPool := x509.NewCertPool()
+			certPool.AddCert(mockServer.Certificate())
+			httpClient := &http.Client{Transport: &http.Transport{TLSClientConfig: &tls.Config{RootCAs: certPool}}}
+			sess, err := session.New(session.WithClient(httpClient), session.WithSigner(&edgegrid.Config{Host: serverURL.Host}))
+			require.NoError(t, err)
+
+			data, err := fetchRulesSchema(context.Background(), sess, "prd_Web_App_Accel", "v2021-05-05")
+			if test.withError {
+				var e *papi.Error
+				require.True(t, errors.As(err, &e))
+				assert.Equal(t, test.expectedErrTitle, e.Title)
+				return
+			}
+			require.NoError(t, err)
+			assert.JSONEq(t, test.responseBody, string(data))
+		})
+	}
+}
+
+// noCacheMeta is the meta of a provider whose cache is disabled
+type noCacheMeta struct {
+	akamai.OperationMeta
+	sess session.Session
+}
+
+func (m *noCacheMeta) Log(...interface{}) log.Interface {
+	return &log.Logger{Handler: discard.New()}
+}
+
+func (m *noCacheMeta) SubproviderSession(akamai.Subprovider) session.Session {
+	return m.sess
+}
+
+func (m *noCacheMeta) CacheGet(akamai.Subprovider, string, interface{}) error {
+	return akamai.ErrCacheDisabled
+}
+
+func (m *noCacheMeta) CacheSet(akamai.Subprovider, string, interface{}) error {
+	return akamai.ErrCacheDisabled
+}
+
+// resetRulesSchemas forgets the schemas loaded by a test
+func resetRulesSchemas() {
+	rulesSchemasMu.Lock()
+	defer rulesSchemasMu.Unlock()
+	rulesSchemas = map[string]*rulesSchemaEntry{}
+}
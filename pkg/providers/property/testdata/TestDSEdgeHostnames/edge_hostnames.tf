@@ -0,0 +1,8 @@
+provider "akamai" {
+  edgerc = "~/.edgerc"
+}
+
+data "akamai_edge_hostnames" "test" {
+  record_name_substring = "example"
+  dns_zone              = "edgekey.net"
+}
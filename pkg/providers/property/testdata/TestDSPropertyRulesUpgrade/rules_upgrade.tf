@@ -0,0 +1,8 @@
+provider "akamai" {
+  edgerc = "~/.edgerc"
+}
+
+data "akamai_property_rules_upgrade" "upgrade" {
+  property_id = "prp_2"
+  rule_format = "v2021-05-05"
+}
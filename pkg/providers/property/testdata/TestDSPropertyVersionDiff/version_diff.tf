@@ -0,0 +1,9 @@
+provider "akamai" {
+  edgerc = "~/.edgerc"
+}
+
+data "akamai_property_version_diff" "diff" {
+  property_id = "prp_1"
+  old_version = "production"
+  new_version = "latest"
+}
@@ -0,0 +1,8 @@
+provider "akamai" {
+  edgerc = "~/.edgerc"
+}
+
+data "akamai_property_activations" "test" {
+  property_id = "prp_test"
+  network     = "PROD"
+}
@@ -0,0 +1,9 @@
+provider "akamai" {
+  edgerc = "~/.edgerc"
+}
+
+data "akamai_property_include_parents" "test" {
+  include_id  = "inc_1"
+  contract_id = "ctr_1"
+  group_id    = "grp_1"
+}
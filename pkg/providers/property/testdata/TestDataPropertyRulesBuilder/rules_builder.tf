@@ -0,0 +1,49 @@
+provider "akamai" {
+  edgerc = "~/.edgerc"
+}
+
+data "akamai_property_rules_builder" "default" {
+  rule_format = "v2021-05-05"
+  product_id  = "prd_Web_App_Accel"
+  rules {
+    name      = "default"
+    is_secure = true
+    behavior {
+      name = "origin"
+      options_json = jsonencode({
+        hostname   = "origin.example.com"
+        httpPort   = 80
+        originType = "CUSTOMER"
+      })
+    }
+    variable {
+      name  = "PMUSER_ORIGIN"
+      value = "origin.example.com"
+    }
+    children = [data.akamai_property_rules_builder.static.json]
+  }
+}
+
+data "akamai_property_rules_builder" "static" {
+  rule_format = "v2021-05-05"
+  product_id  = "prd_Web_App_Accel"
+  rules {
+    name                  = "static"
+    criteria_must_satisfy = "any"
+    criterion {
+      name = "fileExtension"
+      options_json = jsonencode({
+        matchOperator = "IS_ONE_OF"
+        values        = ["css", "js"]
+      })
+    }
+    behavior {
+      name = "caching"
+      options_json = jsonencode({
+        behavior       = "MAX_AGE"
+        mustRevalidate = false
+        ttl            = "1d"
+      })
+    }
+  }
+}
@@ -0,0 +1,22 @@
+provider "akamai" {
+  edgerc = "~/.edgerc"
+}
+
+resource "akamai_property" "test" {
+  name        = "test_property"
+  contract_id = "ctr_0"
+  group_id    = "grp_0"
+  product_id  = "prd_0"
+
+  clone_from {
+    property_id    = "1"
+    version        = 3
+    copy_hostnames = true
+  }
+
+  hostnames {
+    cname_from             = "from.test.domain"
+    cname_to               = "to.test.domain"
+    cert_provisioning_type = "DEFAULT"
+  }
+}
@@ -0,0 +1,17 @@
+provider "akamai" {
+  edgerc = "~/.edgerc"
+}
+
+resource "akamai_property" "test" {
+  name        = "test_property"
+  contract_id = "ctr_0"
+  group_id    = "grp_0"
+  product_id  = "prd_0"
+
+  clone_from {
+    property_id    = "1"
+    version        = 3
+    etag           = "a9dfe78cf93090516bde891d009eaf57"
+    copy_hostnames = true
+  }
+}
@@ -0,0 +1,9 @@
+provider "akamai" {
+  edgerc = "~/.edgerc"
+}
+
+resource "akamai_property_fallback" "test" {
+  property_id = "prp_test"
+  contact     = ["user@example.com"]
+  note        = "roll back release 2"
+}
@@ -0,0 +1,21 @@
+provider "akamai" {
+  edgerc = "~/.edgerc"
+}
+
+resource "akamai_property_hostnames" "test" {
+  property_id = "prp_1"
+  contract_id = "ctr_1"
+  group_id    = "grp_1"
+  network     = "production"
+  note        = "vanity hostnames"
+
+  hostnames {
+    cname_from       = "b.example.com"
+    edge_hostname_id = "ehn_1"
+  }
+  hostnames {
+    cname_from             = "c.example.com"
+    edge_hostname_id       = "ehn_2"
+    cert_provisioning_type = "DEFAULT"
+  }
+}
@@ -0,0 +1,13 @@
+provider "akamai" {
+  edgerc = "~/.edgerc"
+}
+
+resource "akamai_property_include" "test" {
+  contract_id = "ctr_1"
+  group_id    = "grp_1"
+  name        = "shared"
+  type        = "MICROSERVICES"
+  product_id  = "prd_Web_App_Accel"
+  rule_format = "v2021-05-05"
+  rules       = file("testdata/TestResPropertyInclude/rules.json")
+}
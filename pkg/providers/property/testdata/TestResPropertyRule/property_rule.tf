@@ -0,0 +1,9 @@
+provider "akamai" {
+  edgerc = "~/.edgerc"
+}
+
+resource "akamai_property_rule" "test" {
+  property_id = "1"
+  parent_path = "Performance"
+  rules       = file("testdata/TestResPropertyRule/rule.json")
+}
@@ -0,0 +1,14 @@
+provider "akamai" {
+  edgerc = "~/.edgerc"
+}
+
+resource "akamai_edge_hostname" "edgehostname" {
+  contract_id         = "ctr_2"
+  group_id            = "grp_2"
+  product_id          = "prd_2"
+  edge_hostname       = "test.edgesuite.net"
+  ip_behavior         = "IPV4"
+  ttl                 = 300
+  status_update_email = ["user@example.com"]
+  poll_interval       = 1
+}
@@ -20,3 +20,26 @@ func AddPrefix(str, pre string) string {
 func GetIntID(str, prefix string) (int, error) {
 	return strconv.Atoi(strings.TrimPrefix(str, prefix))
 }
+
+const (
+	// ContractPrefix is the prefix of contract IDs in the Property Manager API
+	ContractPrefix = "ctr_"
+
+	// GroupPrefix is the prefix of group IDs in the Property Manager API
+	GroupPrefix = "grp_"
+)
+
+// NormalizeID returns the id with the prefix if withPrefix is true, or without it otherwise,
+// no matter if the id was given with the prefix or not.
+func NormalizeID(id, prefix string, withPrefix bool) string {
+	id = AddPrefix(id, prefix)
+	if withPrefix {
+		return id
+	}
+	return strings.TrimPrefix(id, prefix)
+}
+
+// NormalizeIntID returns the numeric value of the id, which can be given with or without the prefix.
+func NormalizeIntID(id, prefix string) (int, error) {
+	return GetIntID(AddPrefix(id, prefix), prefix)
+}
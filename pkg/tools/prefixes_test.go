@@ -44,3 +44,47 @@ func TestGetIntID(t *testing.T) {
 		})
 	}
 }
+
+func TestNormalizeID(t *testing.T) {
+	tests := map[string]struct {
+		givenID, givenPrefix string
+		withPrefix           bool
+		expected             string
+	}{
+		"add prefix":                     {"C-0N7RAC7", ContractPrefix, true, "ctr_C-0N7RAC7"},
+		"keep prefix":                    {"ctr_C-0N7RAC7", ContractPrefix, true, "ctr_C-0N7RAC7"},
+		"remove prefix":                  {"grp_12345", GroupPrefix, false, "12345"},
+		"no prefix to remove":            {"12345", GroupPrefix, false, "12345"},
+		"blank id":                       {"", GroupPrefix, true, ""},
+		"other prefix is not recognized": {"prp_123", GroupPrefix, false, "prp_123"},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, test.expected, NormalizeID(test.givenID, test.givenPrefix, test.withPrefix))
+		})
+	}
+}
+
+func TestNormalizeIntID(t *testing.T) {
+	tests := map[string]struct {
+		givenID   string
+		expected  int
+		withError bool
+	}{
+		"with prefix":    {"grp_123", 123, false},
+		"without prefix": {"123", 123, false},
+		"invalid id":     {"grp_abc", 0, true},
+		"blank id":       {"", 0, true},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			res, err := NormalizeIntID(test.givenID, GroupPrefix)
+			if test.withError {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, test.expected, res)
+		})
+	}
+}